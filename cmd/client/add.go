@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"log"
 )
 
@@ -20,30 +21,43 @@ func runCreateRequest(ctx context.Context) {
 		isAbsentParam = true
 		log.Println("Owner is not set")
 	}
-	if grpcConfig.StartTime == "" {
+	if grpcConfig.AllDay && grpcConfig.Date == "" {
+		isAbsentParam = true
+		log.Println("Date is not set")
+	}
+	if !grpcConfig.AllDay && grpcConfig.StartTime == "" {
 		isAbsentParam = true
 		log.Println("StartTime is not set")
 	}
-	if grpcConfig.EndTime == "" {
+	if !grpcConfig.AllDay && grpcConfig.EndTime == "" {
 		isAbsentParam = true
 		log.Println("EndTime is not set")
 	}
 	if isAbsentParam {
 		log.Fatal("Some parameters is not set")
 	}
-	st, err := grpcConfig.GetStartTime()
+	st, et, err := getEventTimes()
 	if err != nil {
 		log.Fatal(err)
 	}
-	et, err := grpcConfig.GetEndTime()
+	transparency, err := grpcConfig.GetTransparency()
+	if err != nil {
+		log.Fatal(err)
+	}
+	reminders, err := grpcConfig.GetReminders()
 	if err != nil {
 		log.Fatal(err)
 	}
 	req := &api.CreateEventRequest{
-		Title:     grpcConfig.Title,
-		Text:      grpcConfig.Text,
-		StartTime: st,
-		EndTime:   et,
+		Title:        grpcConfig.Title,
+		Text:         grpcConfig.Text,
+		StartTime:    st,
+		EndTime:      et,
+		Recurrence:   grpcConfig.Recurrence,
+		Transparency: transparency,
+		AllDay:       grpcConfig.AllDay,
+		TimeZone:     eventTimeZone(),
+		Reminders:    reminders,
 	}
 	resp, err := grpcClient.CreateEvent(ctx, req)
 	if err != nil {
@@ -54,3 +68,27 @@ func runCreateRequest(ctx context.Context) {
 	}
 	log.Println(resp.GetEvent().Id)
 }
+
+// getEventTimes returns start and end time of event, or dates of all-day event
+func getEventTimes() (*timestamp.Timestamp, *timestamp.Timestamp, error) {
+	if grpcConfig.AllDay {
+		return grpcConfig.GetDates()
+	}
+	st, err := grpcConfig.GetStartTime()
+	if err != nil {
+		return nil, nil, err
+	}
+	et, err := grpcConfig.GetEndTime()
+	if err != nil {
+		return nil, nil, err
+	}
+	return st, et, nil
+}
+
+// eventTimeZone returns time zone of created or updated event, all-day events don't have it
+func eventTimeZone() string {
+	if grpcConfig.AllDay {
+		return ""
+	}
+	return grpcConfig.TimeZone
+}
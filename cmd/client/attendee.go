@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"log"
+	"strings"
+)
+
+func runInviteAttendeesRequest(ctx context.Context) {
+	isAbsentParam := false
+	if grpcConfig.Id == "" {
+		isAbsentParam = true
+		log.Println("Id is not set")
+	}
+	if len(grpcConfig.Attendees) == 0 {
+		isAbsentParam = true
+		log.Println("Attendees are not set")
+	}
+	if isAbsentParam {
+		log.Fatal("Some parameters is not set")
+	}
+	resp, err := grpcClient.InviteAttendees(ctx, &api.InviteAttendeesRequest{
+		Id:            grpcConfig.Id,
+		Attendees:     grpcConfig.Attendees,
+		CalendarOwner: grpcConfig.CalendarOwner,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if resp.GetError() != "" {
+		log.Fatal(resp.GetError())
+	}
+	log.Println(printEventsList([]*api.Event{resp.GetEvent()}))
+}
+
+func runRespondToInvitationRequest(ctx context.Context) {
+	if grpcConfig.Id == "" {
+		log.Fatal("Id is not set")
+	}
+	status, err := grpcConfig.GetStatus()
+	if err != nil {
+		log.Fatal(err)
+	}
+	resp, err := grpcClient.RespondToInvitation(ctx, &api.RespondToInvitationRequest{
+		Id:     grpcConfig.Id,
+		Status: status,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if resp.GetError() != "" {
+		log.Fatal(resp.GetError())
+	}
+	log.Printf("Responded %s to event `%s`", grpcConfig.Status, grpcConfig.Id)
+}
+
+func printAttendees(attendees []*api.Attendee) string {
+	res := make([]string, 0, len(attendees))
+	for _, a := range attendees {
+		res = append(res, fmt.Sprintf("%s (%s)", a.Name, strings.ToLower(a.Status.String())))
+	}
+	return strings.Join(res, ", ")
+}
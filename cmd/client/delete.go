@@ -10,8 +10,19 @@ func runDeleteRequest(ctx context.Context) {
 	if grpcConfig.Id == "" {
 		log.Printf("Id is not set, will purge all events older 1 year")
 	}
+	scope, err := grpcConfig.GetScope()
+	if err != nil {
+		log.Fatal(err)
+	}
+	ot, err := grpcConfig.GetOccurrence()
+	if err != nil {
+		log.Fatal(err)
+	}
 	req := &api.DeleteEventRequest{
-		Id: grpcConfig.Id,
+		Id:                  grpcConfig.Id,
+		Scope:               scope,
+		OccurrenceStartTime: ot,
+		CalendarOwner:       grpcConfig.CalendarOwner,
 	}
 	resp, err := grpcClient.DeleteEvent(ctx, req)
 	if err != nil {
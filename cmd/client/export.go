@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"io/ioutil"
+	"log"
+	"os"
+)
+
+func runExportRequest(ctx context.Context) {
+	if grpcConfig.Format != "ics" {
+		log.Fatalf("Export format `%s` is not supported", grpcConfig.Format)
+	}
+	if grpcConfig.StartTime == "" {
+		log.Fatal("StartTime is not set")
+	}
+	st, err := grpcConfig.GetStartTime()
+	if err != nil {
+		log.Fatal(err)
+	}
+	req := &api.ExportEventsRequest{
+		StartTime: st,
+	}
+	if grpcConfig.EndTime != "" {
+		if req.EndTime, err = grpcConfig.GetEndTime(); err != nil {
+			log.Fatal(err)
+		}
+	}
+	resp, err := grpcClient.ExportEvents(ctx, req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if grpcConfig.Output == "" {
+		if _, err := os.Stdout.Write(resp.GetCalendar()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if err := ioutil.WriteFile(grpcConfig.Output, resp.GetCalendar(), 0644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Events are exported to %s", grpcConfig.Output)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes"
+	"log"
+)
+
+func runCreateFeedTokenRequest(ctx context.Context) {
+	resp, err := grpcClient.CreateFeedToken(ctx, &api.CreateFeedTokenRequest{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(printFeedTokens([]*api.FeedToken{resp.GetFeedToken()}))
+}
+
+func runRevokeFeedTokenRequest(ctx context.Context) {
+	if grpcConfig.FeedToken == "" {
+		log.Fatal("FeedToken is not set")
+	}
+	resp, err := grpcClient.RevokeFeedToken(ctx, &api.RevokeFeedTokenRequest{
+		Token: grpcConfig.FeedToken,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if resp.GetError() != "" {
+		log.Fatal(resp.GetError())
+	}
+}
+
+func runListFeedTokensRequest(ctx context.Context) {
+	resp, err := grpcClient.ListFeedTokens(ctx, &api.ListFeedTokensRequest{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(printFeedTokens(resp.GetFeedTokens()))
+}
+
+func printFeedTokens(tokens []*api.FeedToken) string {
+	var res string
+	for _, t := range tokens {
+		ct, _ := ptypes.Timestamp(t.CreatedAt)
+		res += fmt.Sprintf(`
+**************************
+Token: %s
+Path: %s
+Created: %s
+`, t.Token, t.Path, ct)
+	}
+	return res
+}
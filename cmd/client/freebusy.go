@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"log"
+)
+
+func runFreeBusyRequest(ctx context.Context) {
+	isAbsentParam := false
+	if grpcConfig.StartTime == "" {
+		isAbsentParam = true
+		log.Println("StartTime is not set")
+	}
+	if grpcConfig.EndTime == "" {
+		isAbsentParam = true
+		log.Println("EndTime is not set")
+	}
+	if isAbsentParam {
+		log.Fatal("Some parameters is not set")
+	}
+	st, err := grpcConfig.GetStartTime()
+	if err != nil {
+		log.Fatal(err)
+	}
+	et, err := grpcConfig.GetEndTime()
+	if err != nil {
+		log.Fatal(err)
+	}
+	owners := grpcConfig.Owners
+	if len(owners) == 0 {
+		owners = []string{grpcConfig.Owner}
+	}
+	resp, err := grpcClient.GetFreeBusy(ctx, &api.GetFreeBusyRequest{
+		Owners:    owners,
+		StartTime: st,
+		EndTime:   et,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(printFreeBusy(resp.GetCalendars()))
+}
+
+func printFreeBusy(calendars []*api.FreeBusy) string {
+	var res string
+	for _, c := range calendars {
+		res += fmt.Sprintf(`
+**************************
+Owner: %s
+`, c.Owner)
+		for _, b := range c.Busy {
+			st, et := viewerTime(b.StartTime), viewerTime(b.EndTime)
+			res += fmt.Sprintf("Busy: %s - %s\n", st, et)
+		}
+	}
+	return res
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"github.com/Brialius/calendar/internal/grpc/api"
-	"github.com/golang/protobuf/ptypes"
 	"log"
 )
 
@@ -13,7 +12,8 @@ func runGetRequest(ctx context.Context) {
 		log.Fatal("Id is not set")
 	}
 	req := &api.GetEventRequest{
-		Id: grpcConfig.Id,
+		Id:            grpcConfig.Id,
+		CalendarOwner: grpcConfig.CalendarOwner,
 	}
 	resp, err := grpcClient.GetEvent(ctx, req)
 	if err != nil {
@@ -26,16 +26,16 @@ func runGetRequest(ctx context.Context) {
 }
 
 func printEvent(event *api.Event) string {
-	st, _ := ptypes.Timestamp(event.StartTime)
-	et, _ := ptypes.Timestamp(event.EndTime)
+	st, et := viewerTime(event.StartTime), viewerTime(event.EndTime)
 	res := fmt.Sprintf(`
 **************************
 Id: %s
 title: %s
 From: %s, To: %s
+Recurrence: %s
 Owner: %s
 ---
 %s
-`, event.Id, event.Title, st, et, grpcConfig.Owner, event.Text)
+`, event.Id, event.Title, st, et, event.Recurrence, grpcConfig.Owner, event.Text)
 	return res
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes"
+	"log"
+)
+
+func runCreateGrantRequest(ctx context.Context) {
+	if grpcConfig.Grantee == "" {
+		log.Fatal("Grantee is not set")
+	}
+	role, err := grpcConfig.GetRole()
+	if err != nil {
+		log.Fatal(err)
+	}
+	resp, err := grpcClient.CreateGrant(ctx, &api.CreateGrantRequest{
+		Grantee: grpcConfig.Grantee,
+		Role:    role,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if resp.GetError() != "" {
+		log.Fatal(resp.GetError())
+	}
+	log.Println(printGrants([]*api.Grant{resp.GetGrant()}))
+}
+
+func runRevokeGrantRequest(ctx context.Context) {
+	if grpcConfig.Grantee == "" {
+		log.Fatal("Grantee is not set")
+	}
+	resp, err := grpcClient.RevokeGrant(ctx, &api.RevokeGrantRequest{
+		Grantee: grpcConfig.Grantee,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if resp.GetError() != "" {
+		log.Fatal(resp.GetError())
+	}
+}
+
+func runListGrantsRequest(ctx context.Context) {
+	resp, err := grpcClient.ListGrants(ctx, &api.ListGrantsRequest{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(printGrants(resp.GetGrants()))
+}
+
+func printGrants(grants []*api.Grant) string {
+	var res string
+	for _, g := range grants {
+		ct, _ := ptypes.Timestamp(g.CreatedAt)
+		res += fmt.Sprintf(`
+**************************
+Grantee: %s
+Role: %s
+Created: %s
+`, g.Grantee, g.Role, ct)
+	}
+	return res
+}
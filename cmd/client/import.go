@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes"
+	"io/ioutil"
+	"log"
+)
+
+func runImportRequest(ctx context.Context, fileName string) {
+	calendar, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	req := &api.ImportEventsRequest{
+		Calendar: calendar,
+	}
+	resp, err := grpcClient.ImportEvents(ctx, req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println(printImportResults(resp.GetResults()))
+}
+
+func printImportResults(results []*api.ImportResult) string {
+	var res string
+	counts := make(map[api.ImportStatus]int)
+	for _, r := range results {
+		counts[r.Status]++
+		title := r.Title
+		if r.OriginalStartTime != nil {
+			ot, _ := ptypes.Timestamp(r.OriginalStartTime)
+			title = fmt.Sprintf("%s (occurrence at %s)", title, ot)
+		}
+		switch r.Status {
+		case api.ImportStatus_REJECTED:
+			res += fmt.Sprintf("\n%s: `%s` uid: %s - %s", r.Status, title, r.Uid, r.Reason)
+		default:
+			res += fmt.Sprintf("\n%s: `%s` uid: %s, id: %s", r.Status, title, r.Uid, r.EventId)
+		}
+	}
+	res += fmt.Sprintf("\n\nCreated: %d, duplicates: %d, rejected: %d", counts[api.ImportStatus_CREATED],
+		counts[api.ImportStatus_DUPLICATE], counts[api.ImportStatus_REJECTED])
+	return res
+}
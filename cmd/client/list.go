@@ -3,9 +3,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"github.com/Brialius/calendar/internal/config"
 	"github.com/Brialius/calendar/internal/grpc/api"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"log"
+	"strings"
+	"time"
 )
 
 func runListRequest(ctx context.Context) {
@@ -26,7 +31,13 @@ func runListRequest(ctx context.Context) {
 		log.Fatal(err)
 	}
 	req := &api.ListEventsRequest{
-		StartTime: st,
+		StartTime:     st,
+		CalendarOwner: grpcConfig.CalendarOwner,
+	}
+	if grpcConfig.EndTime != "" {
+		if req.EndTime, err = grpcConfig.GetEndTime(); err != nil {
+			log.Fatal(err)
+		}
 	}
 	resp, err := grpcClient.ListEvents(ctx, req)
 	if err != nil {
@@ -38,17 +49,46 @@ func runListRequest(ctx context.Context) {
 func printEventsList(events []*api.Event) string {
 	var res string
 	for _, e := range events {
-		st, _ := ptypes.Timestamp(e.StartTime)
-		et, _ := ptypes.Timestamp(e.EndTime)
+		st, et := viewerTime(e.StartTime), viewerTime(e.EndTime)
+		period := fmt.Sprintf("From: %s, To: %s", st, et)
+		if e.AllDay {
+			// dates of all-day events don't depend on time zone of viewer
+			st, _ := ptypes.Timestamp(e.StartTime)
+			et, _ := ptypes.Timestamp(e.EndTime)
+			period = fmt.Sprintf("All day: %s - %s", st.Format(config.DateLayout), et.AddDate(0, 0, -1).Format(config.DateLayout))
+		}
 		res += fmt.Sprintf(`
 **************************
 Id: %s
 title: %s
-From: %s, To: %s
+%s
+Time zone: %s
+Reminders: %s
+Recurrence: %s
+Transparency: %s
 Owner: %s
+Attendees: %s
 ---
 %s
-`, e.Id, e.Title, st, et, grpcConfig.Owner, e.Text)
+`, e.Id, e.Title, period, e.TimeZone, printReminders(e.Reminders), e.Recurrence, strings.ToLower(e.Transparency.String()), e.Owner, printAttendees(e.Attendees), e.Text)
 	}
 	return res
 }
+
+func printReminders(reminders []*duration.Duration) string {
+	res := make([]string, 0, len(reminders))
+	for _, r := range reminders {
+		d, _ := ptypes.Duration(r)
+		res = append(res, d.String())
+	}
+	return strings.Join(res, ", ")
+}
+
+// viewerTime converts timestamp to time zone of the client
+func viewerTime(ts *timestamp.Timestamp) time.Time {
+	t, _ := ptypes.Timestamp(ts)
+	if loc, err := grpcConfig.GetLocation(); err == nil {
+		return t.In(loc)
+	}
+	return t
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 	"log"
 	"os"
@@ -21,14 +22,14 @@ const tsLayout = "2006-01-02T15:04:05"
 const ReqTimeout = time.Second * 10
 
 var RootCmd = &cobra.Command{
-	Use:       "client [add, delete, update, list]",
+	Use:       "client [add, delete, update, list, export, import file.ics, create-feed, revoke-feed, list-feeds, grant, revoke-grant, list-grants, free-busy, find-slot, invite, respond]",
 	Short:     "Run gRPC client",
-	ValidArgs: []string{"add", "delete", "update", "list", "get", "del", "upd", "ls"},
-	Args:      cobra.ExactValidArgs(1),
+	ValidArgs: []string{"add", "delete", "update", "list", "get", "del", "upd", "ls", "export", "import", "create-feed", "revoke-feed", "list-feeds", "grant", "revoke-grant", "list-grants", "free-busy", "find-slot", "invite", "respond"},
+	Args:      validateArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		grpcConfig = getGrpcClientConfig()
 		ctx, cancel := context.WithTimeout(context.Background(), ReqTimeout)
-		ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("owner", grpcConfig.Owner))
+		ctx = metadata.NewOutgoingContext(ctx, grpcConfig.GetMetadata())
 		grpcClient = getGrpcClient(ctx, grpcConfig)
 		go func() {
 			stop := make(chan os.Signal, 1)
@@ -54,10 +55,45 @@ var RootCmd = &cobra.Command{
 			runListRequest(ctx)
 		case "get":
 			runGetRequest(ctx)
+		case "export":
+			runExportRequest(ctx)
+		case "import":
+			runImportRequest(ctx, args[1])
+		case "create-feed":
+			runCreateFeedTokenRequest(ctx)
+		case "revoke-feed":
+			runRevokeFeedTokenRequest(ctx)
+		case "list-feeds":
+			runListFeedTokensRequest(ctx)
+		case "grant":
+			runCreateGrantRequest(ctx)
+		case "revoke-grant":
+			runRevokeGrantRequest(ctx)
+		case "list-grants":
+			runListGrantsRequest(ctx)
+		case "free-busy":
+			runFreeBusyRequest(ctx)
+		case "find-slot":
+			runFindSlotsRequest(ctx)
+		case "invite":
+			runInviteAttendeesRequest(ctx)
+		case "respond":
+			runRespondToInvitationRequest(ctx)
 		}
 	},
 }
 
+// validateArgs allows file name argument of import command
+func validateArgs(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 && args[0] == "import" {
+		if len(args) != 2 {
+			return fmt.Errorf("import requires file name argument")
+		}
+		return nil
+	}
+	return cobra.ExactValidArgs(1)(cmd, args)
+}
+
 var grpcConfig *config.GrpcClientConfig
 var grpcClient api.CalendarServiceClient
 
@@ -66,7 +102,15 @@ func getGrpcClient(ctx context.Context, conf *config.GrpcClientConfig) api.Calen
 		log.Fatal(err)
 	}
 	server := fmt.Sprintf("%s:%s", conf.Host, conf.Port)
-	conn, err := grpc.DialContext(ctx, server, grpc.WithInsecure(), grpc.WithUserAgent("calendar client"))
+	tlsConfig, err := config.GetClientTlsConfig().Tls()
+	if err != nil {
+		log.Fatal(err)
+	}
+	transport := grpc.WithInsecure()
+	if tlsConfig != nil {
+		transport = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+	conn, err := grpc.DialContext(ctx, server, transport, grpc.WithUserAgent("calendar client"))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -87,6 +131,35 @@ func init() {
 	RootCmd.Flags().StringP("owner", "o", "", "event owner")
 	RootCmd.Flags().StringP("start-time", "s", "", "event start time, format: "+tsLayout)
 	RootCmd.Flags().StringP("end-time", "e", "", "event end time, format: "+tsLayout)
+	RootCmd.Flags().StringP("recurrence", "r", "", "event recurrence rule (RFC 5545 RRULE), e.g. FREQ=WEEKLY;COUNT=10")
+	RootCmd.Flags().Bool("all-day", false, "all-day event, it lasts from date till end-date")
+	RootCmd.Flags().String("date", "", "date of all-day event, format: "+config.DateLayout)
+	RootCmd.Flags().String("end-date", "", "last date of multi-day event, date if not set, format: "+config.DateLayout)
+	RootCmd.Flags().StringSlice("reminders", nil, "reminders before event start, e.g. 24h,15m,0s, one day if not set")
+	RootCmd.Flags().String("transparency", "busy", "event transparency: busy, free or tentative, only busy events can't overlap")
+	RootCmd.Flags().String("scope", "all", "scope of recurring event changes: all, this or following")
+	RootCmd.Flags().String("occurrence", "", "start time of changed occurrence of recurring event, format: "+tsLayout)
+	RootCmd.Flags().String("format", "ics", "export format, only ics is supported")
+	RootCmd.Flags().String("output", "", "export file name, stdout if not set")
+	RootCmd.Flags().String("feed-token", "", "token of calendar feed to revoke")
+	RootCmd.Flags().String("calendar-owner", "", "owner of shared calendar, own calendar if not set")
+	RootCmd.Flags().String("grantee", "", "user who gets access to the calendar")
+	RootCmd.Flags().String("role", "read", "role of grantee: free-busy, read, write")
+	RootCmd.Flags().StringSlice("owners", nil, "owners of calendars in free/busy query, own calendar if not set")
+	RootCmd.Flags().StringSlice("attendees", nil, "attendees of meeting, owner if not set, or users invited to event")
+	RootCmd.Flags().String("status", "accepted", "response to invitation: accepted, declined, tentative, needs-action")
+	RootCmd.Flags().Duration("duration", 30*time.Minute, "duration of meeting slot")
+	RootCmd.Flags().Int("count", 3, "number of slots to find")
+	RootCmd.Flags().String("work-start", "", "start of working hours, format: HH:MM")
+	RootCmd.Flags().String("work-end", "", "end of working hours, format: HH:MM")
+	RootCmd.Flags().String("tz", "", "IANA time zone of entered and printed times, created events and working hours, e.g. Europe/Moscow, UTC if not set in config")
+	RootCmd.Flags().String("token", "", "signed authentication token")
+	RootCmd.Flags().String("api-key", "", "API key, it's used if token is not set")
+	RootCmd.Flags().Bool("tls", false, "connect to server with TLS")
+	RootCmd.Flags().String("tls-ca-file", "", "PEM CA certificates file to verify server, system roots are used if not set")
+	RootCmd.Flags().String("tls-cert-file", "", "PEM client certificate file for mutual TLS")
+	RootCmd.Flags().String("tls-key-file", "", "PEM private key file of client certificate")
+	RootCmd.Flags().String("tls-server-name", "", "server name expected in server certificate, host name if not set")
 	RootCmd.Flags().StringP("host", "n", "", "host name")
 	RootCmd.Flags().IntP("port", "p", 0, "port to listen")
 	// bind flags to viper
@@ -96,6 +169,35 @@ func init() {
 	_ = viper.BindPFlag("owner", RootCmd.Flags().Lookup("owner"))
 	_ = viper.BindPFlag("start-time", RootCmd.Flags().Lookup("start-time"))
 	_ = viper.BindPFlag("end-time", RootCmd.Flags().Lookup("end-time"))
+	_ = viper.BindPFlag("recurrence", RootCmd.Flags().Lookup("recurrence"))
+	_ = viper.BindPFlag("all-day", RootCmd.Flags().Lookup("all-day"))
+	_ = viper.BindPFlag("date", RootCmd.Flags().Lookup("date"))
+	_ = viper.BindPFlag("end-date", RootCmd.Flags().Lookup("end-date"))
+	_ = viper.BindPFlag("reminders", RootCmd.Flags().Lookup("reminders"))
+	_ = viper.BindPFlag("transparency", RootCmd.Flags().Lookup("transparency"))
+	_ = viper.BindPFlag("scope", RootCmd.Flags().Lookup("scope"))
+	_ = viper.BindPFlag("occurrence", RootCmd.Flags().Lookup("occurrence"))
+	_ = viper.BindPFlag("format", RootCmd.Flags().Lookup("format"))
+	_ = viper.BindPFlag("output", RootCmd.Flags().Lookup("output"))
+	_ = viper.BindPFlag("feed-token", RootCmd.Flags().Lookup("feed-token"))
+	_ = viper.BindPFlag("calendar-owner", RootCmd.Flags().Lookup("calendar-owner"))
+	_ = viper.BindPFlag("grantee", RootCmd.Flags().Lookup("grantee"))
+	_ = viper.BindPFlag("role", RootCmd.Flags().Lookup("role"))
+	_ = viper.BindPFlag("owners", RootCmd.Flags().Lookup("owners"))
+	_ = viper.BindPFlag("attendees", RootCmd.Flags().Lookup("attendees"))
+	_ = viper.BindPFlag("status", RootCmd.Flags().Lookup("status"))
+	_ = viper.BindPFlag("duration", RootCmd.Flags().Lookup("duration"))
+	_ = viper.BindPFlag("count", RootCmd.Flags().Lookup("count"))
+	_ = viper.BindPFlag("work-start", RootCmd.Flags().Lookup("work-start"))
+	_ = viper.BindPFlag("work-end", RootCmd.Flags().Lookup("work-end"))
+	_ = viper.BindPFlag("tz", RootCmd.Flags().Lookup("tz"))
+	_ = viper.BindPFlag("token", RootCmd.Flags().Lookup("token"))
+	_ = viper.BindPFlag("api-key", RootCmd.Flags().Lookup("api-key"))
+	_ = viper.BindPFlag("tls", RootCmd.Flags().Lookup("tls"))
+	_ = viper.BindPFlag("tls-ca-file", RootCmd.Flags().Lookup("tls-ca-file"))
+	_ = viper.BindPFlag("tls-client-cert-file", RootCmd.Flags().Lookup("tls-cert-file"))
+	_ = viper.BindPFlag("tls-client-key-file", RootCmd.Flags().Lookup("tls-key-file"))
+	_ = viper.BindPFlag("tls-server-name", RootCmd.Flags().Lookup("tls-server-name"))
 	_ = viper.BindPFlag("grpc-cli-host", RootCmd.Flags().Lookup("host"))
 	_ = viper.BindPFlag("grpc-cli-port", RootCmd.Flags().Lookup("port"))
 	viper.Set("ts-layout", tsLayout)
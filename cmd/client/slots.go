@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes"
+	"log"
+	"time"
+)
+
+// slotSearchPeriod is period of slot search if end time isn't set
+const slotSearchPeriod = 7 * 24 * time.Hour
+
+func runFindSlotsRequest(ctx context.Context) {
+	if grpcConfig.StartTime == "" {
+		grpcConfig.StartTime = time.Now().UTC().Format(grpcConfig.TsLayout)
+	}
+	st, err := grpcConfig.GetStartTime()
+	if err != nil {
+		log.Fatal(err)
+	}
+	req := &api.FindSlotsRequest{
+		Attendees: grpcConfig.Attendees,
+		Duration:  ptypes.DurationProto(grpcConfig.Duration),
+		StartTime: st,
+		Count:     int32(grpcConfig.Count),
+	}
+	if grpcConfig.EndTime != "" {
+		if req.EndTime, err = grpcConfig.GetEndTime(); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		t, _ := ptypes.Timestamp(st)
+		req.EndTime, _ = ptypes.TimestampProto(t.Add(slotSearchPeriod))
+	}
+	if grpcConfig.WorkStart != "" || grpcConfig.WorkEnd != "" {
+		req.WorkingHours = &api.WorkingHours{
+			Start:    grpcConfig.WorkStart,
+			End:      grpcConfig.WorkEnd,
+			TimeZone: grpcConfig.TimeZone,
+		}
+	}
+	resp, err := grpcClient.FindSlots(ctx, req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(resp.GetSlots()) == 0 {
+		log.Fatal("Free slots are not found")
+	}
+	log.Println(printSlots(resp.GetSlots()))
+}
+
+func printSlots(slots []*api.Slot) string {
+	var res string
+	for _, s := range slots {
+		st, et := viewerTime(s.StartTime), viewerTime(s.EndTime)
+		res += fmt.Sprintf("\nFree: %s - %s", st, et)
+	}
+	return res
+}
@@ -24,34 +24,58 @@ func runUpdateRequest(ctx context.Context) {
 		isAbsentParam = true
 		log.Println("Owner is not set")
 	}
-	if grpcConfig.StartTime == "" {
+	if grpcConfig.AllDay && grpcConfig.Date == "" {
+		isAbsentParam = true
+		log.Println("Date is not set")
+	}
+	if !grpcConfig.AllDay && grpcConfig.StartTime == "" {
 		isAbsentParam = true
 		log.Println("StartTime is not set")
 	}
-	if grpcConfig.EndTime == "" {
+	if !grpcConfig.AllDay && grpcConfig.EndTime == "" {
 		isAbsentParam = true
 		log.Println("EndTime is not set")
 	}
 	if isAbsentParam {
 		log.Fatal("Some parameters is not set")
 	}
-	st, err := grpcConfig.GetEndTime()
+	st, et, err := getEventTimes()
 	if err != nil {
 		log.Fatal(err)
 	}
-	et, err := grpcConfig.GetEndTime()
+	if et.Seconds < st.Seconds {
+		log.Fatal("End time less then Start time")
+	}
+	scope, err := grpcConfig.GetScope()
 	if err != nil {
 		log.Fatal(err)
 	}
-	if et.Seconds < st.Seconds {
-		log.Fatal("End time less then Start time")
+	ot, err := grpcConfig.GetOccurrence()
+	if err != nil {
+		log.Fatal(err)
+	}
+	transparency, err := grpcConfig.GetTransparency()
+	if err != nil {
+		log.Fatal(err)
+	}
+	reminders, err := grpcConfig.GetReminders()
+	if err != nil {
+		log.Fatal(err)
 	}
 	req := &api.UpdateEventRequest{
-		Id:        grpcConfig.Id,
-		Title:     grpcConfig.Title,
-		Text:      grpcConfig.Text,
-		StartTime: st,
-		EndTime:   et,
+		Id:                  grpcConfig.Id,
+		Title:               grpcConfig.Title,
+		Text:                grpcConfig.Text,
+		StartTime:           st,
+		EndTime:             et,
+		Recurrence:          grpcConfig.Recurrence,
+		Scope:               scope,
+		OccurrenceStartTime: ot,
+		CalendarOwner:       grpcConfig.CalendarOwner,
+		Transparency:        transparency,
+		AllDay:              grpcConfig.AllDay,
+		TimeZone:            eventTimeZone(),
+		Reminders:           reminders,
 	}
 	resp, err := grpcClient.UpdateEvent(ctx, req)
 	if err != nil {
@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/Brialius/calendar/internal/config"
 	"github.com/Brialius/calendar/internal/domain/interfaces"
+	"github.com/Brialius/calendar/internal/domain/models"
 	"github.com/Brialius/calendar/internal/domain/services"
 	"github.com/Brialius/calendar/internal/maindb"
 	"github.com/Brialius/calendar/internal/mainmq"
+	"github.com/Brialius/calendar/internal/memdb"
+	"github.com/Brialius/calendar/internal/sqlitedb"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -18,22 +22,42 @@ import (
 )
 
 func constructNotificator(storage interfaces.EventStorage, taskQueue interfaces.TaskQueue,
-	period time.Duration, qName, exchange string) *services.NotificatorService {
+	period time.Duration, qName, exchange, holder string, leaseTtl time.Duration) *services.NotificatorService {
 	return &services.NotificatorService{
 		EventStorage: storage,
 		TaskQueue:    taskQueue,
 		Period:       period,
 		QName:        qName,
 		Exchange:     exchange,
+		Holder:       holder,
+		LeaseTtl:     leaseTtl,
 	}
 }
 
+// instanceId identifies replica by host name and process id, if it isn't set by flag
+func instanceId() string {
+	if id := viper.GetString("instance"); id != "" {
+		return id
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		host = "notificator"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
 func selectStorage(storageType, dsn string) (interfaces.EventStorage, error) {
-	if storageType == "pg" {
+	switch storageType {
+	case "pg":
 		eventStorage, err := maindb.NewPgEventStorage(dsn)
 		return eventStorage, err
+	case "memory":
+		eventStorage, err := memdb.NewMemEventStorage()
+		return eventStorage, err
+	case "sqlite":
+		eventStorage, err := sqlitedb.NewSqliteEventStorage(dsn)
+		return eventStorage, err
 	}
-
 	return nil, errors.Errorf("storage `%s` is not implemented", storageType)
 }
 
@@ -50,7 +74,7 @@ var RootCmd = &cobra.Command{
 			isAbsentParam = true
 			log.Println("MQ URL is not set")
 		}
-		if storageConfig.Dsn == "" {
+		if storageConfig.Dsn == "" && storageConfig.NeedsDsn() {
 			isAbsentParam = true
 			log.Println("Dsn is not set")
 		}
@@ -74,7 +98,8 @@ var RootCmd = &cobra.Command{
 		}
 		defer storage.Close(ctx)
 
-		nt := constructNotificator(storage, tq, 24*time.Hour, "notification.tasks", "calendar")
+		nt := constructNotificator(storage, tq, models.MaxReminderOffset, "notification.tasks", "calendar",
+			instanceId(), viper.GetDuration("lease-ttl"))
 		go func() {
 			stop := make(chan os.Signal, 1)
 			signal.Notify(stop, os.Interrupt, syscall.SIGINT)
@@ -95,11 +120,15 @@ func init() {
 	RootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose logging")
 	RootCmd.PersistentFlags().StringP("config", "c", "", "Config file location")
 	RootCmd.Flags().StringP("url", "u", "", "amqp connection url")
-	RootCmd.Flags().StringP("dsn", "d", "", "database connection string")
-	RootCmd.Flags().StringP("storage", "s", "", "storage type")
+	RootCmd.Flags().StringP("dsn", "d", "", "database connection string or sqlite file name")
+	RootCmd.Flags().StringP("storage", "s", "", "storage type: pg, sqlite, memory")
 	_ = viper.BindPFlag("dsn", RootCmd.Flags().Lookup("dsn"))
 	_ = viper.BindPFlag("storage", RootCmd.Flags().Lookup("storage"))
 	_ = viper.BindPFlag("amqp-url", RootCmd.Flags().Lookup("url"))
+	RootCmd.Flags().String("instance", "", "id of notificator replica, host name and process id if not set")
+	RootCmd.Flags().Duration("lease-ttl", 30*time.Second, "how long the leader replica holds its lease without renewal")
+	_ = viper.BindPFlag("instance", RootCmd.Flags().Lookup("instance"))
+	_ = viper.BindPFlag("lease-ttl", RootCmd.Flags().Lookup("lease-ttl"))
 }
 
 var (
@@ -5,9 +5,13 @@ import (
 	"github.com/Brialius/calendar/internal/config"
 	"github.com/Brialius/calendar/internal/domain/interfaces"
 	"github.com/Brialius/calendar/internal/domain/services"
+	"github.com/Brialius/calendar/internal/maindb"
 	"github.com/Brialius/calendar/internal/mainmq"
 	"github.com/Brialius/calendar/internal/mainsender"
+	"github.com/Brialius/calendar/internal/memdb"
 	"github.com/Brialius/calendar/internal/monitoring"
+	"github.com/Brialius/calendar/internal/sqlitedb"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"log"
@@ -16,25 +20,55 @@ import (
 	"syscall"
 )
 
-func constructSender(taskQueue interfaces.TaskQueue,
+func constructSender(storage interfaces.EventStorage, taskQueue interfaces.TaskQueue,
 	qName string, sender interfaces.EventSender) *services.SenderService {
 	return &services.SenderService{
-		TaskQueue: taskQueue,
-		QName:     qName,
-		Sender:    sender,
+		EventStorage: storage,
+		TaskQueue:    taskQueue,
+		QName:        qName,
+		Sender:       sender,
 	}
 }
 
+func selectStorage(storageType, dsn string) (interfaces.EventStorage, error) {
+	switch storageType {
+	case "pg":
+		eventStorage, err := maindb.NewPgEventStorage(dsn)
+		return eventStorage, err
+	case "memory":
+		eventStorage, err := memdb.NewMemEventStorage()
+		return eventStorage, err
+	case "sqlite":
+		eventStorage, err := sqlitedb.NewSqliteEventStorage(dsn)
+		return eventStorage, err
+	}
+	return nil, errors.Errorf("storage `%s` is not implemented", storageType)
+}
+
 var RootCmd = &cobra.Command{
 	Use:   "sender",
 	Short: "Run sender service",
 	Run: func(cmd *cobra.Command, args []string) {
 		mqConf := config.GetMqConfig()
+		storageConfig := config.GetStorageConfig()
 		ctx, cancel := context.WithCancel(context.Background())
 
+		var isAbsentParam bool
 		if mqConf.Url == "" {
+			isAbsentParam = true
 			log.Println("MQ URL is not set")
 		}
+		if storageConfig.Dsn == "" && storageConfig.NeedsDsn() {
+			isAbsentParam = true
+			log.Println("Dsn is not set")
+		}
+		if storageConfig.StorageType == "" {
+			isAbsentParam = true
+			log.Println("StorageType is not set")
+		}
+		if isAbsentParam {
+			log.Fatal("Some parameters is not set")
+		}
 
 		tq, err := mainmq.NewRabbitMqQueue(mqConf.Url)
 		if err != nil {
@@ -42,6 +76,13 @@ var RootCmd = &cobra.Command{
 		}
 		defer tq.Close(ctx)
 
+		// ids of sent messages are stored, so duplicates are skipped by all replicas and after restart
+		storage, err := selectStorage(storageConfig.StorageType, storageConfig.Dsn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer storage.Close(ctx)
+
 		go func() {
 			stop := make(chan os.Signal, 1)
 			signal.Notify(stop, os.Interrupt, syscall.SIGINT)
@@ -53,7 +94,7 @@ var RootCmd = &cobra.Command{
 		if err != nil {
 			log.Fatal(err)
 		}
-		s := constructSender(tq, "notification.tasks", sender)
+		s := constructSender(storage, tq, "notification.tasks", sender)
 		m := &monitoring.PrometheusService{
 			Port: viper.GetString("metrics-port"),
 		}
@@ -75,6 +116,10 @@ func init() {
 	_ = viper.BindPFlag("config", RootCmd.PersistentFlags().Lookup("config"))
 	_ = viper.BindPFlag("metrics-port", RootCmd.PersistentFlags().Lookup("metrics-port"))
 	RootCmd.Flags().StringP("url", "u", "", "amqp connection url")
+	RootCmd.Flags().StringP("dsn", "d", "", "database connection string or sqlite file name")
+	RootCmd.Flags().StringP("storage", "s", "", "storage type: pg, sqlite, memory")
+	_ = viper.BindPFlag("dsn", RootCmd.Flags().Lookup("dsn"))
+	_ = viper.BindPFlag("storage", RootCmd.Flags().Lookup("storage"))
 	_ = viper.BindPFlag("amqp-url", RootCmd.Flags().Lookup("url"))
 }
 
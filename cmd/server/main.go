@@ -3,32 +3,44 @@ package main
 import (
 	"context"
 	"fmt"
+	"github.com/Brialius/calendar/internal/auth"
 	"github.com/Brialius/calendar/internal/config"
 	"github.com/Brialius/calendar/internal/domain/interfaces"
 	"github.com/Brialius/calendar/internal/domain/services"
 	"github.com/Brialius/calendar/internal/grpc"
 	"github.com/Brialius/calendar/internal/maindb"
+	"github.com/Brialius/calendar/internal/memdb"
 	"github.com/Brialius/calendar/internal/monitoring"
+	"github.com/Brialius/calendar/internal/sqlitedb"
+	"github.com/Brialius/calendar/internal/web"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"log"
 )
 
-func constructGrpcServer(eventStorage interfaces.EventStorage) *grpc.CalendarServer {
+func constructGrpcServer(eventStorage interfaces.EventStorage, authenticator *auth.Authenticator) *grpc.CalendarServer {
 	eventService := &services.EventService{
 		EventStorage: eventStorage,
 	}
 	server := &grpc.CalendarServer{
-		EventService: eventService,
+		EventService:  eventService,
+		Authenticator: authenticator,
 	}
 	return server
 }
 
 func selectStorage(storageType, dsn string) (interfaces.EventStorage, error) {
-	if storageType == "pg" {
+	switch storageType {
+	case "pg":
 		eventStorage, err := maindb.NewPgEventStorage(dsn)
 		return eventStorage, err
+	case "memory":
+		eventStorage, err := memdb.NewMemEventStorage()
+		return eventStorage, err
+	case "sqlite":
+		eventStorage, err := sqlitedb.NewSqliteEventStorage(dsn)
+		return eventStorage, err
 	}
 	return nil, errors.Errorf("storage `%s` is not implemented", storageType)
 }
@@ -48,7 +60,7 @@ var RootCmd = &cobra.Command{
 			isAbsentParam = true
 			log.Println("Port is not set")
 		}
-		if storageConfig.Dsn == "" {
+		if storageConfig.Dsn == "" && storageConfig.NeedsDsn() {
 			isAbsentParam = true
 			log.Println("Dsn is not set")
 		}
@@ -60,19 +72,52 @@ var RootCmd = &cobra.Command{
 			log.Fatal("Some parameters is not set")
 		}
 
+		if viper.GetBool("auto-migrate") {
+			if err := autoMigrate(storageConfig.StorageType, storageConfig.Dsn); err != nil {
+				log.Fatal(err)
+			}
+		}
+
 		storage, err := selectStorage(storageConfig.StorageType, storageConfig.Dsn)
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer storage.Close(context.Background())
 
-		server := constructGrpcServer(storage)
+		authenticator, err := auth.NewAuthenticator(config.GetAuthConfig())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if authenticator == nil {
+			log.Println("Authentication is disabled, owners sent by clients are trusted")
+		}
+
+		server := constructGrpcServer(storage, authenticator)
+		tlsConfig := config.GetServerTlsConfig()
+		if server.Tls, err = tlsConfig.Tls(); err != nil {
+			log.Fatal(err)
+		}
+		server.CertOwner = tlsConfig.CertOwner
+		if server.Tls == nil {
+			log.Println("TLS is disabled, gRPC and http connections are not encrypted")
+		} else if authenticator == nil && !server.CertOwner {
+			log.Printf("TLS is enabled, but authentication is disabled and owners sent by clients are trusted, "+
+				"set auth to `%s` or enable tls-cert-owner", config.AuthToken)
+		}
 		addr := fmt.Sprintf("%s:%s", serverConfig.Host, serverConfig.Port)
 		m := &monitoring.PrometheusService{
 			Port: serverConfig.MetricsPort,
 		}
 		log.Printf("Starting monitoring server on %s...", m.Port)
 		m.Serve()
+		h := &web.Server{
+			EventService:  server.EventService,
+			Authenticator: authenticator,
+			Port:          serverConfig.HttpPort,
+			Tls:           server.Tls,
+		}
+		log.Printf("Starting http server on %s...", h.Port)
+		h.Serve()
 		log.Printf("Starting server on %s...", addr)
 		err = server.Serve(addr)
 		if err != nil {
@@ -89,14 +134,36 @@ func init() {
 	_ = viper.BindPFlag("verbose", RootCmd.PersistentFlags().Lookup("verbose"))
 	_ = viper.BindPFlag("config", RootCmd.PersistentFlags().Lookup("config"))
 	_ = viper.BindPFlag("metrics-port", RootCmd.PersistentFlags().Lookup("metrics-port"))
+	RootCmd.Flags().String("http-port", "8081", "Port for http server with calendar feeds, CalDAV and JSON API")
+	_ = viper.BindPFlag("http-port", RootCmd.Flags().Lookup("http-port"))
 	RootCmd.Flags().StringP("host", "n", "", "host name")
 	RootCmd.Flags().IntP("port", "p", 0, "port to listen")
-	RootCmd.Flags().StringP("dsn", "d", "", "database connection string")
-	RootCmd.Flags().StringP("storage", "s", "", "storage type")
+	RootCmd.PersistentFlags().StringP("dsn", "d", "", "database connection string or sqlite file name")
+	RootCmd.PersistentFlags().StringP("storage", "s", "", "storage type: pg, sqlite, memory")
+	RootCmd.Flags().Bool("auto-migrate", false, "apply database migrations before start")
 	_ = viper.BindPFlag("grpc-srv-host", RootCmd.Flags().Lookup("host"))
 	_ = viper.BindPFlag("grpc-srv-port", RootCmd.Flags().Lookup("port"))
-	_ = viper.BindPFlag("dsn", RootCmd.Flags().Lookup("dsn"))
-	_ = viper.BindPFlag("storage", RootCmd.Flags().Lookup("storage"))
+	_ = viper.BindPFlag("dsn", RootCmd.PersistentFlags().Lookup("dsn"))
+	_ = viper.BindPFlag("storage", RootCmd.PersistentFlags().Lookup("storage"))
+	_ = viper.BindPFlag("auto-migrate", RootCmd.Flags().Lookup("auto-migrate"))
+	RootCmd.Flags().String("auth", "none", "authentication: none, token")
+	RootCmd.PersistentFlags().String("auth-hmac-key-file", "", "secret key file of HMAC signed tokens")
+	RootCmd.Flags().String("auth-rsa-key-file", "", "PEM public key file of RSA signed tokens")
+	RootCmd.Flags().String("auth-api-keys-file", "", "file with `owner key` lines of API keys")
+	_ = viper.BindPFlag("auth", RootCmd.Flags().Lookup("auth"))
+	_ = viper.BindPFlag("auth-hmac-key-file", RootCmd.PersistentFlags().Lookup("auth-hmac-key-file"))
+	_ = viper.BindPFlag("auth-rsa-key-file", RootCmd.Flags().Lookup("auth-rsa-key-file"))
+	_ = viper.BindPFlag("auth-api-keys-file", RootCmd.Flags().Lookup("auth-api-keys-file"))
+	RootCmd.Flags().String("tls-cert-file", "", "PEM certificate file of gRPC server, TLS is enabled if it's set")
+	RootCmd.Flags().String("tls-key-file", "", "PEM private key file of gRPC server certificate")
+	RootCmd.Flags().String("tls-client-ca-file", "", "PEM CA certificates file to verify client certificates (mutual TLS)")
+	RootCmd.Flags().Bool("tls-cert-owner", false, "take owner from common name of client certificate")
+	_ = viper.BindPFlag("tls-cert-file", RootCmd.Flags().Lookup("tls-cert-file"))
+	_ = viper.BindPFlag("tls-key-file", RootCmd.Flags().Lookup("tls-key-file"))
+	_ = viper.BindPFlag("tls-client-ca-file", RootCmd.Flags().Lookup("tls-client-ca-file"))
+	_ = viper.BindPFlag("tls-cert-owner", RootCmd.Flags().Lookup("tls-cert-owner"))
+	RootCmd.AddCommand(migrateCmd)
+	RootCmd.AddCommand(tokenCmd)
 }
 
 var (
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/config"
+	"github.com/Brialius/calendar/internal/migrations"
+	"github.com/spf13/cobra"
+	"log"
+	"strconv"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:       "migrate [up, down, status, to N]",
+	Short:     "Apply database schema migrations",
+	ValidArgs: []string{"up", "down", "status", "to"},
+	Args:      cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		storageConfig := config.GetStorageConfig()
+		m, err := migrations.NewMigrator(storageConfig.StorageType, storageConfig.Dsn)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer m.Close()
+		ctx := context.Background()
+
+		switch args[0] {
+		case "up":
+			err = m.Up(ctx)
+		case "down":
+			err = m.Down(ctx)
+		case "to":
+			if len(args) != 2 {
+				log.Fatal("Version is not set")
+			}
+			version, perr := strconv.ParseInt(args[1], 10, 64)
+			if perr != nil {
+				log.Fatal(perr)
+			}
+			err = m.To(ctx, version)
+		case "status":
+			st, serr := m.Status(ctx)
+			if serr != nil {
+				log.Fatal(serr)
+			}
+			fmt.Printf("Current version: %d, dirty: %t, latest: %d\n", st.Version, st.Dirty, m.Latest())
+			for _, v := range st.Available {
+				state := "pending"
+				if v <= st.Version {
+					state = "applied"
+				}
+				fmt.Printf("%06d %s\n", v, state)
+			}
+		default:
+			log.Fatalf("Unknown migrate command `%s`", args[0])
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// autoMigrate applies pending migrations before server start, storages without schema are skipped
+func autoMigrate(storageType, dsn string) error {
+	if storageType == "memory" {
+		return nil
+	}
+	m, err := migrations.NewMigrator(storageType, dsn)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	log.Println("Applying database migrations...")
+	return m.Up(context.Background())
+}
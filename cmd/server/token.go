@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"github.com/Brialius/calendar/internal/auth"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"log"
+	"time"
+)
+
+var tokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Issue HS256 signed token of owner with configured HMAC key",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		owner, _ := cmd.Flags().GetString("owner")
+		ttl, _ := cmd.Flags().GetDuration("ttl")
+		if owner == "" {
+			log.Fatal("Owner is not set")
+		}
+		file := viper.GetString("auth-hmac-key-file")
+		if file == "" {
+			log.Fatal("HMAC key file is not set")
+		}
+		key, err := auth.ReadHmacKey(file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		token, err := auth.IssueToken(key, owner, ttl)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(token)
+	},
+}
+
+func init() {
+	tokenCmd.Flags().StringP("owner", "o", "", "owner of events")
+	tokenCmd.Flags().Duration("ttl", 24*time.Hour, "token lifetime")
+}
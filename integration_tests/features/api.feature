@@ -143,3 +143,86 @@ Feature: Test event service API
 		And I get event list
 		Then Event list should contain created events
 		And I delete all created events
+
+	Scenario: API List Recurring Event
+		Given there is user "test_user"
+		And there is server "calendar-service:8080"
+		When I create event
+		"""
+		{
+			"title":"test_event_recurring",
+			"text":"Test event: API Recurrence check",
+			"startTime":"2019-11-04T10:00:00Z",
+			"endTime":"2019-11-04T10:15:00Z",
+			"recurrence":"FREQ=WEEKLY;COUNT=52"
+		}
+		"""
+		And I get event list from "2019-11-01T00:00:00Z" till "2019-12-01T00:00:00Z"
+		Then Event list should contain 4 events
+		And I delete all created events
+
+	Scenario: API Cancel Occurrence of Recurring Event
+		Given there is user "test_user"
+		And there is server "calendar-service:8080"
+		When I create event
+		"""
+		{
+			"title":"test_event_series",
+			"text":"Test event: API Series check",
+			"startTime":"2019-11-04T12:00:00Z",
+			"endTime":"2019-11-04T12:15:00Z",
+			"recurrence":"FREQ=WEEKLY;COUNT=52"
+		}
+		"""
+		And I delete occurrence "2019-11-11T12:00:00Z" of created event
+		And I get event list from "2019-11-01T00:00:00Z" till "2019-12-01T00:00:00Z"
+		Then Event list should contain 3 events
+		And I delete all created events
+
+	Scenario: API Export Events
+		Given there is user "test_user"
+		And there is server "calendar-service:8080"
+		When I create event
+		"""
+		{
+			"title":"test_event_export",
+			"text":"Test event: API Export check",
+			"startTime":"2019-11-05T15:00:00Z",
+			"endTime":"2019-11-05T16:00:00Z"
+		}
+		"""
+		And I export events from "2019-11-05T00:00:00Z"
+		Then Export should contain created event
+		And I delete all created events
+
+	Scenario: API Import Events
+		Given there is user "test_user"
+		And there is server "calendar-service:8080"
+		When I import calendar
+		"""
+		BEGIN:VCALENDAR
+		VERSION:2.0
+		BEGIN:VEVENT
+		UID:import-1@calendar
+		DTSTART:20191106T100000Z
+		DTEND:20191106T110000Z
+		SUMMARY:test_event_import
+		END:VEVENT
+		BEGIN:VEVENT
+		UID:import-2@calendar
+		DTSTART:20191106T103000Z
+		DTEND:20191106T113000Z
+		SUMMARY:test_event_import_overlap
+		END:VEVENT
+		BEGIN:VEVENT
+		UID:import-1@calendar
+		DTSTART:20191106T100000Z
+		DTEND:20191106T110000Z
+		SUMMARY:test_event_import_duplicate
+		END:VEVENT
+		END:VCALENDAR
+		"""
+		Then Import should contain 1 CREATED events
+		And Import should contain 1 REJECTED events
+		And Import should contain 1 DUPLICATE events
+		And I delete all created events
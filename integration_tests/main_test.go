@@ -18,6 +18,7 @@ import (
 	"google.golang.org/grpc/metadata"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -35,9 +36,12 @@ type apiStruct struct {
 	deleteResponse   *api.DeleteEventResponse
 	listRequest      *api.ListEventsRequest
 	listResponse     *api.ListEventsResponse
+	exportResponse   *api.ExportEventsResponse
+	importResponse   *api.ImportEventsResponse
 	eventToVerify    *api.Event
 	createdEventsIds []string
 	mq               *mqStruct
+	cancel           context.CancelFunc
 }
 
 type mqStruct struct {
@@ -52,7 +56,7 @@ type mqStruct struct {
 var ctx context.Context
 
 func (a *apiStruct) thereIsUser(owner string) error {
-	ctx, _ = context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, a.cancel = context.WithTimeout(context.Background(), 10*time.Second)
 	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("owner", owner))
 	return nil
 }
@@ -80,10 +84,11 @@ func (a *apiStruct) iCreateEvent(eventJSON *gherkin.DocString) error {
 		return err
 	}
 	a.createResponse, err = a.apiCli.CreateEvent(ctx, &api.CreateEventRequest{
-		Title:     eventProto.Title,
-		Text:      eventProto.Text,
-		StartTime: eventProto.StartTime,
-		EndTime:   eventProto.EndTime,
+		Title:      eventProto.Title,
+		Text:       eventProto.Text,
+		StartTime:  eventProto.StartTime,
+		EndTime:    eventProto.EndTime,
+		Recurrence: eventProto.Recurrence,
 	})
 	if err != nil {
 		return err
@@ -160,6 +165,28 @@ func (a *apiStruct) iDeleteEventById(id string) (err error) {
 	return
 }
 
+func (a *apiStruct) iDeleteOccurrenceOfCreatedEvent(occurrence string) error {
+	ot, err := time.Parse(time.RFC3339, occurrence)
+	if err != nil {
+		return err
+	}
+	req := &api.DeleteEventRequest{
+		Id:    a.eventToVerify.Id,
+		Scope: api.Scope_THIS,
+	}
+	if req.OccurrenceStartTime, err = ptypes.TimestampProto(ot); err != nil {
+		return err
+	}
+	a.deleteResponse, err = a.apiCli.DeleteEvent(ctx, req)
+	if err != nil {
+		return err
+	}
+	if errResponce := a.deleteResponse.GetError(); errResponce != "" {
+		return errors.New(errResponce)
+	}
+	return nil
+}
+
 func (a *apiStruct) eventByPreviousIdShouldBeAbsent() (err error) {
 	a.getResponse, err = a.apiCli.GetEvent(ctx, &api.GetEventRequest{
 		Id: a.eventToVerify.Id,
@@ -203,6 +230,88 @@ func (a *apiStruct) eventListShouldContainCreatedEvents() error {
 	return nil
 }
 
+func (a *apiStruct) iGetEventListFromTill(from, till string) error {
+	st, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return err
+	}
+	et, err := time.Parse(time.RFC3339, till)
+	if err != nil {
+		return err
+	}
+	req := &api.ListEventsRequest{}
+	if req.StartTime, err = ptypes.TimestampProto(st); err != nil {
+		return err
+	}
+	if req.EndTime, err = ptypes.TimestampProto(et); err != nil {
+		return err
+	}
+	a.listResponse, err = a.apiCli.ListEvents(ctx, req)
+	return err
+}
+
+func (a *apiStruct) iExportEventsFrom(from string) error {
+	st, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return err
+	}
+	req := &api.ExportEventsRequest{}
+	if req.StartTime, err = ptypes.TimestampProto(st); err != nil {
+		return err
+	}
+	a.exportResponse, err = a.apiCli.ExportEvents(ctx, req)
+	return err
+}
+
+func (a *apiStruct) exportShouldContainCreatedEvent() error {
+	calendar := string(a.exportResponse.GetCalendar())
+	if !strings.Contains(calendar, "UID:"+a.eventToVerify.Id) {
+		return fmt.Errorf("event %s is not exported:\n%s", a.eventToVerify.Id, calendar)
+	}
+	return nil
+}
+
+func (a *apiStruct) iImportCalendar(calendar *gherkin.DocString) (err error) {
+	// indentation of feature file would be treated as folding of content lines
+	lines := strings.Split(calendar.Content, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	a.importResponse, err = a.apiCli.ImportEvents(ctx, &api.ImportEventsRequest{
+		Calendar: []byte(strings.Join(lines, "\r\n")),
+	})
+	if err != nil {
+		return err
+	}
+	for _, r := range a.importResponse.GetResults() {
+		if r.Status == api.ImportStatus_CREATED && r.OriginalStartTime == nil {
+			a.createdEventsIds = append(a.createdEventsIds, r.EventId)
+		}
+	}
+	return nil
+}
+
+func (a *apiStruct) importShouldContainEventsWithStatus(count int, status string) error {
+	c := 0
+	for _, r := range a.importResponse.GetResults() {
+		if r.Status.String() == status {
+			c++
+		}
+	}
+	if c != count {
+		return fmt.Errorf("import contains wrong number of %s events: %d but expect: %d", status, c, count)
+	}
+	return nil
+}
+
+func (a *apiStruct) eventListShouldContainEvents(count int) error {
+	if len(a.listResponse.Events) != count {
+		return fmt.Errorf("list contains wrong number of records: %d but expect: %d",
+			len(a.listResponse.Events), count)
+	}
+	return nil
+}
+
 func (a *apiStruct) iDeleteAllCreatedEvents() error {
 	for _, id := range a.createdEventsIds {
 		err := a.iDeleteEventById(id)
@@ -354,6 +463,11 @@ func FeatureContext(s *godog.Suite) {
 	s.BeforeScenario(func(interface{}) {
 		a.createdEventsIds = make([]string, 0)
 	})
+	s.AfterScenario(func(interface{}, error) {
+		if a.cancel != nil {
+			a.cancel()
+		}
+	})
 	s.Step(`^there is user "([^"]*)"$`, a.thereIsUser)
 	s.Step(`^there is server "([^"]*)"$`, a.thereIsServer)
 	s.Step(`^I create event$`, a.iCreateEvent)
@@ -361,9 +475,16 @@ func FeatureContext(s *godog.Suite) {
 	s.Step(`^Events should be the same$`, a.eventsShouldBeTheSame)
 	s.Step(`^I update created event$`, a.iUpdateCreatedEvent)
 	s.Step(`^I delete event by previous id$`, a.iDeleteEventByPreviousId)
+	s.Step(`^I delete occurrence "([^"]*)" of created event$`, a.iDeleteOccurrenceOfCreatedEvent)
 	s.Step(`^Event by previous id should be absent$`, a.eventByPreviousIdShouldBeAbsent)
 	s.Step(`^I get event list$`, a.iGetEventList)
 	s.Step(`^Event list should contain created events$`, a.eventListShouldContainCreatedEvents)
+	s.Step(`^I get event list from "([^"]*)" till "([^"]*)"$`, a.iGetEventListFromTill)
+	s.Step(`^Event list should contain (\d+) events$`, a.eventListShouldContainEvents)
+	s.Step(`^I export events from "([^"]*)"$`, a.iExportEventsFrom)
+	s.Step(`^Export should contain created event$`, a.exportShouldContainCreatedEvent)
+	s.Step(`^I import calendar$`, a.iImportCalendar)
+	s.Step(`^Import should contain (\d+) ([A-Z]+) events$`, a.importShouldContainEventsWithStatus)
 	s.Step(`^I delete all created events$`, a.iDeleteAllCreatedEvents)
 	s.Step(`^I purge old events$`, a.iPurgeOldEvents)
 	s.Step(`^there is MQ server "([^"]*)"$`, a.thereIsMQServer)
@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"crypto/sha256"
+	"github.com/Brialius/calendar/internal/config"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+var ErrUnauthenticated = errors.New("credentials are incorrect")
+
+// Authenticator verifies signed tokens and API keys and returns owner of events identified by them.
+// Owner is `sub` claim of token
+type Authenticator struct {
+	hmacKey []byte
+	rsaKey  *rsa.PublicKey
+	// apiKeys maps hash of key to owner
+	apiKeys map[[sha256.Size]byte]string
+}
+
+// NewAuthenticator loads keys from configured files, nil is returned if authentication is disabled.
+// Keys configured without token authentication are refused, since owners sent by clients would be trusted
+func NewAuthenticator(c *config.AuthConfig) (*Authenticator, error) {
+	switch c.Mode {
+	case "", config.AuthNone:
+		if c.HmacKeyFile != "" || c.RsaKeyFile != "" || c.ApiKeysFile != "" {
+			return nil, errors.Errorf("keys are configured, but authentication is `%s`, set it to `%s`", config.AuthNone, config.AuthToken)
+		}
+		return nil, nil
+	case config.AuthToken:
+	default:
+		return nil, errors.Errorf("authentication `%s` is not implemented", c.Mode)
+	}
+	a := &Authenticator{apiKeys: make(map[[sha256.Size]byte]string)}
+	var err error
+	if c.HmacKeyFile != "" {
+		if a.hmacKey, err = ReadHmacKey(c.HmacKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	if c.RsaKeyFile != "" {
+		pem, err := ioutil.ReadFile(c.RsaKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't read RSA key")
+		}
+		if a.rsaKey, err = jwt.ParseRSAPublicKeyFromPEM(pem); err != nil {
+			return nil, errors.Wrap(err, "can't parse RSA key")
+		}
+	}
+	if c.ApiKeysFile != "" {
+		if err := a.loadApiKeys(c.ApiKeysFile); err != nil {
+			return nil, err
+		}
+	}
+	if a.hmacKey == nil && a.rsaKey == nil && len(a.apiKeys) == 0 {
+		return nil, errors.New("keys of token authentication are not set")
+	}
+	return a, nil
+}
+
+// ReadHmacKey reads secret key, trailing new line is ignored
+func ReadHmacKey(file string) ([]byte, error) {
+	key, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read HMAC key")
+	}
+	key = bytes.TrimRight(key, "\r\n")
+	if len(key) == 0 {
+		return nil, errors.New("HMAC key is empty")
+	}
+	return key, nil
+}
+
+// loadApiKeys reads `owner key` lines, empty lines and lines started with # are skipped
+func (a *Authenticator) loadApiKeys(file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return errors.Wrap(err, "can't read API keys")
+	}
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return errors.Errorf("API keys line %d: `owner key` is expected", n)
+		}
+		a.apiKeys[sha256.Sum256([]byte(fields[1]))] = fields[0]
+	}
+	return s.Err()
+}
+
+// VerifyToken checks signature and expiration of JWT, signing method should match configured key
+// and expiration is required
+func (a *Authenticator) VerifyToken(token string) (string, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if a.hmacKey != nil {
+				return a.hmacKey, nil
+			}
+		case *jwt.SigningMethodRSA:
+			if a.rsaKey != nil {
+				return a.rsaKey, nil
+			}
+		}
+		return nil, errors.Errorf("signing method `%s` is not allowed", t.Method.Alg())
+	})
+	if err != nil {
+		return "", errors.Wrap(ErrUnauthenticated, err.Error())
+	}
+	// tokens without expiration are valid forever, if they leak
+	if claims.ExpiresAt == nil {
+		return "", errors.Wrap(ErrUnauthenticated, "expiration is not set")
+	}
+	if claims.Subject == "" {
+		return "", errors.Wrap(ErrUnauthenticated, "subject is not set")
+	}
+	return claims.Subject, nil
+}
+
+func (a *Authenticator) VerifyApiKey(key string) (string, error) {
+	owner, ok := a.apiKeys[sha256.Sum256([]byte(key))]
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	return owner, nil
+}
+
+// IssueToken signs HS256 token of owner, which expires after ttl
+func IssueToken(key []byte, owner string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.RegisteredClaims{
+		Subject:   owner,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}).SignedString(key)
+}
+
+type ownerKey struct{}
+
+// NewContext returns context with owner of verified identity
+func NewContext(ctx context.Context, owner string) context.Context {
+	return context.WithValue(ctx, ownerKey{}, owner)
+}
+
+func OwnerFromContext(ctx context.Context) (string, bool) {
+	owner, ok := ctx.Value(ownerKey{}).(string)
+	return owner, ok
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/Brialius/calendar/internal/config"
+	"github.com/golang-jwt/jwt/v4"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuthenticator(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		file := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(file, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, _ := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	a, err := NewAuthenticator(&config.AuthConfig{
+		Mode:        config.AuthToken,
+		HmacKeyFile: write("hmac", []byte("secret\n")),
+		RsaKeyFile:  write("rsa.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})),
+		ApiKeysFile: write("keys", []byte("# owner key\nalice alice-key\n\nbob bob-key\n")),
+	})
+	if err != nil {
+		t.Fatalf("can't create authenticator: %s", err)
+	}
+
+	hmacToken, _ := IssueToken([]byte("secret"), "alice", time.Hour)
+	rsaToken, _ := jwt.NewWithClaims(jwt.SigningMethodRS256, &jwt.RegisteredClaims{
+		Subject:   "bob",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString(rsaKey)
+	endless, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwt.RegisteredClaims{Subject: "alice"}).SignedString([]byte("secret"))
+	expired, _ := IssueToken([]byte("secret"), "alice", -time.Hour)
+	forged, _ := IssueToken([]byte("another"), "alice", time.Hour)
+	unsigned, _ := jwt.NewWithClaims(jwt.SigningMethodNone, &jwt.RegisteredClaims{Subject: "alice"}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	tests := []struct {
+		name  string
+		token string
+		owner string
+	}{
+		{name: "hmac", token: hmacToken, owner: "alice"},
+		{name: "rsa", token: rsaToken, owner: "bob"},
+		{name: "expired", token: expired},
+		{name: "without expiration", token: endless},
+		{name: "forged", token: forged},
+		{name: "unsigned", token: unsigned},
+		{name: "malformed", token: "token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, err := a.VerifyToken(tt.token)
+			if tt.owner == "" && err == nil {
+				t.Errorf("token should be rejected, got owner `%s`", owner)
+			}
+			if tt.owner != "" && (err != nil || owner != tt.owner) {
+				t.Errorf("expected owner `%s`, got `%s` (%v)", tt.owner, owner, err)
+			}
+		})
+	}
+
+	if owner, err := a.VerifyApiKey("bob-key"); err != nil || owner != "bob" {
+		t.Errorf("expected owner `bob`, got `%s` (%v)", owner, err)
+	}
+	if _, err := a.VerifyApiKey("unknown"); err != ErrUnauthenticated {
+		t.Errorf("expected %q, got %v", ErrUnauthenticated, err)
+	}
+	if a, err := NewAuthenticator(&config.AuthConfig{Mode: config.AuthNone}); a != nil || err != nil {
+		t.Errorf("authenticator shouldn't be created if authentication is disabled")
+	}
+	if _, err := NewAuthenticator(&config.AuthConfig{Mode: config.AuthNone, ApiKeysFile: write("keys", nil)}); err == nil {
+		t.Errorf("keys shouldn't be accepted if authentication is disabled")
+	}
+}
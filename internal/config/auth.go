@@ -0,0 +1,41 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"log"
+)
+
+const (
+	// AuthNone trusts owner sent by client
+	AuthNone = "none"
+	// AuthToken requires signed token or API key, owner is taken from verified identity
+	AuthToken = "token"
+)
+
+type AuthConfig struct {
+	Mode string
+	// HmacKeyFile is secret key of HS256/HS384/HS512 signed tokens
+	HmacKeyFile string
+	// RsaKeyFile is PEM encoded public key of RS256/RS384/RS512 signed tokens
+	RsaKeyFile string
+	// ApiKeysFile contains `owner key` lines
+	ApiKeysFile string
+}
+
+func GetAuthConfig() *AuthConfig {
+	log.Println("Configuring authentication...")
+	viper.SetDefault("auth", AuthNone)
+	viper.SetDefault("auth-hmac-key-file", "")
+	viper.SetDefault("auth-rsa-key-file", "")
+	viper.SetDefault("auth-api-keys-file", "")
+	return newAuthConfig()
+}
+
+func newAuthConfig() *AuthConfig {
+	return &AuthConfig{
+		Mode:        viper.GetString("auth"),
+		HmacKeyFile: viper.GetString("auth-hmac-key-file"),
+		RsaKeyFile:  viper.GetString("auth-rsa-key-file"),
+		ApiKeysFile: viper.GetString("auth-api-keys-file"),
+	}
+}
@@ -1,9 +1,13 @@
 package config
 
 import (
+	"fmt"
+	"github.com/Brialius/calendar/internal/grpc/api"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/spf13/viper"
+	"google.golang.org/grpc/metadata"
 	"log"
 	"time"
 )
@@ -18,10 +22,58 @@ type GrpcClientConfig struct {
 	StartTime string
 	EndTime   string
 	TsLayout  string
+	// Recurrence is RFC 5545 RRULE, e.g. FREQ=WEEKLY;COUNT=10
+	Recurrence string
+	// Scope of changes of recurring event: all, this or following
+	Scope string
+	// Occurrence is original start time of changed occurrence of recurring event
+	Occurrence string
+	// Format of exported events
+	Format string
+	// Output is file name of exported events
+	Output string
+	// FeedToken is token of calendar feed
+	FeedToken string
+	// Token is signed token sent as bearer authorization, owner is taken from it by server
+	Token string
+	// ApiKey is sent if token isn't set
+	ApiKey string
+	// CalendarOwner is owner of shared calendar, own calendar is used if it's empty
+	CalendarOwner string
+	// Grantee is user who gets access to the calendar
+	Grantee string
+	// Role of grantee: free-busy, read or write
+	Role string
+	// Owners of calendars in free/busy query
+	Owners []string
+	// Attendees of meeting, which slot is searched for, or who are invited to event
+	Attendees []string
+	// Transparency of created or updated event: busy, free or tentative
+	Transparency string
+	// Status is response to invitation: needs-action, accepted, declined or tentative
+	Status string
+	// Duration of searched slot
+	Duration time.Duration
+	// Count of searched slots
+	Count int
+	// WorkStart and WorkEnd are working hours in HH:MM format, slots are searched within them if they're set
+	WorkStart string
+	WorkEnd   string
+	// TimeZone is IANA time zone of entered and printed times, created events and working hours
+	TimeZone string
+	// AllDay event lasts from Date till EndDate inclusive, Date is used as EndDate if it isn't set
+	AllDay  bool
+	Date    string
+	EndDate string
+	// Reminders are durations before start of created or updated event, e.g. 24h, 15m or 0s
+	Reminders []string
 }
 
-func parseTs(s, tsLayout string) (*timestamp.Timestamp, error) {
-	t, err := time.Parse(tsLayout, s)
+// DateLayout is format of dates of all-day events
+const DateLayout = "2006-01-02"
+
+func parseTs(s, tsLayout string, loc *time.Location) (*timestamp.Timestamp, error) {
+	t, err := time.ParseInLocation(tsLayout, s, loc)
 	if err != nil {
 		return nil, err
 	}
@@ -39,30 +91,211 @@ func GetGrpcClientConfig() *GrpcClientConfig {
 	viper.SetDefault("body", "")
 	viper.SetDefault("start-time", "")
 	viper.SetDefault("end-time", "")
+	viper.SetDefault("recurrence", "")
+	viper.SetDefault("scope", "all")
+	viper.SetDefault("occurrence", "")
+	viper.SetDefault("format", "ics")
+	viper.SetDefault("output", "")
+	viper.SetDefault("feed-token", "")
+	viper.SetDefault("token", "")
+	viper.SetDefault("api-key", "")
+	viper.SetDefault("calendar-owner", "")
+	viper.SetDefault("grantee", "")
+	viper.SetDefault("role", "read")
+	viper.SetDefault("owners", []string{})
+	viper.SetDefault("attendees", []string{})
+	viper.SetDefault("status", "accepted")
+	viper.SetDefault("transparency", "busy")
+	viper.SetDefault("duration", 30*time.Minute)
+	viper.SetDefault("count", 3)
+	viper.SetDefault("work-start", "")
+	viper.SetDefault("work-end", "")
+	viper.SetDefault("tz", "UTC")
+	viper.SetDefault("all-day", false)
+	viper.SetDefault("date", "")
+	viper.SetDefault("end-date", "")
+	viper.SetDefault("reminders", []string{})
 	viper.SetDefault("owner", "user")
 	viper.SetDefault("grpc-cli-host", "localhost")
 	viper.SetDefault("grpc-cli-port", "8080")
 	return newGrpcClientConfig()
 }
 
+// GetLocation returns time zone of entered and printed times, UTC if it isn't set
+func (c *GrpcClientConfig) GetLocation() (*time.Location, error) {
+	if c.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone `%s`", c.TimeZone)
+	}
+	return loc, nil
+}
+
 func (c *GrpcClientConfig) GetStartTime() (*timestamp.Timestamp, error) {
-	return parseTs(c.StartTime, c.TsLayout)
+	loc, err := c.GetLocation()
+	if err != nil {
+		return nil, err
+	}
+	return parseTs(c.StartTime, c.TsLayout, loc)
 }
 
 func (c *GrpcClientConfig) GetEndTime() (*timestamp.Timestamp, error) {
-	return parseTs(c.EndTime, c.TsLayout)
+	loc, err := c.GetLocation()
+	if err != nil {
+		return nil, err
+	}
+	return parseTs(c.EndTime, c.TsLayout, loc)
+}
+
+// GetDates returns start and exclusive end of all-day event, they're midnights in UTC
+func (c *GrpcClientConfig) GetDates() (*timestamp.Timestamp, *timestamp.Timestamp, error) {
+	st, err := time.Parse(DateLayout, c.Date)
+	if err != nil {
+		return nil, nil, err
+	}
+	et := st
+	if c.EndDate != "" {
+		if et, err = time.Parse(DateLayout, c.EndDate); err != nil {
+			return nil, nil, err
+		}
+	}
+	if et.Before(st) {
+		return nil, nil, fmt.Errorf("end date is before date")
+	}
+	start, err := ptypes.TimestampProto(st)
+	if err != nil {
+		return nil, nil, err
+	}
+	end, err := ptypes.TimestampProto(et.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, nil, err
+	}
+	return start, end, nil
+}
+
+// GetReminders returns nil if reminders aren't set, server uses default reminder then
+func (c *GrpcClientConfig) GetReminders() ([]*duration.Duration, error) {
+	var res []*duration.Duration
+	for _, r := range c.Reminders {
+		d, err := time.ParseDuration(r)
+		if err != nil {
+			return nil, fmt.Errorf("reminder `%s` is incorrect: %s", r, err)
+		}
+		res = append(res, ptypes.DurationProto(d))
+	}
+	return res, nil
+}
+
+// GetOccurrence returns nil if occurrence isn't set
+func (c *GrpcClientConfig) GetOccurrence() (*timestamp.Timestamp, error) {
+	if c.Occurrence == "" {
+		return nil, nil
+	}
+	loc, err := c.GetLocation()
+	if err != nil {
+		return nil, err
+	}
+	return parseTs(c.Occurrence, c.TsLayout, loc)
+}
+
+func (c *GrpcClientConfig) GetScope() (api.Scope, error) {
+	switch c.Scope {
+	case "", "all":
+		return api.Scope_ALL, nil
+	case "this":
+		return api.Scope_THIS, nil
+	case "following":
+		return api.Scope_THIS_AND_FOLLOWING, nil
+	}
+	return api.Scope_ALL, fmt.Errorf("unknown scope `%s`", c.Scope)
+}
+
+func (c *GrpcClientConfig) GetRole() (api.Role, error) {
+	switch c.Role {
+	case "free-busy":
+		return api.Role_FREE_BUSY, nil
+	case "", "read":
+		return api.Role_READ, nil
+	case "write":
+		return api.Role_WRITE, nil
+	}
+	return api.Role_READ, fmt.Errorf("unknown role `%s`", c.Role)
+}
+
+func (c *GrpcClientConfig) GetStatus() (api.AttendeeStatus, error) {
+	switch c.Status {
+	case "needs-action":
+		return api.AttendeeStatus_NEEDS_ACTION, nil
+	case "", "accepted":
+		return api.AttendeeStatus_ACCEPTED, nil
+	case "declined":
+		return api.AttendeeStatus_DECLINED, nil
+	case "tentative":
+		return api.AttendeeStatus_TENTATIVE, nil
+	}
+	return api.AttendeeStatus_NEEDS_ACTION, fmt.Errorf("unknown status `%s`", c.Status)
+}
+
+func (c *GrpcClientConfig) GetTransparency() (api.Transparency, error) {
+	switch c.Transparency {
+	case "", "busy":
+		return api.Transparency_BUSY, nil
+	case "free":
+		return api.Transparency_FREE, nil
+	case "tentative":
+		return api.Transparency_TENTATIVE, nil
+	}
+	return api.Transparency_BUSY, fmt.Errorf("unknown transparency `%s`", c.Transparency)
+}
+
+// GetMetadata returns owner and credentials of requests
+func (c *GrpcClientConfig) GetMetadata() metadata.MD {
+	md := metadata.Pairs("owner", c.Owner)
+	switch {
+	case c.Token != "":
+		md.Set("authorization", "Bearer "+c.Token)
+	case c.ApiKey != "":
+		md.Set("x-api-key", c.ApiKey)
+	}
+	return md
 }
 
 func newGrpcClientConfig() *GrpcClientConfig {
 	return &GrpcClientConfig{
-		Port:      viper.GetString("grpc-cli-port"),
-		Host:      viper.GetString("grpc-cli-host"),
-		Title:     viper.GetString("title"),
-		Text:      viper.GetString("body"),
-		Id:        viper.GetString("id"),
-		Owner:     viper.GetString("owner"),
-		StartTime: viper.GetString("start-time"),
-		EndTime:   viper.GetString("end-time"),
-		TsLayout:  viper.GetString("ts-layout"),
+		Port:          viper.GetString("grpc-cli-port"),
+		Host:          viper.GetString("grpc-cli-host"),
+		Title:         viper.GetString("title"),
+		Text:          viper.GetString("body"),
+		Id:            viper.GetString("id"),
+		Owner:         viper.GetString("owner"),
+		StartTime:     viper.GetString("start-time"),
+		EndTime:       viper.GetString("end-time"),
+		TsLayout:      viper.GetString("ts-layout"),
+		Recurrence:    viper.GetString("recurrence"),
+		Scope:         viper.GetString("scope"),
+		Occurrence:    viper.GetString("occurrence"),
+		Format:        viper.GetString("format"),
+		Output:        viper.GetString("output"),
+		FeedToken:     viper.GetString("feed-token"),
+		Token:         viper.GetString("token"),
+		ApiKey:        viper.GetString("api-key"),
+		CalendarOwner: viper.GetString("calendar-owner"),
+		Grantee:       viper.GetString("grantee"),
+		Role:          viper.GetString("role"),
+		Owners:        viper.GetStringSlice("owners"),
+		Attendees:     viper.GetStringSlice("attendees"),
+		Status:        viper.GetString("status"),
+		Transparency:  viper.GetString("transparency"),
+		Duration:      viper.GetDuration("duration"),
+		Count:         viper.GetInt("count"),
+		WorkStart:     viper.GetString("work-start"),
+		WorkEnd:       viper.GetString("work-end"),
+		TimeZone:      viper.GetString("tz"),
+		AllDay:        viper.GetBool("all-day"),
+		Date:          viper.GetString("date"),
+		EndDate:       viper.GetString("end-date"),
+		Reminders:     viper.GetStringSlice("reminders"),
 	}
 }
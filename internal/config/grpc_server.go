@@ -9,12 +9,14 @@ type GrpcServerConfig struct {
 	Host        string
 	Port        string
 	MetricsPort string
+	HttpPort    string
 }
 
 func GetGrpcServerConfig() *GrpcServerConfig {
 	log.Println("Configuring server...")
 	viper.SetDefault("grpc-srv-host", "localhost")
 	viper.SetDefault("grpc-srv-port", "8080")
+	viper.SetDefault("http-port", "8081")
 	return newGrpcServerConfig()
 }
 
@@ -23,5 +25,6 @@ func newGrpcServerConfig() *GrpcServerConfig {
 		Host:        viper.GetString("grpc-srv-host"),
 		Port:        viper.GetString("grpc-srv-port"),
 		MetricsPort: viper.GetString("metrics-port"),
+		HttpPort:    viper.GetString("http-port"),
 	}
 }
@@ -23,3 +23,8 @@ func newDbConfig() *StorageConfig {
 		StorageType: viper.GetString("storage"),
 	}
 }
+
+// NeedsDsn reports if the storage is external and can't be opened without connection string
+func (c *StorageConfig) NeedsDsn() bool {
+	return c.StorageType != "memory"
+}
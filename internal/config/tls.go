@@ -0,0 +1,123 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"io/ioutil"
+	"log"
+)
+
+type ServerTlsConfig struct {
+	CertFile string
+	KeyFile  string
+	// ClientCaFile enables mutual TLS, client certificates are verified with it
+	ClientCaFile string
+	// CertOwner takes owner from common name of client certificate subject
+	CertOwner bool
+}
+
+type ClientTlsConfig struct {
+	Enabled bool
+	// CaFile verifies server certificate, system roots are used if it's empty
+	CaFile   string
+	CertFile string
+	KeyFile  string
+	// ServerName overrides host name which is expected in server certificate
+	ServerName string
+}
+
+func GetServerTlsConfig() *ServerTlsConfig {
+	log.Println("Configuring TLS...")
+	viper.SetDefault("tls-cert-file", "")
+	viper.SetDefault("tls-key-file", "")
+	viper.SetDefault("tls-client-ca-file", "")
+	viper.SetDefault("tls-cert-owner", false)
+	return &ServerTlsConfig{
+		CertFile:     viper.GetString("tls-cert-file"),
+		KeyFile:      viper.GetString("tls-key-file"),
+		ClientCaFile: viper.GetString("tls-client-ca-file"),
+		CertOwner:    viper.GetBool("tls-cert-owner"),
+	}
+}
+
+func GetClientTlsConfig() *ClientTlsConfig {
+	viper.SetDefault("tls", false)
+	viper.SetDefault("tls-ca-file", "")
+	viper.SetDefault("tls-client-cert-file", "")
+	viper.SetDefault("tls-client-key-file", "")
+	viper.SetDefault("tls-server-name", "")
+	return &ClientTlsConfig{
+		Enabled:    viper.GetBool("tls"),
+		CaFile:     viper.GetString("tls-ca-file"),
+		CertFile:   viper.GetString("tls-client-cert-file"),
+		KeyFile:    viper.GetString("tls-client-key-file"),
+		ServerName: viper.GetString("tls-server-name"),
+	}
+}
+
+// Tls returns TLS configuration of server, nil if certificate isn't configured.
+// Client certificates are required if client CA is set
+func (c *ServerTlsConfig) Tls() (*tls.Config, error) {
+	if c.CertFile == "" && c.KeyFile == "" {
+		if c.ClientCaFile != "" || c.CertOwner {
+			return nil, errors.New("server certificate is required for mutual TLS")
+		}
+		return nil, nil
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't load server certificate")
+	}
+	conf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.ClientCaFile != "" {
+		if conf.ClientCAs, err = loadCertPool(c.ClientCaFile); err != nil {
+			return nil, err
+		}
+		conf.ClientAuth = tls.RequireAndVerifyClientCert
+	} else if c.CertOwner {
+		return nil, errors.New("client CA is required to take owner from certificate")
+	}
+	return conf, nil
+}
+
+// Tls returns TLS configuration of client, nil if TLS is disabled
+func (c *ClientTlsConfig) Tls() (*tls.Config, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+	conf := &tls.Config{
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	var err error
+	if c.CaFile != "" {
+		if conf.RootCAs, err = loadCertPool(c.CaFile); err != nil {
+			return nil, err
+		}
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't load client certificate")
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read CA certificates")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("CA certificates are not found in `%s`", file)
+	}
+	return pool, nil
+}
@@ -7,7 +7,20 @@ func (ee EventError) Error() string {
 }
 
 var (
-	ErrNotFound         = EventError("event not found")
-	ErrOverlaping       = EventError("another event exists for this date")
-	ErrIncorrectEndDate = EventError("end-date is incorrect")
+	ErrNotFound              = EventError("event not found")
+	ErrOverlaping            = EventError("another event exists for this date")
+	ErrIncorrectEndDate      = EventError("end-date is incorrect")
+	ErrIncorrectRecurrence   = EventError("recurrence rule is incorrect")
+	ErrIncorrectOccurrence   = EventError("occurrence of recurring event is incorrect")
+	ErrAccessDenied          = EventError("access to calendar is denied")
+	ErrIncorrectRole         = EventError("role is incorrect")
+	ErrIncorrectGrantee      = EventError("grantee is incorrect")
+	ErrIncorrectDuration     = EventError("duration is incorrect")
+	ErrIncorrectHours        = EventError("working hours are incorrect")
+	ErrIncorrectAttendee     = EventError("attendee is incorrect")
+	ErrIncorrectStatus       = EventError("attendee status is incorrect")
+	ErrIncorrectTransparency = EventError("event transparency is incorrect")
+	ErrIncorrectTimeZone     = EventError("time zone is incorrect")
+	ErrIncorrectReminder     = EventError("reminder is incorrect")
+	ErrLeaseLost             = EventError("lease is held by another replica")
 )
@@ -6,5 +6,5 @@ import (
 )
 
 type EventSender interface {
-	SendEvent(ctx context.Context, event *models.Event) error
+	SendNotification(ctx context.Context, notification *models.Notification) error
 }
@@ -7,14 +7,55 @@ import (
 )
 
 type EventStorage interface {
-	SaveEvent(ctx context.Context, event *models.Event) error
+	// SaveEvent, UpdateEventByIdOwner and DeleteEventByIdOwner save outbox messages about the change
+	// in the same transaction
+	SaveEvent(ctx context.Context, event *models.Event, messages []*models.OutboxMessage) error
 	GetEventByIdOwner(ctx context.Context, id, owner string) (*models.Event, error)
-	GetEventsForNotification(ctx context.Context, startTime time.Time, period time.Duration) ([]*models.Event, error)
+	GetEventByUidOwner(ctx context.Context, uid, owner string) (*models.Event, error)
 	GetEventsByOwnerStartDate(ctx context.Context, owner string, startTime *time.Time) ([]*models.Event, error)
 	GetEventsCountByOwnerStartDateEndDate(ctx context.Context, owner string, startTime, endTime *time.Time) (int, error)
-	DeleteEventByIdOwner(ctx context.Context, id, owner string) error
+	GetEventsByOwnerStartDateEndDate(ctx context.Context, owner string, startTime, endTime *time.Time) ([]*models.Event, error)
+	GetEventsBySeriesIdOwner(ctx context.Context, seriesId, owner string) ([]*models.Event, error)
+	GetEventBySeriesIdOwnerStartDate(ctx context.Context, seriesId, owner string, startTime *time.Time) (*models.Event, error)
+	DeleteEventByIdOwner(ctx context.Context, id, owner string, messages []*models.OutboxMessage) error
+	DeleteEventsBySeriesIdOwnerStartDate(ctx context.Context, seriesId, owner string, startTime *time.Time) (int64, error)
 	DeleteEventsOlderDateByIdOwner(ctx context.Context, date *time.Time, owner string) (int64, error)
-	UpdateEventByIdOwner(ctx context.Context, id string, event *models.Event) error
-	MarkEventNotified(ctx context.Context, id string) error
+	// UpdateEventByIdOwner, UpdateSeries and SplitSeries forget notified reminders of the updated event
+	// in the same transaction, if its start is moved
+	UpdateEventByIdOwner(ctx context.Context, id string, event *models.Event, messages []*models.OutboxMessage) error
+	// UpdateSeries and SplitSeries change series together with its overrides and save outbox messages
+	// in one transaction
+	UpdateSeries(ctx context.Context, series *models.Event, since *time.Time, messages []*models.OutboxMessage) error
+	SplitSeries(ctx context.Context, series *models.Event, till time.Time, event *models.Event, messages []*models.OutboxMessage) error
+	// GetEventsForNotification returns events, which start within period and haven't ended at startTime,
+	// recurring events started within period, which series haven't ended, and overrides of occurrences,
+	// which start within period and haven't ended
+	GetEventsForNotification(ctx context.Context, startTime time.Time, period time.Duration) ([]*models.Event, error)
+	GetNotifiedReminders(ctx context.Context, id string, startTime time.Time) (models.Reminders, error)
+	// MarkReminderNotified saves outbox messages in the same transaction, messages are skipped
+	// if reminder of event or occurrence started at startTime is already notified
+	MarkReminderNotified(ctx context.Context, id string, startTime time.Time, reminder time.Duration, messages []*models.OutboxMessage) error
+	GetPendingOutboxMessages(ctx context.Context, limit int) ([]*models.OutboxMessage, error)
+	MarkOutboxMessageSent(ctx context.Context, id string, sentAt time.Time) error
+	DeleteOutboxMessagesSentBefore(ctx context.Context, date time.Time) (int64, error)
+	// IsMessageProcessed checks if notification with the message id is already sent by sender
+	IsMessageProcessed(ctx context.Context, id string) (bool, error)
+	MarkMessageProcessed(ctx context.Context, id string, processedAt time.Time) error
+	DeleteMessagesProcessedBefore(ctx context.Context, date time.Time) (int64, error)
+	// AcquireLease takes free or expired at now lease, or renews lease of the same holder
+	AcquireLease(ctx context.Context, lease *models.Lease, now time.Time) (bool, error)
+	ReleaseLease(ctx context.Context, name, holder string) error
+	SaveFeedToken(ctx context.Context, token *models.FeedToken) error
+	GetFeedToken(ctx context.Context, token string) (*models.FeedToken, error)
+	GetFeedTokensByOwner(ctx context.Context, owner string) ([]*models.FeedToken, error)
+	DeleteFeedTokenByTokenOwner(ctx context.Context, token, owner string) error
+	SaveGrant(ctx context.Context, grant *models.Grant) error
+	GetGrant(ctx context.Context, owner, grantee string) (*models.Grant, error)
+	GetGrantsByOwner(ctx context.Context, owner string) ([]*models.Grant, error)
+	DeleteGrantByOwnerGrantee(ctx context.Context, owner, grantee string) error
+	SaveAttendee(ctx context.Context, eventId string, attendee *models.Attendee) error
+	GetAttendeesByEventId(ctx context.Context, eventId string) ([]*models.Attendee, error)
+	UpdateAttendeeStatus(ctx context.Context, eventId, name string, status models.AttendeeStatus) error
+	GetEventsByAttendeeStartDate(ctx context.Context, attendee string, startTime *time.Time) ([]*models.Event, error)
 	Close(ctx context.Context)
 }
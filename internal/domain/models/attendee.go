@@ -0,0 +1,27 @@
+package models
+
+// AttendeeStatus is RSVP of invited attendee
+type AttendeeStatus string
+
+const (
+	StatusNeedsAction = AttendeeStatus("needs-action")
+	StatusAccepted    = AttendeeStatus("accepted")
+	StatusDeclined    = AttendeeStatus("declined")
+	StatusTentative   = AttendeeStatus("tentative")
+)
+
+func (s AttendeeStatus) IsValid() bool {
+	switch s {
+	case StatusNeedsAction, StatusAccepted, StatusDeclined, StatusTentative:
+		return true
+	}
+	return false
+}
+
+// Attendee is user invited to event by its owner, attendees of recurring event are shared
+// by all its occurrences and overrides
+type Attendee struct {
+	// Name is owner name of invited user
+	Name   string
+	Status AttendeeStatus
+}
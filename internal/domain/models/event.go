@@ -11,9 +11,94 @@ type Event struct {
 	Owner     string
 	Title     string
 	Text      string
-	Notified  bool
 	StartTime *time.Time `db:"start_time"`
 	EndTime   *time.Time `db:"end_time"`
+	// Recurrence is RFC 5545 RRULE value, e.g. `FREQ=WEEKLY;COUNT=52`, empty for single events
+	Recurrence string
+	// SeriesEnd is end of the last occurrence of recurring event with COUNT or UNTIL, nil for endless series
+	SeriesEnd *time.Time `db:"series_end"`
+	// SeriesId is set for occurrences of recurring event and for their overrides
+	SeriesId *uuid.UUID `db:"series_id"`
+	// OriginalStartTime is start time of occurrence replaced by override
+	OriginalStartTime *time.Time `db:"original_start_time"`
+	// Cancelled override removes occurrence from series
+	Cancelled bool
+	// Uid is RFC 5545 UID of the event, it's equal to Id unless event is imported
+	Uid string
+	// Transparency defines if the event blocks other bookings, only busy events do
+	Transparency Transparency
+	// AllDay event lasts whole calendar dates, its start and end times are midnights in UTC,
+	// end is exclusive, so one day event ends at midnight of the next day
+	AllDay bool `db:"all_day"`
+	// TimeZone is IANA time zone of the event, recurring event keeps local time of its start in it.
+	// Empty time zone is UTC
+	TimeZone string `db:"time_zone"`
+	// Reminders are sorted from the earliest one, event without reminders gets DefaultReminder
+	Reminders Reminders
+	// Attendees are loaded separately from the event
+	Attendees []*Attendee `db:"-"`
+}
+
+// Transparency is availability of owner during the event
+type Transparency string
+
+const (
+	TransparencyBusy      = Transparency("busy")
+	TransparencyFree      = Transparency("free")
+	TransparencyTentative = Transparency("tentative")
+)
+
+func (t Transparency) IsValid() bool {
+	switch t {
+	case TransparencyBusy, TransparencyFree, TransparencyTentative:
+		return true
+	}
+	return false
+}
+
+// Scope of changes of recurring event series
+type Scope int
+
+const (
+	ScopeAll Scope = iota
+	ScopeThis
+	ScopeThisAndFollowing
+)
+
+func (e Event) IsRecurring() bool {
+	return e.Recurrence != ""
+}
+
+// IsBusy returns true if the event can't overlap with other busy events and is shown in free/busy time
+func (e Event) IsBusy() bool {
+	return e.Transparency == TransparencyBusy || e.Transparency == ""
+}
+
+// IsBlocking returns true if the event can't overlap with other blocking events,
+// all-day events are dates and don't block bookings of any time of the day
+func (e Event) IsBlocking() bool {
+	return e.IsBusy() && !e.AllDay
+}
+
+// EventReminders returns reminders of the event or DefaultReminder if they aren't set
+func (e Event) EventReminders() Reminders {
+	if len(e.Reminders) == 0 {
+		return Reminders{DefaultReminder}
+	}
+	return e.Reminders
+}
+
+// IsOverride returns true for stored modified or cancelled occurrence of series
+func (e Event) IsOverride() bool {
+	return e.SeriesId != nil && *e.SeriesId != e.Id
+}
+
+// SeriesOrEventId returns id of series for occurrences and overrides, id of the event otherwise
+func (e Event) SeriesOrEventId() uuid.UUID {
+	if e.SeriesId != nil {
+		return *e.SeriesId
+	}
+	return e.Id
 }
 
 func (e Event) String() string {
@@ -22,8 +107,9 @@ func (e Event) String() string {
 Id: %s
 title: %s
 From: %s, To: %s
+Recurrence: %s
 Owner: %s
 ---
 %s
-`, e.Id, e.Title, e.StartTime, e.EndTime, e.Owner, e.Text)
+`, e.Id, e.Title, e.StartTime, e.EndTime, e.Recurrence, e.Owner, e.Text)
 }
@@ -0,0 +1,13 @@
+package models
+
+// EventObject is single event or recurring event with overrides of its occurrences,
+// it's stored by calendar clients as one resource
+type EventObject struct {
+	Event     *Event
+	Overrides []*Event
+}
+
+// Events returns the event followed by its overrides
+func (o *EventObject) Events() []*Event {
+	return append([]*Event{o.Event}, o.Overrides...)
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// FeedToken gives read-only access to owner's calendar feed without authentication
+type FeedToken struct {
+	Token     string
+	Owner     string
+	CreatedAt *time.Time `db:"created_at"`
+}
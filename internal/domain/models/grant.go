@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Role is access level to another owner's calendar, each role includes lower ones
+type Role string
+
+const (
+	// RoleFreeBusy allows to see busy time of events only
+	RoleFreeBusy = Role("free-busy")
+	RoleRead     = Role("read")
+	RoleWrite    = Role("write")
+)
+
+var roleLevels = map[Role]int{RoleFreeBusy: 1, RoleRead: 2, RoleWrite: 3}
+
+func (r Role) IsValid() bool {
+	return roleLevels[r] > 0
+}
+
+// Includes reports whether role gives access of required one
+func (r Role) Includes(required Role) bool {
+	return roleLevels[r] >= roleLevels[required]
+}
+
+// Grant gives grantee access to owner's calendar
+type Grant struct {
+	Owner     string
+	Grantee   string
+	Role      Role
+	CreatedAt *time.Time `db:"created_at"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/satori/go.uuid"
+	"time"
+)
+
+// ImportStatus is outcome of imported event
+type ImportStatus int
+
+const (
+	ImportCreated ImportStatus = iota
+	ImportDuplicate
+	ImportRejected
+)
+
+type ImportResult struct {
+	Uid   string
+	Title string
+	// OriginalStartTime is set for imported overrides of recurring events
+	OriginalStartTime *time.Time
+	Status            ImportStatus
+	// EventId is id of created event
+	EventId uuid.UUID
+	// Reason of rejection
+	Reason string
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Interval is [Start, End) period of time, e.g. busy time of events without their details or free slot
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// WorkingHours are the same hours of every day in Location, End before Start means night shift.
+// Hours are durations since midnight
+type WorkingHours struct {
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
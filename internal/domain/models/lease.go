@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Lease is held by one of service replicas till it expires, holder renews it to stay the leader
+type Lease struct {
+	Name      string
+	Holder    string
+	ExpiresAt *time.Time `db:"expires_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github.com/satori/go.uuid"
+	"time"
+)
+
+// NotificationType is kind of notification, reminders are sent before start of event,
+// other notifications are sent on changes of event
+type NotificationType string
+
+const (
+	NotificationReminder  = NotificationType("reminder")
+	NotificationCreated   = NotificationType("created")
+	NotificationUpdated   = NotificationType("updated")
+	NotificationCancelled = NotificationType("cancelled")
+)
+
+// Notification about event is sent to every recipient separately, fields of event are kept
+// on the top level of its JSON. Event is the state after change, cancelled event is sent on cancellation
+type Notification struct {
+	*Event
+	Recipient string
+	// MessageId is id of outbox message, repeatedly published notifications have the same one
+	MessageId uuid.UUID
+	// Type is empty in notifications published before types were added, they are reminders
+	Type NotificationType
+	// Reminder is offset of the notification before start of event
+	Reminder time.Duration
+	// Before is the state of updated or cancelled event before change
+	Before *Event `json:",omitempty"`
+}
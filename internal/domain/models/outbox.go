@@ -0,0 +1,16 @@
+package models
+
+import (
+	"github.com/satori/go.uuid"
+	"time"
+)
+
+// OutboxMessage is notification saved in the same transaction as the change, which caused it.
+// It's published till it's marked sent, so it can be published more than once
+type OutboxMessage struct {
+	Id uuid.UUID
+	// Payload is JSON of Notification
+	Payload   string
+	CreatedAt *time.Time `db:"created_at"`
+	SentAt    *time.Time `db:"sent_at"`
+}
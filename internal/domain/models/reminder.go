@@ -0,0 +1,80 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultReminder is sent for events without reminders
+	DefaultReminder = 24 * time.Hour
+	// MaxReminderOffset is the longest time between reminder and start of event
+	MaxReminderOffset = 28 * 24 * time.Hour
+)
+
+// Reminders are offsets of notifications before start of event, zero offset is reminder at start.
+// They are stored as comma separated seconds
+type Reminders []time.Duration
+
+// Normalize sorts reminders from the earliest one and removes duplicates
+func (r Reminders) Normalize() Reminders {
+	if len(r) == 0 {
+		return nil
+	}
+	res := append(Reminders(nil), r...)
+	sort.Slice(res, func(i, j int) bool { return res[i] > res[j] })
+	n := 1
+	for _, d := range res[1:] {
+		if d != res[n-1] {
+			res[n] = d
+			n++
+		}
+	}
+	return res[:n]
+}
+
+func (r Reminders) IsValid() bool {
+	for _, d := range r {
+		if d < 0 || d > MaxReminderOffset || d%time.Second != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Reminders) Value() (driver.Value, error) {
+	res := make([]string, 0, len(r))
+	for _, d := range r {
+		res = append(res, strconv.FormatInt(int64(d/time.Second), 10))
+	}
+	return strings.Join(res, ","), nil
+}
+
+func (r *Reminders) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case nil:
+	default:
+		return fmt.Errorf("can't scan reminders from %T", src)
+	}
+	*r = nil
+	if s == "" {
+		return nil
+	}
+	for _, f := range strings.Split(s, ",") {
+		sec, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return fmt.Errorf("can't scan reminder `%s`: %w", f, err)
+		}
+		*r = append(*r, time.Duration(sec)*time.Second)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"log"
+)
+
+// InviteAttendees adds attendees to owner's event with needs-action status, already invited attendees
+// keep their status. Attendees of occurrence are added to the whole series
+func (es *EventService) InviteAttendees(ctx context.Context, id, owner string, attendees []string) (*models.Event, error) {
+	if len(attendees) == 0 {
+		return nil, errors.ErrIncorrectAttendee
+	}
+	for _, a := range attendees {
+		if a == "" || a == owner {
+			return nil, errors.ErrIncorrectAttendee
+		}
+	}
+	event, err := es.GetEvent(ctx, id, owner)
+	if err != nil {
+		return nil, err
+	}
+	seriesId := event.SeriesOrEventId().String()
+	for _, a := range attendees {
+		if err := es.EventStorage.SaveAttendee(ctx, seriesId, &models.Attendee{Name: a, Status: models.StatusNeedsAction}); err != nil {
+			log.Printf("can't invite `%s` to event `%s`: %s", a, id, err)
+			return nil, err
+		}
+	}
+	if event.Attendees, err = es.EventStorage.GetAttendeesByEventId(ctx, seriesId); err != nil {
+		log.Printf("can't get attendees of event `%s`: %s", id, err)
+		return nil, err
+	}
+	return event, nil
+}
+
+// RespondToInvitation sets status of attendee in event, which attendee is invited to
+func (es *EventService) RespondToInvitation(ctx context.Context, id, attendee string, status models.AttendeeStatus) error {
+	if !status.IsValid() {
+		return errors.ErrIncorrectStatus
+	}
+	if _, err := parseUuid(id); err != nil {
+		return err
+	}
+	if err := es.EventStorage.UpdateAttendeeStatus(ctx, id, attendee, status); err != nil {
+		log.Printf("can't set status of `%s` in event `%s`: %s", attendee, id, err)
+		return err
+	}
+	return nil
+}
+
+// loadAttendees sets attendees of events, they are queried once per series
+func (es *EventService) loadAttendees(ctx context.Context, events []*models.Event) error {
+	bySeries := make(map[uuid.UUID][]*models.Attendee)
+	for _, e := range events {
+		seriesId := e.SeriesOrEventId()
+		attendees, ok := bySeries[seriesId]
+		if !ok {
+			var err error
+			if attendees, err = es.EventStorage.GetAttendeesByEventId(ctx, seriesId.String()); err != nil {
+				log.Printf("can't get attendees of event `%s`: %s", seriesId, err)
+				return err
+			}
+			bySeries[seriesId] = attendees
+		}
+		e.Attendees = attendees
+	}
+	return nil
+}
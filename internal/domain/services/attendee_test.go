@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"testing"
+	"time"
+)
+
+func TestEventService_InviteAttendees(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 10, 10, 10, 0, 0, 0, time.UTC)
+
+	daily := newTestEvent("manager", "standup", day, 15*time.Minute)
+	daily.Recurrence = "FREQ=DAILY;COUNT=3"
+	daily, err := es.CreateEvent(ctx, daily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := es.InviteAttendees(ctx, daily.Id.String(), "manager", []string{"manager"}); err != errors.ErrIncorrectAttendee {
+		t.Errorf("expected %q, got %v", errors.ErrIncorrectAttendee, err)
+	}
+	if _, err := es.InviteAttendees(ctx, daily.Id.String(), "developer", []string{"tester"}); err != errors.ErrNotFound {
+		t.Errorf("only owner can invite attendees, got %v", err)
+	}
+	invited, err := es.InviteAttendees(ctx, daily.Id.String(), "manager", []string{"developer", "tester"})
+	if err != nil {
+		t.Fatalf("can't invite attendees: %s", err)
+	}
+	if len(invited.Attendees) != 2 || invited.Attendees[0].Status != models.StatusNeedsAction {
+		t.Errorf("expected 2 attendees with needs-action status, got %v", invited.Attendees)
+	}
+
+	list := func(owner string) []*models.Event {
+		t.Helper()
+		events, err := es.ListEvents(ctx, owner, &day, nil)
+		if err != nil {
+			t.Fatalf("can't list events: %s", err)
+		}
+		return events
+	}
+	if events := list("developer"); len(events) != 3 || events[0].Owner != "manager" || len(events[0].Attendees) != 2 {
+		t.Errorf("expected 3 occurrences of invited event, got %d", len(events))
+	}
+	if err := es.RespondToInvitation(ctx, daily.Id.String(), "developer", models.AttendeeStatus("maybe")); err != errors.ErrIncorrectStatus {
+		t.Errorf("expected %q, got %v", errors.ErrIncorrectStatus, err)
+	}
+	if err := es.RespondToInvitation(ctx, daily.Id.String(), "stranger", models.StatusAccepted); err != errors.ErrNotFound {
+		t.Errorf("not invited user can't respond, got %v", err)
+	}
+	if err := es.RespondToInvitation(ctx, daily.Id.String(), "developer", models.StatusDeclined); err != nil {
+		t.Fatalf("can't respond to invitation: %s", err)
+	}
+	if events := list("developer"); len(events) != 0 {
+		t.Errorf("declined event shouldn't be listed, got %d", len(events))
+	}
+	if events := list("tester"); len(events) != 3 {
+		t.Errorf("expected 3 occurrences of invited event, got %d", len(events))
+	}
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"log"
+	"time"
+)
+
+// change is notification about created, updated or cancelled event. Its outbox messages are saved
+// in transaction of the first write of the change, so the change fails if they can't be saved
+type change struct {
+	notificationType models.NotificationType
+	recipients       []string
+	// before is nil for created event
+	before *models.Event
+}
+
+// newChange returns notification to owner of the event and every attendee, who hasn't declined invitation,
+// they are looked up before the change, since attendees are deleted together with event
+func (es *EventService) newChange(ctx context.Context, notificationType models.NotificationType, before *models.Event) (*change, error) {
+	attendees, err := es.EventStorage.GetAttendeesByEventId(ctx, before.SeriesOrEventId().String())
+	if err != nil {
+		log.Printf("can't get attendees of event `%s`: %s", before.Id, err)
+		return nil, err
+	}
+	recipients := []string{before.Owner}
+	for _, a := range attendees {
+		if a.Status != models.StatusDeclined {
+			recipients = append(recipients, a.Name)
+		}
+	}
+	return &change{notificationType: notificationType, recipients: recipients, before: before}, nil
+}
+
+// messages returns outbox messages about the change to every recipient, after is nil for cancelled event
+func (c *change) messages(after *models.Event) ([]*models.OutboxMessage, error) {
+	event := after
+	if event == nil {
+		cancelled := *c.before
+		cancelled.Cancelled = true
+		event = &cancelled
+	}
+	now := time.Now()
+	messages := make([]*models.OutboxMessage, 0, len(c.recipients))
+	for _, r := range c.recipients {
+		m, err := outboxMessage(&models.Notification{Event: event, Recipient: r, Type: c.notificationType, Before: c.before}, now)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// snapshot returns copy of event or occurrence changed by update or delete with the scope
+func (t *seriesTarget) snapshot(scope models.Scope) *models.Event {
+	var e models.Event
+	switch {
+	case t.series == nil:
+		e = *t.event
+	case scope == models.ScopeAll || (scope == models.ScopeThisAndFollowing && t.isFirst()):
+		e = *t.series
+	case t.event.IsOverride():
+		e = *t.event
+	default:
+		occs, err := occurrences(t.series, *t.occurrenceStart, *t.occurrenceStart)
+		if err != nil || len(occs) == 0 {
+			e = *t.series
+			break
+		}
+		e = *occs[0]
+	}
+	return &e
+}
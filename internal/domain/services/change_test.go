@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Brialius/calendar/internal/domain/interfaces"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/memdb"
+	"testing"
+	"time"
+)
+
+// pendingNotifications decodes pending outbox messages and marks them sent
+func pendingNotifications(ctx context.Context, t *testing.T, storage interfaces.EventStorage) []*models.Notification {
+	pending, err := storage.GetPendingOutboxMessages(ctx, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res []*models.Notification
+	for _, m := range pending {
+		notification := &models.Notification{}
+		if err := json.Unmarshal([]byte(m.Payload), notification); err != nil {
+			t.Fatal(err)
+		}
+		res = append(res, notification)
+		if err := storage.MarkOutboxMessageSent(ctx, m.Id.String(), time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res
+}
+
+func TestEventService_ChangeNotifications(t *testing.T) {
+	ctx := context.Background()
+	storage, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := &EventService{EventStorage: storage}
+	start := time.Now().UTC().Truncate(time.Second).Add(time.Hour)
+
+	event, err := es.CreateEvent(ctx, newTestEvent("manager", "meeting", start, time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := pendingNotifications(ctx, t, storage)
+	if len(created) != 1 || created[0].Type != models.NotificationCreated || created[0].Recipient != "manager" || created[0].Before != nil {
+		t.Fatalf("expected created notification to owner, got %v", created)
+	}
+
+	if _, err := es.InviteAttendees(ctx, event.Id.String(), "manager", []string{"developer", "tester"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := es.RespondToInvitation(ctx, event.Id.String(), "tester", models.StatusDeclined); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.MarkReminderNotified(ctx, event.Id.String(), start, models.DefaultReminder, nil); err != nil {
+		t.Fatal(err)
+	}
+	moved := start.Add(2 * time.Hour)
+	if _, err := es.UpdateEvent(ctx, event.Id.String(), models.ScopeAll, nil, newTestEvent("manager", "meeting", moved, time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	updated := pendingNotifications(ctx, t, storage)
+	recipients := make(map[string]bool)
+	for _, n := range updated {
+		recipients[n.Recipient] = true
+		if n.Type != models.NotificationUpdated || n.Before == nil || !n.Before.StartTime.Equal(start) || !n.StartTime.Equal(moved) {
+			t.Errorf("expected updated notification with times before and after, got %+v", n)
+		}
+	}
+	if len(updated) != 2 || !recipients["manager"] || !recipients["developer"] {
+		t.Errorf("expected notifications to owner and attendee, who hasn't declined, got %v", recipients)
+	}
+	if notified, err := storage.GetNotifiedReminders(ctx, event.Id.String(), start); err != nil || len(notified) != 0 {
+		t.Errorf("reminders of moved event should be reset, got %v (%v)", notified, err)
+	}
+
+	if err := es.DeleteEvent(ctx, event.Id.String(), "manager", models.ScopeAll, nil); err != nil {
+		t.Fatal(err)
+	}
+	cancelled := pendingNotifications(ctx, t, storage)
+	if len(cancelled) != 2 || cancelled[0].Type != models.NotificationCancelled || !cancelled[0].Cancelled ||
+		!cancelled[0].StartTime.Equal(moved) {
+		t.Errorf("expected cancelled notifications about deleted event, got %v", cancelled)
+	}
+
+	daily := newTestEvent("manager", "daily", start, time.Hour)
+	daily.Recurrence = "FREQ=DAILY"
+	if daily, err = es.CreateEvent(ctx, daily); err != nil {
+		t.Fatal(err)
+	}
+	occurrence := start.AddDate(0, 0, 2)
+	pendingNotifications(ctx, t, storage)
+	if err := es.DeleteEvent(ctx, daily.Id.String(), "manager", models.ScopeThis, &occurrence); err != nil {
+		t.Fatal(err)
+	}
+	cancelled = pendingNotifications(ctx, t, storage)
+	if len(cancelled) != 1 || !cancelled[0].StartTime.Equal(occurrence) || *cancelled[0].SeriesId != daily.Id {
+		t.Errorf("expected cancelled notification about occurrence, got %v", cancelled)
+	}
+}
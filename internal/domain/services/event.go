@@ -16,18 +16,24 @@ type EventService struct {
 
 func (es *EventService) CreateEvent(ctx context.Context, event *models.Event) (*models.Event, error) {
 	event.Id = uuid.NewV4()
+	if event.Uid == "" {
+		event.Uid = event.Id.String()
+	}
+	normalizeEvent(event)
 
-	count, err := es.EventStorage.GetEventsCountByOwnerStartDateEndDate(ctx, event.Owner, event.StartTime, event.EndTime)
-	if err != nil {
+	if err := validateEvent(event); err != nil {
 		return nil, err
 	}
-	if count > 0 {
-		return nil, errors.ErrOverlaping
+	event.SeriesEnd = seriesEnd(event)
+	if err := es.checkOverlaps(ctx, event); err != nil {
+		return nil, err
 	}
-	if event.StartTime.After(*event.EndTime) {
-		return nil, errors.ErrIncorrectEndDate
+	created := &change{notificationType: models.NotificationCreated, recipients: []string{event.Owner}}
+	messages, err := created.messages(event)
+	if err != nil {
+		return nil, err
 	}
-	err = es.EventStorage.SaveEvent(ctx, event)
+	err = es.EventStorage.SaveEvent(ctx, event, messages)
 	if err != nil {
 		log.Printf("can't create event `%s`: %s", event, err)
 		return nil, err
@@ -35,19 +41,103 @@ func (es *EventService) CreateEvent(ctx context.Context, event *models.Event) (*
 	return event, nil
 }
 
-func (es *EventService) DeleteEvent(ctx context.Context, id, owner string) error {
-	_, err := parseUuid(id)
-	if err != nil {
-		return err
+// normalizeEvent sets default transparency and truncates times of all-day event to dates,
+// all-day event ended at its start date lasts the day
+func normalizeEvent(event *models.Event) {
+	if event.Transparency == "" {
+		event.Transparency = models.TransparencyBusy
+	}
+	event.Reminders = event.Reminders.Normalize()
+	if !event.AllDay {
+		return
+	}
+	st, et := date(*event.StartTime), date(*event.EndTime)
+	if et.Equal(st) {
+		et = st.AddDate(0, 0, 1)
+	}
+	event.StartTime, event.EndTime = &st, &et
+}
+
+// date returns UTC midnight of the calendar date of t
+func date(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func validateEvent(event *models.Event) error {
+	if event.StartTime.After(*event.EndTime) {
+		return errors.ErrIncorrectEndDate
+	}
+	if !event.Transparency.IsValid() {
+		return errors.ErrIncorrectTransparency
+	}
+	if !event.Reminders.IsValid() {
+		return errors.ErrIncorrectReminder
+	}
+	if event.TimeZone != "" {
+		if _, err := loadLocation(event.TimeZone); err != nil {
+			return err
+		}
 	}
-	err = es.EventStorage.DeleteEventByIdOwner(ctx, id, owner)
+	if event.IsRecurring() {
+		return validateRecurrence(event)
+	}
+	return nil
+}
+
+// checkOverlaps returns ErrOverlaping if the busy event overlaps with owner's busy events
+func (es *EventService) checkOverlaps(ctx context.Context, event *models.Event) error {
+	overlapping, err := es.FindOverlap(ctx, event)
 	if err != nil {
-		log.Printf("can't delete event `%s`: %s", id, err)
 		return err
 	}
+	if overlapping != nil {
+		return errors.ErrOverlaping
+	}
 	return nil
 }
 
+// FindOverlap compares occurrences of the event with occurrences of owner's events and returns
+// the first overlapping one or nil, endless recurring events are checked within recurrenceHorizon
+func (es *EventService) FindOverlap(ctx context.Context, event *models.Event) (*models.Event, error) {
+	return es.findOverlap(ctx, event, func(e *models.Event) bool {
+		return e.Id == event.Id || (e.SeriesId != nil && *e.SeriesId == event.Id)
+	})
+}
+
+// findOverlap is FindOverlap, which ignores existing events and occurrences matched by skip.
+// Free and tentative events don't overlap with anything
+func (es *EventService) findOverlap(ctx context.Context, event *models.Event, skip func(e *models.Event) bool) (*models.Event, error) {
+	if !event.IsBlocking() {
+		return nil, nil
+	}
+	newOccs, err := occurrences(event, *event.StartTime, event.StartTime.Add(recurrenceHorizon))
+	if err != nil || len(newOccs) == 0 {
+		return nil, err
+	}
+	from, till := *newOccs[0].StartTime, *newOccs[len(newOccs)-1].EndTime
+	candidates, err := es.EventStorage.GetEventsByOwnerStartDateEndDate(ctx, event.Owner, &from, &till)
+	if err != nil {
+		return nil, err
+	}
+	// events started before the period can still overlap it
+	existing, err := expandEvents(candidates, from.Add(-maxDuration(candidates)), &till)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range existing {
+		if !e.IsBlocking() || skip(e) {
+			continue
+		}
+		for _, n := range newOccs {
+			if overlaps(e, n) {
+				return e, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
 func (es *EventService) DeleteEventsOlderDate(ctx context.Context, date *time.Time, owner string) error {
 	deleted, err := es.EventStorage.DeleteEventsOlderDateByIdOwner(ctx, date, owner)
 	if err != nil {
@@ -68,31 +158,43 @@ func (es *EventService) GetEvent(ctx context.Context, id, owner string) (*models
 		log.Printf("can't get event `%s`: %s", id, err)
 		return nil, err
 	}
+	if err := es.loadAttendees(ctx, []*models.Event{event}); err != nil {
+		return nil, err
+	}
 	return event, nil
 }
 
-func (es *EventService) UpdateEvent(ctx context.Context, owner, title, text, id string, startTime, endTime *time.Time) (*models.Event, error) {
-	uuidId, err := parseUuid(id)
-	event := &models.Event{
-		Id:        uuidId,
-		Owner:     owner,
-		Title:     title,
-		Text:      text,
-		StartTime: startTime,
-		EndTime:   endTime,
-	}
-	err = es.EventStorage.UpdateEventByIdOwner(ctx, id, event)
+// ListEvents returns events started after startTime, recurring events are expanded till endTime,
+// or for recurrenceHorizon if endTime is nil. Events of other owners, which owner is invited to
+// and hasn't declined, are listed too
+func (es *EventService) ListEvents(ctx context.Context, owner string, startTime, endTime *time.Time) ([]*models.Event, error) {
+	events, err := es.EventStorage.GetEventsByOwnerStartDate(ctx, owner, startTime)
 	if err != nil {
-		log.Printf("can't update event `%s`: %s", id, err)
+		log.Printf("can't get list of events for owner: `%s` startTime: `%s`: %s", owner, startTime, err)
 		return nil, err
 	}
-	return event, nil
+	invited, err := es.EventStorage.GetEventsByAttendeeStartDate(ctx, owner, startTime)
+	if err != nil {
+		log.Printf("can't get list of invitations for attendee: `%s` startTime: `%s`: %s", owner, startTime, err)
+		return nil, err
+	}
+	events = append(events, invited...)
+	if err := es.loadAttendees(ctx, events); err != nil {
+		return nil, err
+	}
+	return expandEvents(events, *startTime, endTime)
 }
 
-func (es *EventService) ListEvents(ctx context.Context, owner string, startTime *time.Time) ([]*models.Event, error) {
-	events, err := es.EventStorage.GetEventsByOwnerStartDate(ctx, owner, startTime)
+// ExportEvents returns stored events of owner in the period without expanding recurring ones,
+// so they can be exported together with their overrides. Period ends after recurrenceHorizon if endTime is nil
+func (es *EventService) ExportEvents(ctx context.Context, owner string, startTime, endTime *time.Time) ([]*models.Event, error) {
+	if endTime == nil {
+		till := defaultTill(*startTime)
+		endTime = &till
+	}
+	events, err := es.EventStorage.GetEventsByOwnerStartDateEndDate(ctx, owner, startTime, endTime)
 	if err != nil {
-		log.Printf("can't get list of events for owner: `%s` startTime: `%s`: %s", owner, startTime, err)
+		log.Printf("can't get events for export for owner: `%s` startTime: `%s`: %s", owner, startTime, err)
 		return nil, err
 	}
 	return events, nil
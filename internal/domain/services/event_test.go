@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/memdb"
+	"testing"
+	"time"
+)
+
+func newTestEventService(t *testing.T) *EventService {
+	storage, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &EventService{EventStorage: storage}
+}
+
+func newTestEvent(owner, title string, start time.Time, d time.Duration) *models.Event {
+	end := start.Add(d)
+	return &models.Event{
+		Owner:     owner,
+		Title:     title,
+		StartTime: &start,
+		EndTime:   &end,
+	}
+}
+
+func TestEventService_CreateEvent(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 10, 10, 10, 0, 0, 0, time.UTC)
+
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "first", day, time.Hour)); err != nil {
+		t.Fatalf("can't create event: %s", err)
+	}
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "overlap", day.Add(30*time.Minute), time.Hour)); err != errors.ErrOverlaping {
+		t.Errorf("expected %q, got %v", errors.ErrOverlaping, err)
+	}
+	if _, err := es.CreateEvent(ctx, newTestEvent("another", "other owner", day, time.Hour)); err != nil {
+		t.Errorf("events of different owners shouldn't overlap: %s", err)
+	}
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "reversed", day.Add(5*time.Hour), -time.Hour)); err != errors.ErrIncorrectEndDate {
+		t.Errorf("expected %q, got %v", errors.ErrIncorrectEndDate, err)
+	}
+}
+
+func TestEventService_Overlaps(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 10, 10, 10, 0, 0, 0, time.UTC)
+
+	long, err := es.CreateEvent(ctx, newTestEvent("user", "long", day, 3*time.Hour))
+	if err != nil {
+		t.Fatalf("can't create event: %s", err)
+	}
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "inside", day.Add(time.Hour), time.Hour)); err != errors.ErrOverlaping {
+		t.Errorf("event inside another one: expected %q, got %v", errors.ErrOverlaping, err)
+	}
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "enclosing", day.Add(-time.Hour), 5*time.Hour)); err != errors.ErrOverlaping {
+		t.Errorf("event enclosing another one: expected %q, got %v", errors.ErrOverlaping, err)
+	}
+	next, err := es.CreateEvent(ctx, newTestEvent("user", "back-to-back", day.Add(3*time.Hour), time.Hour))
+	if err != nil {
+		t.Fatalf("back-to-back events shouldn't overlap: %s", err)
+	}
+	reminder := newTestEvent("user", "reminder", day.Add(time.Hour), time.Hour)
+	reminder.Transparency = models.TransparencyTentative
+	if reminder, err = es.CreateEvent(ctx, reminder); err != nil {
+		t.Fatalf("tentative event shouldn't overlap: %s", err)
+	}
+	if _, err := es.UpdateEvent(ctx, reminder.Id.String(), models.ScopeAll, nil, newTestEvent("user", "reminder", day.Add(time.Hour), time.Hour)); err != errors.ErrOverlaping {
+		t.Errorf("event changed to busy: expected %q, got %v", errors.ErrOverlaping, err)
+	}
+	wrong := newTestEvent("user", "wrong", day.Add(10*time.Hour), time.Hour)
+	wrong.Transparency = models.Transparency("opaque")
+	if _, err := es.CreateEvent(ctx, wrong); err != errors.ErrIncorrectTransparency {
+		t.Errorf("expected %q, got %v", errors.ErrIncorrectTransparency, err)
+	}
+
+	// the event doesn't overlap with itself
+	if _, err := es.UpdateEvent(ctx, long.Id.String(), models.ScopeAll, nil, newTestEvent("user", "long", day.Add(-time.Hour), 3*time.Hour)); err != nil {
+		t.Errorf("can't move event: %s", err)
+	}
+	if _, err := es.UpdateEvent(ctx, next.Id.String(), models.ScopeAll, nil, newTestEvent("user", "moved", day.Add(time.Hour), time.Hour)); err != errors.ErrOverlaping {
+		t.Errorf("event moved inside another one: expected %q, got %v", errors.ErrOverlaping, err)
+	}
+
+	daily := newTestEvent("user", "daily", day.Add(5*time.Hour), time.Hour)
+	daily.Recurrence = "FREQ=DAILY;COUNT=3"
+	daily, err = es.CreateEvent(ctx, daily)
+	if err != nil {
+		t.Fatalf("can't create recurring event: %s", err)
+	}
+	second := day.Add(29 * time.Hour)
+	if _, err := es.UpdateEvent(ctx, daily.Id.String(), models.ScopeThis, &second, newTestEvent("user", "daily", day.Add(29*time.Hour+30*time.Minute), time.Hour)); err != nil {
+		t.Errorf("occurrence shouldn't overlap with itself: %s", err)
+	}
+	third := day.Add(53 * time.Hour)
+	if _, err := es.UpdateEvent(ctx, daily.Id.String(), models.ScopeThis, &third, newTestEvent("user", "daily", second, time.Hour)); err != errors.ErrOverlaping {
+		t.Errorf("occurrence moved to another one: expected %q, got %v", errors.ErrOverlaping, err)
+	}
+}
+
+func TestEventService_AllDay(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	loc := time.FixedZone("MSK", 3*60*60)
+	day := time.Date(2019, 10, 10, 1, 0, 0, 0, loc)
+
+	holiday := newTestEvent("user", "holiday", day, 0)
+	holiday.AllDay = true
+	holiday, err := es.CreateEvent(ctx, holiday)
+	if err != nil {
+		t.Fatalf("can't create all-day event: %s", err)
+	}
+	if st, et := time.Date(2019, 10, 10, 0, 0, 0, 0, time.UTC), time.Date(2019, 10, 11, 0, 0, 0, 0, time.UTC); !holiday.StartTime.Equal(st) || !holiday.EndTime.Equal(et) {
+		t.Errorf("expected all-day event from %s till %s, got %s - %s", st, et, holiday.StartTime, holiday.EndTime)
+	}
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "meeting", day.Add(10*time.Hour), time.Hour)); err != nil {
+		t.Errorf("all-day event shouldn't block bookings: %s", err)
+	}
+	trip := newTestEvent("user", "trip", day, 48*time.Hour)
+	trip.AllDay = true
+	if trip, err = es.CreateEvent(ctx, trip); err != nil {
+		t.Fatalf("can't create multi-day event: %s", err)
+	}
+	if d := trip.EndTime.Sub(*trip.StartTime); d != 48*time.Hour {
+		t.Errorf("expected two days event, got %s", d)
+	}
+	reversed := newTestEvent("user", "reversed", day, -48*time.Hour)
+	reversed.AllDay = true
+	if _, err := es.CreateEvent(ctx, reversed); err != errors.ErrIncorrectEndDate {
+		t.Errorf("expected %q, got %v", errors.ErrIncorrectEndDate, err)
+	}
+}
+
+func TestEventService_GetUpdateDeleteEvent(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 10, 10, 10, 0, 0, 0, time.UTC)
+
+	event, err := es.CreateEvent(ctx, newTestEvent("user", "first", day, time.Hour))
+	if err != nil {
+		t.Fatalf("can't create event: %s", err)
+	}
+	id := event.Id.String()
+	if _, err := es.GetEvent(ctx, id, "another"); err != errors.ErrNotFound {
+		t.Errorf("expected %q for foreign event, got %v", errors.ErrNotFound, err)
+	}
+
+	updated := newTestEvent("user", "updated", day.Add(2*time.Hour), time.Hour)
+	st := *updated.StartTime
+	if _, err := es.UpdateEvent(ctx, id, models.ScopeAll, nil, updated); err != nil {
+		t.Fatalf("can't update event: %s", err)
+	}
+	got, err := es.GetEvent(ctx, id, "user")
+	if err != nil {
+		t.Fatalf("can't get event: %s", err)
+	}
+	if got.Title != "updated" || !got.StartTime.Equal(st) {
+		t.Errorf("event is not updated: %s", got)
+	}
+
+	events, err := es.ListEvents(ctx, "user", &day, nil)
+	if err != nil || len(events) != 1 {
+		t.Errorf("expected 1 event in list, got %d (%v)", len(events), err)
+	}
+
+	if err := es.DeleteEvent(ctx, id, "user", models.ScopeAll, nil); err != nil {
+		t.Fatalf("can't delete event: %s", err)
+	}
+	if _, err := es.GetEvent(ctx, id, "user"); err != errors.ErrNotFound {
+		t.Errorf("expected %q after deletion, got %v", errors.ErrNotFound, err)
+	}
+}
+
+func TestEventService_RecurringEvent(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 10, 7, 10, 0, 0, 0, time.UTC)
+
+	standup := newTestEvent("user", "standup", day, 15*time.Minute)
+	standup.Recurrence = "FREQ=WEEKLY;COUNT=52"
+	if _, err := es.CreateEvent(ctx, standup); err != nil {
+		t.Fatalf("can't create recurring event: %s", err)
+	}
+	if end := day.AddDate(0, 0, 7*51).Add(15 * time.Minute); standup.SeriesEnd == nil || !standup.SeriesEnd.Equal(end) {
+		t.Errorf("expected series to end at %s, got %v", end, standup.SeriesEnd)
+	}
+
+	till := day.AddDate(0, 1, 0)
+	events, err := es.ListEvents(ctx, "user", &day, &till)
+	if err != nil {
+		t.Fatalf("can't list events: %s", err)
+	}
+	if len(events) != 5 {
+		t.Errorf("expected 5 weekly occurrences in a month, got %d", len(events))
+	}
+
+	nextWeek := day.AddDate(0, 0, 7).Add(5 * time.Minute)
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "overlap", nextWeek, time.Hour)); err != errors.ErrOverlaping {
+		t.Errorf("expected %q for occurrence overlap, got %v", errors.ErrOverlaping, err)
+	}
+	daily := newTestEvent("user", "daily", day.Add(-2*24*time.Hour).Add(5*time.Minute), time.Hour)
+	daily.Recurrence = "FREQ=DAILY"
+	if _, err := es.CreateEvent(ctx, daily); err != errors.ErrOverlaping {
+		t.Errorf("expected %q for recurring events overlap, got %v", errors.ErrOverlaping, err)
+	}
+
+	invalid := newTestEvent("user", "invalid", day.Add(time.Hour), time.Hour)
+	for _, rule := range []string{"FREQ=SOMETIMES", "FREQ=MINUTELY;COUNT=10", "FREQ=HOURLY"} {
+		invalid.Recurrence = rule
+		if _, err := es.CreateEvent(ctx, invalid); err != errors.ErrIncorrectRecurrence {
+			t.Errorf("expected %q for `%s`, got %v", errors.ErrIncorrectRecurrence, rule, err)
+		}
+	}
+	hourly := newTestEvent("user", "hourly", day.AddDate(1, 0, 0), 15*time.Minute)
+	hourly.Recurrence = "FREQ=HOURLY;COUNT=24"
+	if _, err := es.CreateEvent(ctx, hourly); err != nil {
+		t.Errorf("hourly event within occurrences limit should be created: %s", err)
+	}
+}
+
+func TestEventService_TimeZone(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	// 09:00 EDT, daylight saving time ends on 2019-11-03
+	day := time.Date(2019, 10, 28, 13, 0, 0, 0, time.UTC)
+
+	standup := newTestEvent("user", "standup", day, 15*time.Minute)
+	standup.Recurrence = "FREQ=WEEKLY;COUNT=3"
+	standup.TimeZone = "America/New_York"
+	if _, err := es.CreateEvent(ctx, standup); err != nil {
+		t.Fatalf("can't create recurring event: %s", err)
+	}
+	till := day.AddDate(0, 1, 0)
+	events, err := es.ListEvents(ctx, "user", &day, &till)
+	if err != nil {
+		t.Fatalf("can't list events: %s", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 occurrences, got %d", len(events))
+	}
+	// 09:00 EST
+	if st := time.Date(2019, 11, 4, 14, 0, 0, 0, time.UTC); !events[1].StartTime.Equal(st) {
+		t.Errorf("occurrence should keep local time: expected %s, got %s", st, events[1].StartTime)
+	}
+
+	for _, tz := range []string{"Mars/Olympus", "Local"} {
+		wrong := newTestEvent("user", "wrong", day.Add(-5*time.Hour), time.Hour)
+		wrong.TimeZone = tz
+		if _, err := es.CreateEvent(ctx, wrong); err != errors.ErrIncorrectTimeZone {
+			t.Errorf("%s: expected %q, got %v", tz, errors.ErrIncorrectTimeZone, err)
+		}
+	}
+}
+
+func TestEventService_Reminders(t *testing.T) {
+	ctx := context.Background()
+	storage, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := &EventService{EventStorage: storage}
+	day := time.Date(2030, 1, 10, 10, 0, 0, 0, time.UTC)
+
+	for _, r := range []time.Duration{-time.Minute, models.MaxReminderOffset + time.Hour, 1500 * time.Millisecond} {
+		wrong := newTestEvent("user", "wrong", day, time.Hour)
+		wrong.Reminders = models.Reminders{r}
+		if _, err := es.CreateEvent(ctx, wrong); err != errors.ErrIncorrectReminder {
+			t.Errorf("%s: expected %q, got %v", r, errors.ErrIncorrectReminder, err)
+		}
+	}
+	event := newTestEvent("user", "meeting", day, time.Hour)
+	if got := event.EventReminders(); len(got) != 1 || got[0] != models.DefaultReminder {
+		t.Errorf("event without reminders should get default one, got %v", got)
+	}
+}
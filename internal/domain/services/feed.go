@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"log"
+	"time"
+)
+
+// feedHistory limits past events published in the feed
+const feedHistory = 90 * 24 * time.Hour
+
+// feedTokenSize is number of random bytes in the token
+const feedTokenSize = 24
+
+// CreateFeedToken generates secret token of owner's read-only feed
+func (es *EventService) CreateFeedToken(ctx context.Context, owner string) (*models.FeedToken, error) {
+	b := make([]byte, feedTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		log.Printf("can't generate feed token: %s", err)
+		return nil, err
+	}
+	now := time.Now()
+	token := &models.FeedToken{
+		Token:     base64.RawURLEncoding.EncodeToString(b),
+		Owner:     owner,
+		CreatedAt: &now,
+	}
+	if err := es.EventStorage.SaveFeedToken(ctx, token); err != nil {
+		log.Printf("can't save feed token for owner `%s`: %s", owner, err)
+		return nil, err
+	}
+	return token, nil
+}
+
+func (es *EventService) RevokeFeedToken(ctx context.Context, token, owner string) error {
+	if err := es.EventStorage.DeleteFeedTokenByTokenOwner(ctx, token, owner); err != nil {
+		log.Printf("can't revoke feed token of owner `%s`: %s", owner, err)
+		return err
+	}
+	return nil
+}
+
+func (es *EventService) ListFeedTokens(ctx context.Context, owner string) ([]*models.FeedToken, error) {
+	tokens, err := es.EventStorage.GetFeedTokensByOwner(ctx, owner)
+	if err != nil {
+		log.Printf("can't get feed tokens of owner `%s`: %s", owner, err)
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// GetFeed returns events of token's owner started within feedHistory or later
+func (es *EventService) GetFeed(ctx context.Context, token string) ([]*models.Event, error) {
+	t, err := es.EventStorage.GetFeedToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	from := time.Now().Add(-feedHistory)
+	return es.ExportEvents(ctx, t.Owner, &from, nil)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"log"
+	"sort"
+	"time"
+)
+
+// GetFreeBusy returns busy intervals of owner's busy events in [from, till) period, overlapping and adjacent
+// intervals are merged and intervals are cut by the period. Free and tentative events are skipped
+func (es *EventService) GetFreeBusy(ctx context.Context, owner string, from, till time.Time) ([]models.Interval, error) {
+	if !till.After(from) {
+		return nil, errors.ErrIncorrectEndDate
+	}
+	candidates, err := es.EventStorage.GetEventsByOwnerStartDateEndDate(ctx, owner, &from, &till)
+	if err != nil {
+		log.Printf("can't get events for free/busy of owner: `%s` startTime: `%s`: %s", owner, from, err)
+		return nil, err
+	}
+	// events started before the period can still overlap it
+	events, err := expandEvents(candidates, from.Add(-maxDuration(candidates)), &till)
+	if err != nil {
+		return nil, err
+	}
+	intervals := make([]models.Interval, 0, len(events))
+	for _, e := range events {
+		if !e.IsBusy() || !e.EndTime.After(from) || !e.StartTime.Before(till) {
+			continue
+		}
+		intervals = append(intervals, models.Interval{Start: maxTime(*e.StartTime, from), End: minTime(*e.EndTime, till)})
+	}
+	return mergeIntervals(intervals), nil
+}
+
+// mergeIntervals joins overlapping and adjacent intervals, result is sorted by start
+func mergeIntervals(intervals []models.Interval) []models.Interval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].Start.Before(intervals[j].Start)
+	})
+	res := make([]models.Interval, 0, len(intervals))
+	for _, in := range intervals {
+		if last := len(res) - 1; last >= 0 && !in.Start.After(res[last].End) {
+			res[last].End = maxTime(res[last].End, in.End)
+			continue
+		}
+		res = append(res, in)
+	}
+	return res
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
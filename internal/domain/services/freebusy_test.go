@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"testing"
+	"time"
+)
+
+func TestEventService_GetFreeBusy(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 11, 4, 9, 0, 0, 0, time.UTC)
+	daily := newTestEvent("user", "standup", day, time.Hour)
+	daily.Recurrence = "FREQ=DAILY;COUNT=3"
+	if _, err := es.CreateEvent(ctx, daily); err != nil {
+		t.Fatalf("can't create event: %s", err)
+	}
+	// overlapping events are saved directly, they are merged into one interval
+	for _, e := range []*models.Event{
+		newTestEvent("user", "adjacent", day.Add(time.Hour), time.Hour),
+		newTestEvent("user", "overlapping", day.AddDate(0, 0, 1).Add(30*time.Minute), time.Hour),
+		newTestEvent("another", "another", day.Add(2*time.Hour), time.Hour),
+	} {
+		e.Id = uuid.NewV4()
+		e.Uid = e.Id.String()
+		if err := es.EventStorage.SaveEvent(ctx, e, nil); err != nil {
+			t.Fatalf("can't save event: %s", err)
+		}
+	}
+	onCall := newTestEvent("user", "on call", day.Add(2*time.Hour), 24*time.Hour)
+	onCall.Transparency = models.TransparencyFree
+	if _, err := es.CreateEvent(ctx, onCall); err != nil {
+		t.Fatalf("free event shouldn't overlap: %s", err)
+	}
+
+	from, till := day.Add(30*time.Minute), day.AddDate(0, 0, 1).Add(3*time.Hour)
+	busy, err := es.GetFreeBusy(ctx, "user", from, till)
+	if err != nil {
+		t.Fatalf("can't get free/busy: %s", err)
+	}
+	expected := []models.Interval{
+		{Start: from, End: day.Add(2 * time.Hour)},
+		{Start: day.AddDate(0, 0, 1), End: day.AddDate(0, 0, 1).Add(90 * time.Minute)},
+	}
+	if len(busy) != len(expected) {
+		t.Fatalf("expected %d intervals, got %v", len(expected), busy)
+	}
+	for i := range expected {
+		if !busy[i].Start.Equal(expected[i].Start) || !busy[i].End.Equal(expected[i].End) {
+			t.Errorf("interval %d: expected %v, got %v", i, expected[i], busy[i])
+		}
+	}
+	if _, err := es.GetFreeBusy(ctx, "user", till, from); err == nil {
+		t.Error("period with end before start should be rejected")
+	}
+}
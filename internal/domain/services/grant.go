@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"log"
+	"time"
+)
+
+// GrantAccess gives grantee role in owner's calendar, role of existing grant is replaced
+func (es *EventService) GrantAccess(ctx context.Context, owner, grantee string, role models.Role) (*models.Grant, error) {
+	if !role.IsValid() {
+		return nil, errors.ErrIncorrectRole
+	}
+	if grantee == "" || grantee == owner {
+		return nil, errors.ErrIncorrectGrantee
+	}
+	now := time.Now()
+	grant := &models.Grant{
+		Owner:     owner,
+		Grantee:   grantee,
+		Role:      role,
+		CreatedAt: &now,
+	}
+	if err := es.EventStorage.SaveGrant(ctx, grant); err != nil {
+		log.Printf("can't save grant of owner `%s` to `%s`: %s", owner, grantee, err)
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (es *EventService) RevokeGrant(ctx context.Context, owner, grantee string) error {
+	if err := es.EventStorage.DeleteGrantByOwnerGrantee(ctx, owner, grantee); err != nil {
+		log.Printf("can't revoke grant of owner `%s` to `%s`: %s", owner, grantee, err)
+		return err
+	}
+	return nil
+}
+
+func (es *EventService) ListGrants(ctx context.Context, owner string) ([]*models.Grant, error) {
+	grants, err := es.EventStorage.GetGrantsByOwner(ctx, owner)
+	if err != nil {
+		log.Printf("can't get grants of owner `%s`: %s", owner, err)
+		return nil, err
+	}
+	return grants, nil
+}
+
+// Authorize checks that user has required role in owner's calendar, granted role is returned.
+// Owner has write access to own calendar
+func (es *EventService) Authorize(ctx context.Context, user, owner string, required models.Role) (models.Role, error) {
+	if user == owner {
+		return models.RoleWrite, nil
+	}
+	grant, err := es.EventStorage.GetGrant(ctx, owner, user)
+	if err == errors.ErrNotFound {
+		return "", errors.ErrAccessDenied
+	}
+	if err != nil {
+		log.Printf("can't get grant of owner `%s` to `%s`: %s", owner, user, err)
+		return "", err
+	}
+	if !grant.Role.Includes(required) {
+		return "", errors.ErrAccessDenied
+	}
+	return grant.Role, nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"testing"
+)
+
+func TestEventService_Authorize(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	if _, err := es.GrantAccess(ctx, "manager", "assistant", models.RoleRead); err != nil {
+		t.Fatalf("can't grant access: %s", err)
+	}
+	if _, err := es.GrantAccess(ctx, "manager", "manager", models.RoleRead); err != errors.ErrIncorrectGrantee {
+		t.Errorf("expected %q, got %v", errors.ErrIncorrectGrantee, err)
+	}
+	if _, err := es.GrantAccess(ctx, "manager", "assistant", models.Role("admin")); err != errors.ErrIncorrectRole {
+		t.Errorf("expected %q, got %v", errors.ErrIncorrectRole, err)
+	}
+
+	tests := []struct {
+		user, owner string
+		required    models.Role
+		err         error
+	}{
+		{"manager", "manager", models.RoleWrite, nil},
+		{"assistant", "manager", models.RoleFreeBusy, nil},
+		{"assistant", "manager", models.RoleRead, nil},
+		{"assistant", "manager", models.RoleWrite, errors.ErrAccessDenied},
+		{"stranger", "manager", models.RoleFreeBusy, errors.ErrAccessDenied},
+		{"manager", "assistant", models.RoleRead, errors.ErrAccessDenied},
+	}
+	for _, tt := range tests {
+		if _, err := es.Authorize(ctx, tt.user, tt.owner, tt.required); err != tt.err {
+			t.Errorf("%s in calendar of %s with %s role: expected %v, got %v", tt.user, tt.owner, tt.required, tt.err, err)
+		}
+	}
+
+	if _, err := es.GrantAccess(ctx, "manager", "assistant", models.RoleWrite); err != nil {
+		t.Fatalf("can't change grant: %s", err)
+	}
+	if role, err := es.Authorize(ctx, "assistant", "manager", models.RoleWrite); err != nil || role != models.RoleWrite {
+		t.Errorf("changed grant: expected %s role, got %s (%v)", models.RoleWrite, role, err)
+	}
+	if err := es.RevokeGrant(ctx, "manager", "assistant"); err != nil {
+		t.Fatalf("can't revoke grant: %s", err)
+	}
+	if _, err := es.Authorize(ctx, "assistant", "manager", models.RoleFreeBusy); err != errors.ErrAccessDenied {
+		t.Errorf("revoked grant: expected %q, got %v", errors.ErrAccessDenied, err)
+	}
+}
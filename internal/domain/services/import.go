@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"log"
+	"sort"
+)
+
+// ImportEvents creates owner's events one by one with the same rules as CreateEvent.
+// Events with already known UID are skipped, overrides with OriginalStartTime are applied
+// to recurring event with the same UID
+func (es *EventService) ImportEvents(ctx context.Context, owner string, events []*models.Event) ([]*models.ImportResult, error) {
+	// series should be created before their overrides
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].OriginalStartTime == nil && events[j].OriginalStartTime != nil
+	})
+	results := make([]*models.ImportResult, 0, len(events))
+	for _, e := range events {
+		e.Owner = owner
+		res := &models.ImportResult{Uid: e.Uid, Title: e.Title, OriginalStartTime: e.OriginalStartTime}
+		var err error
+		if e.OriginalStartTime != nil {
+			err = es.importOccurrence(ctx, e, res)
+		} else {
+			err = es.importEvent(ctx, e, res)
+		}
+		if berr, ok := err.(errors.EventError); ok {
+			res.Status = models.ImportRejected
+			res.Reason = string(berr)
+		} else if err != nil {
+			log.Printf("can't import event `%s`: %s", e.Uid, err)
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func (es *EventService) importEvent(ctx context.Context, e *models.Event, res *models.ImportResult) error {
+	if e.Uid != "" {
+		existing, err := es.EventStorage.GetEventByUidOwner(ctx, e.Uid, e.Owner)
+		switch err {
+		case nil:
+			res.Status, res.EventId = models.ImportDuplicate, existing.Id
+			return nil
+		case errors.ErrNotFound:
+		default:
+			return err
+		}
+	}
+	created, err := es.CreateEvent(ctx, e)
+	if err != nil {
+		return err
+	}
+	res.Status, res.EventId = models.ImportCreated, created.Id
+	return nil
+}
+
+// importOccurrence changes or cancels occurrence of already imported series
+func (es *EventService) importOccurrence(ctx context.Context, e *models.Event, res *models.ImportResult) error {
+	series, err := es.EventStorage.GetEventByUidOwner(ctx, e.Uid, e.Owner)
+	if err != nil {
+		return err
+	}
+	if !series.IsRecurring() {
+		return errors.ErrIncorrectOccurrence
+	}
+	existing, err := es.EventStorage.GetEventBySeriesIdOwnerStartDate(ctx, series.Id.String(), e.Owner, e.OriginalStartTime)
+	switch err {
+	case nil:
+		res.Status, res.EventId = models.ImportDuplicate, existing.Id
+		return nil
+	case errors.ErrNotFound:
+	default:
+		return err
+	}
+	if e.Cancelled {
+		err = es.DeleteEvent(ctx, series.Id.String(), e.Owner, models.ScopeThis, e.OriginalStartTime)
+		res.EventId = series.Id
+	} else {
+		var updated *models.Event
+		if updated, err = es.UpdateEvent(ctx, series.Id.String(), models.ScopeThis, e.OriginalStartTime, e); err == nil {
+			res.EventId = updated.Id
+		}
+	}
+	if err != nil {
+		return err
+	}
+	res.Status = models.ImportCreated
+	return nil
+}
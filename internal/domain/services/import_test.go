@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"testing"
+	"time"
+)
+
+func TestEventService_ImportEvents(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 11, 4, 10, 0, 0, 0, time.UTC)
+
+	newImported := func(uid, title string, start time.Time) *models.Event {
+		e := newTestEvent("", title, start, time.Hour)
+		e.Uid = uid
+		return e
+	}
+	series := newImported("series", "series", day)
+	series.Recurrence = "FREQ=DAILY;COUNT=5"
+	newOverride := func() *models.Event {
+		e := newImported("series", "moved", day.AddDate(0, 0, 1).Add(2*time.Hour))
+		originalStart := day.AddDate(0, 0, 1)
+		e.OriginalStartTime = &originalStart
+		return e
+	}
+	events := []*models.Event{
+		newOverride(),
+		series,
+		newImported("series", "duplicate", day.AddDate(1, 0, 0)),
+		newImported("overlap", "overlap", day.Add(30*time.Minute)),
+		newImported("", "without uid", day.AddDate(0, 1, 0)),
+	}
+
+	results, err := es.ImportEvents(ctx, "user", events)
+	if err != nil {
+		t.Fatalf("can't import events: %s", err)
+	}
+	expected := map[string]models.ImportStatus{
+		"series":      models.ImportCreated,
+		"moved":       models.ImportCreated,
+		"duplicate":   models.ImportDuplicate,
+		"overlap":     models.ImportRejected,
+		"without uid": models.ImportCreated,
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(results))
+	}
+	for _, r := range results {
+		if r.Status != expected[r.Title] {
+			t.Errorf("expected status %d for `%s`, got %d (%s)", expected[r.Title], r.Title, r.Status, r.Reason)
+		}
+		if r.Title == "overlap" && r.Reason != string(errors.ErrOverlaping) {
+			t.Errorf("expected reason %q, got %q", errors.ErrOverlaping, r.Reason)
+		}
+	}
+
+	// import of the same calendar again creates nothing
+	results, err = es.ImportEvents(ctx, "user", []*models.Event{newImported("series", "series", day), newOverride()})
+	if err != nil {
+		t.Fatalf("can't import events: %s", err)
+	}
+	for _, r := range results {
+		if r.Status != models.ImportDuplicate {
+			t.Errorf("expected duplicate status for `%s`, got %d (%s)", r.Title, r.Status, r.Reason)
+		}
+	}
+}
@@ -2,41 +2,262 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"github.com/Brialius/calendar/internal/domain/errors"
 	"github.com/Brialius/calendar/internal/domain/interfaces"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
 	"log"
 	"time"
 )
 
+const (
+	// notificatorLease is held by the replica, which scans events and relays outbox
+	notificatorLease = "notificator"
+	// defaultLeaseTtl is used if LeaseTtl isn't set
+	defaultLeaseTtl = 30 * time.Second
+	// notificatorTick is period of lease renewal, scanning of events and relaying of outbox
+	notificatorTick = 5 * time.Second
+	// outboxBatchSize limits number of outbox messages read at once
+	outboxBatchSize = 100
+	// scanBatchSize is number of events, which reminders are claimed between checks of the lease
+	scanBatchSize = 100
+	// outboxRetention is how long sent outbox messages are kept
+	outboxRetention = 24 * time.Hour
+)
+
+// NotificatorService notifies about events through transactional outbox. ScanEvents saves notifications
+// to outbox in the same transaction, which marks reminder of event or occurrence notified, and RelayOutbox
+// publishes them together with notifications about changes saved by EventService. Message is marked sent
+// only after broker confirms it, so notification published right before failure is published again:
+// delivery is at least once, and receivers skip duplicates by MessageId.
+// Replicas elect the leader by lease in storage, only the leader serves notifications
 type NotificatorService struct {
 	EventStorage interfaces.EventStorage
 	TaskQueue    interfaces.TaskQueue
-	Period       time.Duration
-	QName        string
-	Exchange     string
+	// Period is the longest offset of reminders, events starting later aren't scanned
+	Period   time.Duration
+	QName    string
+	Exchange string
+	// Holder identifies the replica, random id is used if it's not set
+	Holder string
+	// LeaseTtl is how long the leader holds lease without renewal, it should be much longer than
+	// notificatorTick and than clock difference of replicas
+	LeaseTtl time.Duration
 }
 
 func (n *NotificatorService) ScanEvents(ctx context.Context) error {
-	events, err := n.EventStorage.GetEventsForNotification(ctx, time.Now(), n.Period)
+	now := time.Now()
+	events, err := n.EventStorage.GetEventsForNotification(ctx, now, n.Period)
 	if err != nil {
 		log.Printf("can't get events for notifications for period `%s`: %s", n.Period, err)
 		return err
 	}
 
-	for _, e := range events {
-		log.Printf("sending notification to `%s` about event `%s`", e.Owner, e.Id)
-		if err := n.TaskQueue.SendTaskToQueue(ctx, n.Exchange, n.QName, e); err != nil {
-			log.Printf("can't publish notification to task queue: %s", err)
+	overrides := seriesOverrides(events)
+	for i, e := range events {
+		if i%scanBatchSize == 0 {
+			if err := n.checkLease(ctx); err != nil {
+				return err
+			}
+		}
+		var err error
+		switch {
+		case e.IsRecurring():
+			err = n.notifyOccurrences(ctx, e, now, overrides[e.Id])
+		// overrides are selected by original start time to skip replaced occurrences
+		case e.Cancelled:
+		default:
+			err = n.notifyReminders(ctx, e, now)
+		}
+		// failed event doesn't delay reminders of others, it's notified on the next scan
+		if err != nil {
+			log.Printf("can't notify reminders of event `%s`, retrying on the next scan: %s", e.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// notifyOccurrences notifies reminders of occurrences, which haven't ended and start within notification period,
+// overridden occurrences are notified as single events
+func (n *NotificatorService) notifyOccurrences(ctx context.Context, event *models.Event, now time.Time, overridden map[int64]bool) error {
+	occs, err := occurrences(event, now.Add(-event.EndTime.Sub(*event.StartTime)), now.Add(n.Period))
+	if err != nil {
+		log.Printf("can't expand occurrences of event `%s`: %s", event.Id, err)
+		return nil
+	}
+	for _, o := range occs {
+		if overridden[o.StartTime.Unix()] {
+			continue
+		}
+		if err := n.notifyReminders(ctx, o, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyReminders saves notification to outbox once per reminder of event or occurrence, which fired before now.
+// Only the latest of fired reminders is sent, earlier ones, e.g. missed because event was created later
+// than they fire, are marked notified without notification
+func (n *NotificatorService) notifyReminders(ctx context.Context, event *models.Event, now time.Time) error {
+	if now.After(*event.EndTime) {
+		return nil
+	}
+	var fired models.Reminders
+	for _, r := range event.EventReminders().Normalize() {
+		if !event.StartTime.Add(-r).After(now) {
+			fired = append(fired, r)
+		}
+	}
+	if len(fired) == 0 {
+		return nil
+	}
+	notified, err := n.EventStorage.GetNotifiedReminders(ctx, event.Id.String(), *event.StartTime)
+	if err != nil {
+		log.Printf("can't get notified reminders of event `%s` at %s: %s", event.Id, event.StartTime, err)
+		return err
+	}
+	isNotified := make(map[time.Duration]bool, len(notified))
+	for _, r := range notified {
+		isNotified[r] = true
+	}
+	for i, r := range fired {
+		if isNotified[r] {
+			continue
+		}
+		var messages []*models.OutboxMessage
+		if i == len(fired)-1 {
+			if messages, err = n.notifications(ctx, event, r); err != nil {
+				return err
+			}
+		}
+		if err := n.EventStorage.MarkReminderNotified(ctx, event.Id.String(), *event.StartTime, r, messages); err != nil {
+			log.Printf("can't mark reminder `%s` of event `%s` at %s as notified: %s", r, event.Id, event.StartTime, err)
+		}
+	}
+	return nil
+}
+
+// notifications returns outbox messages about event to its owner and every attendee, who accepted invitation
+func (n *NotificatorService) notifications(ctx context.Context, event *models.Event, reminder time.Duration) ([]*models.OutboxMessage, error) {
+	attendees, err := n.EventStorage.GetAttendeesByEventId(ctx, event.SeriesOrEventId().String())
+	if err != nil {
+		log.Printf("can't get attendees of event `%s`: %s", event.Id, err)
+		return nil, err
+	}
+	recipients := []string{event.Owner}
+	for _, a := range attendees {
+		if a.Status == models.StatusAccepted {
+			recipients = append(recipients, a.Name)
+		}
+	}
+	now := time.Now()
+	messages := make([]*models.OutboxMessage, 0, len(recipients))
+	for _, r := range recipients {
+		m, err := outboxMessage(&models.Notification{Event: event, Recipient: r, Type: models.NotificationReminder, Reminder: reminder}, now)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// outboxMessage encodes notification to outbox message, notification gets id of the message
+func outboxMessage(notification *models.Notification, createdAt time.Time) (*models.OutboxMessage, error) {
+	notification.MessageId = uuid.NewV4()
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		log.Printf("can't marshal notification about event `%s`: %s", notification.Id, err)
+		return nil, err
+	}
+	return &models.OutboxMessage{
+		Id:        notification.MessageId,
+		Payload:   string(payload),
+		CreatedAt: &createdAt,
+	}, nil
+}
+
+// RelayOutbox publishes pending outbox messages in order of creation and marks them sent, the lease
+// is checked before every batch. Sent messages are deleted after outboxRetention
+func (n *NotificatorService) RelayOutbox(ctx context.Context) error {
+	for {
+		if err := n.checkLease(ctx); err != nil {
+			return err
+		}
+		messages, err := n.EventStorage.GetPendingOutboxMessages(ctx, outboxBatchSize)
+		if err != nil {
+			log.Printf("can't get pending outbox messages: %s", err)
+			return err
+		}
+		for _, m := range messages {
+			if err := n.publish(ctx, m); err != nil {
+				return err
+			}
+		}
+		if len(messages) < outboxBatchSize {
 			break
 		}
-		if err := n.EventStorage.MarkEventNotified(ctx, e.Id.String()); err != nil {
-			log.Printf("can't mark event `%s` as notified: %s", e.Id, err)
+	}
+	if _, err := n.EventStorage.DeleteOutboxMessagesSentBefore(ctx, time.Now().Add(-outboxRetention)); err != nil {
+		log.Printf("can't delete sent outbox messages: %s", err)
+		return err
+	}
+	return nil
+}
+
+// publish sends outbox message to task queue and marks it sent, message, which can't be decoded, is dropped
+func (n *NotificatorService) publish(ctx context.Context, m *models.OutboxMessage) error {
+	notification := &models.Notification{}
+	if err := json.Unmarshal([]byte(m.Payload), notification); err != nil || notification.Event == nil {
+		log.Printf("dropping malformed outbox message `%s`: %v", m.Id, err)
+	} else {
+		log.Printf("sending %s notification to `%s` about event `%s` at %s", notification.Type, notification.Recipient, notification.Id, notification.StartTime)
+		if err := n.TaskQueue.SendTaskToQueue(ctx, n.Exchange, n.QName, notification); err != nil {
+			log.Printf("can't publish notification to task queue: %s", err)
+			return err
 		}
 	}
+	if err := n.EventStorage.MarkOutboxMessageSent(ctx, m.Id.String(), time.Now()); err != nil {
+		log.Printf("can't mark outbox message `%s` as sent: %s", m.Id, err)
+		return err
+	}
+	return nil
+}
+
+// acquireLease takes or renews notificator lease, true is returned if the replica is the leader
+func (n *NotificatorService) acquireLease(ctx context.Context) (bool, error) {
+	ttl := n.LeaseTtl
+	if ttl == 0 {
+		ttl = defaultLeaseTtl
+	}
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	return n.EventStorage.AcquireLease(ctx, &models.Lease{Name: notificatorLease, Holder: n.Holder, ExpiresAt: &expiresAt}, now)
+}
 
+// checkLease renews notificator lease before the next batch of claimed reminders or published messages,
+// ErrLeaseLost is returned if the lease has expired and is taken over, or can't be renewed
+func (n *NotificatorService) checkLease(ctx context.Context) error {
+	leader, err := n.acquireLease(ctx)
+	if err != nil {
+		log.Printf("can't renew notificator lease: %s", err)
+		return errors.ErrLeaseLost
+	}
+	if !leader {
+		return errors.ErrLeaseLost
+	}
 	return nil
 }
 
+// ServeNotificator scans events and relays outbox while the replica holds notificator lease,
+// lease is released when ctx is done
 func (n *NotificatorService) ServeNotificator(ctx context.Context) error {
+	if n.Holder == "" {
+		n.Holder = uuid.NewV4().String()
+	}
 	err := n.TaskQueue.DeclareQueue(ctx, n.QName, false)
 	if err != nil {
 		log.Printf("can't declare task quueue `%s`: %s", n.QName, err)
@@ -57,13 +278,49 @@ func (n *NotificatorService) ServeNotificator(ctx context.Context) error {
 		log.Printf("can't set QoS for MQ channel: %s", err)
 		return err
 	}
-	tick := time.Tick(5 * time.Second)
+	tick := time.NewTicker(notificatorTick)
+	defer tick.Stop()
+	leader := false
 	for {
-		<-tick
-		err := n.ScanEvents(ctx)
-		if err != nil {
-			log.Printf("Error during ScanEvents: %s", err)
-			return err
+		select {
+		case <-ctx.Done():
+			if leader {
+				if err := n.EventStorage.ReleaseLease(context.Background(), notificatorLease, n.Holder); err != nil {
+					log.Printf("can't release notificator lease: %s", err)
+				}
+			}
+			return nil
+		case <-tick.C:
 		}
+		leader = n.serve(ctx, leader)
+	}
+}
+
+// serve scans events and relays outbox once, if the replica holds notificator lease, and returns if it's
+// the leader. Errors are logged and work is retried on the next tick, unsent messages stay pending in outbox
+func (n *NotificatorService) serve(ctx context.Context, leader bool) bool {
+	acquired, err := n.acquireLease(ctx)
+	if err != nil {
+		// the replica isn't the leader till the lease is acquired on one of the next ticks
+		log.Printf("can't acquire notificator lease: %s", err)
+		acquired = false
+	}
+	if acquired != leader {
+		log.Printf("Notificator `%s` is the leader: %t", n.Holder, acquired)
+	}
+	if !acquired {
+		return false
+	}
+	err = n.ScanEvents(ctx)
+	if err == nil {
+		err = n.RelayOutbox(ctx)
+	}
+	if err == errors.ErrLeaseLost {
+		log.Printf("Notificator `%s` is the leader: false", n.Holder)
+		return false
+	}
+	if err != nil {
+		log.Printf("Error during serving notifications, retrying on the next tick: %s", err)
 	}
+	return true
 }
@@ -0,0 +1,418 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/interfaces"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/memdb"
+	"testing"
+	"time"
+)
+
+type testTaskQueue struct {
+	sent []*models.Notification
+	// err is returned instead of publishing
+	err error
+}
+
+func (q *testTaskQueue) DeclareQueue(ctx context.Context, qName string, durable bool) error {
+	return nil
+}
+
+func (q *testTaskQueue) BindQueue(ctx context.Context, qName, routingKey, exchange string, durable bool) error {
+	return nil
+}
+
+func (q *testTaskQueue) DeclareExchange(ctx context.Context, name, kind string, durable bool) error {
+	return nil
+}
+
+func (q *testTaskQueue) SetQos(ctx context.Context, prefetchCount, prefetchSize int, global bool) error {
+	return nil
+}
+
+func (q *testTaskQueue) SendTaskToQueue(ctx context.Context, qName, exchange string, notification *models.Notification) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.sent = append(q.sent, notification)
+	return nil
+}
+
+func (q *testTaskQueue) ConsumeTasksFromQueue(ctx context.Context, qName, consumer string, autoAck bool,
+	task func(ctx context.Context, notification *models.Notification) error) error {
+	return nil
+}
+
+// skipChangeNotifications marks pending notifications about changes of events sent, so only reminders are relayed
+func skipChangeNotifications(ctx context.Context, t *testing.T, storage interfaces.EventStorage) {
+	pending, err := storage.GetPendingOutboxMessages(ctx, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range pending {
+		if err := storage.MarkOutboxMessageSent(ctx, m.Id.String(), time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestNotificatorService_ScanEvents(t *testing.T) {
+	ctx := context.Background()
+	storage, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := &EventService{EventStorage: storage}
+	tq := &testTaskQueue{}
+	n := &NotificatorService{EventStorage: storage, TaskQueue: tq, Period: 24 * time.Hour}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "single", now.Add(time.Hour), time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	daily := newTestEvent("user", "daily", now.Add(-2*24*time.Hour+3*time.Hour), time.Hour)
+	daily.Recurrence = "FREQ=DAILY"
+	if _, err := es.CreateEvent(ctx, daily); err != nil {
+		t.Fatal(err)
+	}
+	skipChangeNotifications(ctx, t, storage)
+
+	for i := 0; i < 2; i++ {
+		if err := n.ScanEvents(ctx); err != nil {
+			t.Fatalf("can't scan events: %s", err)
+		}
+	}
+	if len(tq.sent) != 0 {
+		t.Fatalf("notifications should be published by relay, got %d", len(tq.sent))
+	}
+	if err := n.RelayOutbox(ctx); err != nil {
+		t.Fatalf("can't relay outbox: %s", err)
+	}
+	if len(tq.sent) != 2 {
+		t.Fatalf("expected 2 notifications (single event and next occurrence), got %d", len(tq.sent))
+	}
+	for _, e := range tq.sent {
+		if e.Title == "daily" && !e.StartTime.Equal(now.Add(3*time.Hour)) {
+			t.Errorf("notification should be sent about the next occurrence, got %s", e.StartTime)
+		}
+	}
+}
+
+type failingRemindersStorage struct {
+	*memdb.MemEventStorage
+	failing string
+}
+
+func (s *failingRemindersStorage) GetNotifiedReminders(ctx context.Context, id string, startTime time.Time) (models.Reminders, error) {
+	if id == s.failing {
+		return nil, errors.EventError("connection is lost")
+	}
+	return s.MemEventStorage.GetNotifiedReminders(ctx, id, startTime)
+}
+
+func TestNotificatorService_ScanEventsFailure(t *testing.T) {
+	ctx := context.Background()
+	mem, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage := &failingRemindersStorage{MemEventStorage: mem}
+	es := &EventService{EventStorage: storage}
+	tq := &testTaskQueue{}
+	n := &NotificatorService{EventStorage: storage, TaskQueue: tq, Period: 24 * time.Hour}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	failing, err := es.CreateEvent(ctx, newTestEvent("user", "failing", now.Add(time.Hour), time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "later", now.Add(3*time.Hour), time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	skipChangeNotifications(ctx, t, storage)
+	storage.failing = failing.Id.String()
+
+	if err := n.ScanEvents(ctx); err != nil {
+		t.Fatalf("can't scan events: %s", err)
+	}
+	if err := n.RelayOutbox(ctx); err != nil {
+		t.Fatalf("can't relay outbox: %s", err)
+	}
+	if len(tq.sent) != 1 || tq.sent[0].Title != "later" {
+		t.Fatalf("events after the failed one should be notified, got %v", tq.sent)
+	}
+
+	storage.failing = ""
+	if err := n.ScanEvents(ctx); err != nil {
+		t.Fatalf("can't scan events: %s", err)
+	}
+	if err := n.RelayOutbox(ctx); err != nil {
+		t.Fatalf("can't relay outbox: %s", err)
+	}
+	if len(tq.sent) != 2 || tq.sent[1].Title != "failing" {
+		t.Errorf("failed event should be notified on the next scan, got %v", tq.sent)
+	}
+}
+
+func TestNotificatorService_ScanEventsAttendees(t *testing.T) {
+	ctx := context.Background()
+	storage, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := &EventService{EventStorage: storage}
+	tq := &testTaskQueue{}
+	n := &NotificatorService{EventStorage: storage, TaskQueue: tq, Period: 24 * time.Hour}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	event, err := es.CreateEvent(ctx, newTestEvent("manager", "meeting", now.Add(time.Hour), time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := es.InviteAttendees(ctx, event.Id.String(), "manager", []string{"developer", "tester"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := es.RespondToInvitation(ctx, event.Id.String(), "developer", models.StatusAccepted); err != nil {
+		t.Fatal(err)
+	}
+	skipChangeNotifications(ctx, t, storage)
+	if err := n.ScanEvents(ctx); err != nil {
+		t.Fatalf("can't scan events: %s", err)
+	}
+	if err := n.RelayOutbox(ctx); err != nil {
+		t.Fatalf("can't relay outbox: %s", err)
+	}
+	recipients := make(map[string]bool)
+	for _, notification := range tq.sent {
+		recipients[notification.Recipient] = true
+	}
+	if len(tq.sent) != 2 || !recipients["manager"] || !recipients["developer"] {
+		t.Errorf("expected notifications to owner and accepted attendee, got %v", recipients)
+	}
+}
+
+// failingOutboxStorage fails to mark outbox messages sent, like notificator stopped right after publishing
+type failingOutboxStorage struct {
+	*memdb.MemEventStorage
+	fail bool
+}
+
+func (s *failingOutboxStorage) MarkOutboxMessageSent(ctx context.Context, id string, sentAt time.Time) error {
+	if s.fail {
+		return errors.EventError("connection is lost")
+	}
+	return s.MemEventStorage.MarkOutboxMessageSent(ctx, id, sentAt)
+}
+
+type testSender struct {
+	sent []*models.Notification
+}
+
+func (s *testSender) SendNotification(ctx context.Context, notification *models.Notification) error {
+	s.sent = append(s.sent, notification)
+	return nil
+}
+
+func TestNotificatorService_RelayOutbox(t *testing.T) {
+	ctx := context.Background()
+	mem, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage := &failingOutboxStorage{MemEventStorage: mem}
+	es := &EventService{EventStorage: storage}
+	tq := &testTaskQueue{err: errors.EventError("broker is unavailable")}
+	n := &NotificatorService{EventStorage: storage, TaskQueue: tq, Period: 24 * time.Hour}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "single", now.Add(time.Hour), time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	skipChangeNotifications(ctx, t, storage)
+	if err := n.ScanEvents(ctx); err != nil {
+		t.Fatalf("can't scan events: %s", err)
+	}
+	if err := n.RelayOutbox(ctx); err == nil {
+		t.Errorf("relay should fail if broker doesn't confirm publishing")
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 1 {
+		t.Fatalf("unpublished message should stay pending, got %d (%v)", len(pending), err)
+	}
+
+	// published message isn't marked sent and is published again
+	tq.err = nil
+	storage.fail = true
+	if err := n.RelayOutbox(ctx); err == nil {
+		t.Errorf("relay should fail if message can't be marked sent")
+	}
+	storage.fail = false
+	if err := n.RelayOutbox(ctx); err != nil {
+		t.Fatalf("can't relay outbox: %s", err)
+	}
+	if len(tq.sent) != 2 || tq.sent[0].MessageId != tq.sent[1].MessageId {
+		t.Fatalf("expected the same message published twice, got %d", len(tq.sent))
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 0 {
+		t.Errorf("expected no pending messages, got %d (%v)", len(pending), err)
+	}
+
+	sender := &testSender{}
+	s := &SenderService{EventStorage: storage, Sender: sender}
+	for _, notification := range tq.sent {
+		if err := s.SendNotification(ctx, notification); err != nil {
+			t.Fatalf("can't send notification: %s", err)
+		}
+	}
+	if len(sender.sent) != 1 {
+		t.Errorf("duplicate should be skipped, got %d sent notifications", len(sender.sent))
+	}
+	// processed messages are shared by replicas and survive restart of sender
+	restarted := &SenderService{EventStorage: storage, Sender: sender}
+	if err := restarted.SendNotification(ctx, tq.sent[0]); err != nil || len(sender.sent) != 1 {
+		t.Errorf("duplicate should be skipped after restart, got %d sent notifications (%v)", len(sender.sent), err)
+	}
+}
+
+func TestNotificatorService_ServeRetries(t *testing.T) {
+	ctx := context.Background()
+	storage, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := &EventService{EventStorage: storage}
+	tq := &testTaskQueue{err: errors.EventError("broker is unavailable")}
+	n := &NotificatorService{EventStorage: storage, TaskQueue: tq, Period: 24 * time.Hour, Holder: "leader"}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "single", now.Add(48*time.Hour), time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	// failed publishing doesn't stop the leader, message is published on the next tick
+	if leader := n.serve(ctx, false); !leader {
+		t.Fatalf("the replica should stay the leader after failed publishing")
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 1 {
+		t.Fatalf("unpublished message should stay pending, got %d (%v)", len(pending), err)
+	}
+	tq.err = nil
+	if leader := n.serve(ctx, true); !leader {
+		t.Fatalf("the replica should stay the leader")
+	}
+	if len(tq.sent) != 1 || tq.sent[0].Type != models.NotificationCreated {
+		t.Errorf("expected created notification published on retry, got %d", len(tq.sent))
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 0 {
+		t.Errorf("expected no pending messages, got %d (%v)", len(pending), err)
+	}
+}
+
+func TestNotificatorService_Lease(t *testing.T) {
+	ctx := context.Background()
+	storage, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := &NotificatorService{EventStorage: storage, Period: 24 * time.Hour, Holder: "first", LeaseTtl: time.Hour}
+	second := &NotificatorService{EventStorage: storage, Period: 24 * time.Hour, Holder: "second", LeaseTtl: time.Hour}
+
+	if leader, err := first.acquireLease(ctx); err != nil || !leader {
+		t.Fatalf("the first replica should be the leader, got %t (%v)", leader, err)
+	}
+	if leader, err := second.acquireLease(ctx); err != nil || leader {
+		t.Errorf("the second replica shouldn't be the leader, got %t (%v)", leader, err)
+	}
+	if leader, err := first.acquireLease(ctx); err != nil || !leader {
+		t.Errorf("the leader should renew its lease, got %t (%v)", leader, err)
+	}
+	if err := storage.ReleaseLease(ctx, notificatorLease, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if leader, err := second.acquireLease(ctx); err != nil || !leader {
+		t.Errorf("released lease should be taken over, got %t (%v)", leader, err)
+	}
+
+	// replicas, which scan events at the same time, notify once
+	es := &EventService{EventStorage: storage}
+	now := time.Now().UTC().Truncate(time.Second)
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "single", now.Add(time.Hour), time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	daily := newTestEvent("user", "daily", now.Add(3*time.Hour), time.Hour)
+	daily.Recurrence = "FREQ=DAILY"
+	if _, err := es.CreateEvent(ctx, daily); err != nil {
+		t.Fatal(err)
+	}
+	skipChangeNotifications(ctx, t, storage)
+	events, err := storage.GetEventsForNotification(ctx, now, first.Period)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []*NotificatorService{first, second} {
+		for _, e := range events {
+			messages, err := n.notifications(ctx, e, models.DefaultReminder)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = storage.MarkReminderNotified(ctx, e.Id.String(), *e.StartTime, models.DefaultReminder, messages)
+			if err != nil {
+				t.Fatalf("can't mark reminder notified: %s", err)
+			}
+		}
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 2 {
+		t.Errorf("expected 2 outbox messages, got %d (%v)", len(pending), err)
+	}
+
+	// the former leader, which lease is taken over, stops claiming and publishing
+	first.TaskQueue = &testTaskQueue{}
+	if err := first.ScanEvents(ctx); err != errors.ErrLeaseLost {
+		t.Errorf("expected %q on scan, got %v", errors.ErrLeaseLost, err)
+	}
+	if err := first.RelayOutbox(ctx); err != errors.ErrLeaseLost {
+		t.Errorf("expected %q on relay, got %v", errors.ErrLeaseLost, err)
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 2 {
+		t.Errorf("outbox messages shouldn't be published, got %d pending (%v)", len(pending), err)
+	}
+}
+
+func TestNotificatorService_Reminders(t *testing.T) {
+	ctx := context.Background()
+	storage, err := memdb.NewMemEventStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := &EventService{EventStorage: storage}
+	tq := &testTaskQueue{}
+	n := &NotificatorService{EventStorage: storage, TaskQueue: tq, Period: models.MaxReminderOffset}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	event := newTestEvent("user", "meeting", now.Add(10*time.Minute), time.Hour)
+	event.Reminders = models.Reminders{0, 15 * time.Minute, 24 * time.Hour, 15 * time.Minute}
+	event, err = es.CreateEvent(ctx, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(event.Reminders) != 3 || event.Reminders[0] != 24*time.Hour || event.Reminders[2] != 0 {
+		t.Errorf("reminders should be sorted without duplicates, got %v", event.Reminders)
+	}
+	skipChangeNotifications(ctx, t, storage)
+	for i := 0; i < 2; i++ {
+		if err := n.ScanEvents(ctx); err != nil {
+			t.Fatalf("can't scan events: %s", err)
+		}
+	}
+	if err := n.RelayOutbox(ctx); err != nil {
+		t.Fatalf("can't relay outbox: %s", err)
+	}
+	if len(tq.sent) != 1 || tq.sent[0].Reminder != 15*time.Minute {
+		t.Fatalf("expected only the latest fired reminder, got %d", len(tq.sent))
+	}
+	notified, err := storage.GetNotifiedReminders(ctx, event.Id.String(), *event.StartTime)
+	if err != nil || len(notified) != 2 {
+		t.Errorf("missed reminder should be marked notified, got %v (%v)", notified, err)
+	}
+
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"log"
+	"time"
+)
+
+// GetEventObject returns event with the UID and overrides of its occurrences,
+// overrides aren't addressed by their own UIDs
+func (es *EventService) GetEventObject(ctx context.Context, uid, owner string) (*models.EventObject, error) {
+	event, err := es.EventStorage.GetEventByUidOwner(ctx, uid, owner)
+	if err != nil {
+		return nil, err
+	}
+	if event.IsOverride() {
+		return nil, errors.ErrNotFound
+	}
+	return es.eventObject(ctx, event)
+}
+
+func (es *EventService) eventObject(ctx context.Context, event *models.Event) (*models.EventObject, error) {
+	o := &models.EventObject{Event: event}
+	if !event.IsRecurring() {
+		return o, nil
+	}
+	overrides, err := es.EventStorage.GetEventsBySeriesIdOwner(ctx, event.Id.String(), event.Owner)
+	if err != nil {
+		log.Printf("can't get overrides of event `%s`: %s", event.Id, err)
+		return nil, err
+	}
+	o.Overrides = overrides
+	return o, nil
+}
+
+// endOfTime is the end of period which isn't limited
+var endOfTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// ListEventObjects returns owner's events which have occurrences in [startTime, endTime] period,
+// nil times mean the period isn't limited. Recurring events aren't expanded if endTime is nil,
+// so they are returned unless they are started after endTime
+func (es *EventService) ListEventObjects(ctx context.Context, owner string, startTime, endTime *time.Time) ([]*models.EventObject, error) {
+	if startTime == nil {
+		startTime = &time.Time{}
+	}
+	till := endOfTime
+	if endTime != nil {
+		till = *endTime
+	}
+	events, err := es.EventStorage.GetEventsByOwnerStartDateEndDate(ctx, owner, startTime, &till)
+	if err != nil {
+		log.Printf("can't get events for owner: `%s` startTime: `%s`: %s", owner, startTime, err)
+		return nil, err
+	}
+	var objects []*models.EventObject
+	for _, e := range events {
+		if e.IsOverride() {
+			continue
+		}
+		o, err := es.eventObject(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+		if endTime == nil {
+			objects = append(objects, o)
+			continue
+		}
+		if ok, err := hasOccurrences(o, *startTime, *endTime); err != nil {
+			return nil, err
+		} else if ok {
+			objects = append(objects, o)
+		}
+	}
+	return objects, nil
+}
+
+// hasOccurrences checks if any occurrence of the object lasts in [from, till] period
+func hasOccurrences(o *models.EventObject, from, till time.Time) (bool, error) {
+	// occurrences started before the period can still last in it
+	occs, err := expandEvents(o.Events(), from.Add(-maxDuration(o.Events())), &till)
+	if err != nil {
+		return false, err
+	}
+	for _, occ := range occs {
+		if !occ.EndTime.Before(from) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SaveEventObject creates event with UID of the object or replaces existing one, overrides of occurrences
+// are replaced with overrides of the object. Returns true if event is created
+func (es *EventService) SaveEventObject(ctx context.Context, owner string, object *models.EventObject) (bool, error) {
+	event := object.Event
+	event.Owner = owner
+	existing, err := es.EventStorage.GetEventByUidOwner(ctx, event.Uid, owner)
+	switch {
+	case err == errors.ErrNotFound:
+		event, err = es.CreateEvent(ctx, event)
+	case err != nil:
+	case existing.IsOverride():
+		err = errors.ErrIncorrectOccurrence
+	default:
+		event, err = es.UpdateEvent(ctx, existing.Id.String(), models.ScopeAll, nil, event)
+	}
+	if err != nil {
+		log.Printf("can't save event `%s`: %s", object.Event.Uid, err)
+		return false, err
+	}
+	if err := es.replaceOverrides(ctx, event, object.Overrides); err != nil {
+		log.Printf("can't save overrides of event `%s`: %s", event.Uid, err)
+		return false, err
+	}
+	return existing == nil, nil
+}
+
+// replaceOverrides applies overrides to stored series, stored overrides which are absent are deleted
+func (es *EventService) replaceOverrides(ctx context.Context, series *models.Event, overrides []*models.Event) error {
+	if len(overrides) > 0 && !series.IsRecurring() {
+		return errors.ErrIncorrectOccurrence
+	}
+	if !series.IsRecurring() {
+		return nil
+	}
+	id := series.Id.String()
+	kept := make(map[int64]bool)
+	for _, o := range overrides {
+		if o.OriginalStartTime == nil {
+			return errors.ErrIncorrectOccurrence
+		}
+		o.Owner = series.Owner
+		kept[o.OriginalStartTime.Unix()] = true
+		var err error
+		if o.Cancelled {
+			err = es.DeleteEvent(ctx, id, series.Owner, models.ScopeThis, o.OriginalStartTime)
+		} else {
+			_, err = es.UpdateEvent(ctx, id, models.ScopeThis, o.OriginalStartTime, o)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	stored, err := es.EventStorage.GetEventsBySeriesIdOwner(ctx, id, series.Owner)
+	if err != nil {
+		return err
+	}
+	for _, s := range stored {
+		if kept[s.OriginalStartTime.Unix()] {
+			continue
+		}
+		if err := es.EventStorage.DeleteEventByIdOwner(ctx, s.Id.String(), s.Owner, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteEventObject deletes event with the UID together with overrides of its occurrences
+func (es *EventService) DeleteEventObject(ctx context.Context, uid, owner string) error {
+	event, err := es.EventStorage.GetEventByUidOwner(ctx, uid, owner)
+	if err == nil && event.IsOverride() {
+		err = errors.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return es.DeleteEvent(ctx, event.Id.String(), owner, models.ScopeAll, nil)
+}
+
+func maxDuration(events []*models.Event) time.Duration {
+	var d time.Duration
+	for _, e := range events {
+		if ed := e.EndTime.Sub(*e.StartTime); ed > d {
+			d = ed
+		}
+	}
+	return d
+}
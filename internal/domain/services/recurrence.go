@@ -0,0 +1,248 @@
+package services
+
+import (
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"github.com/teambition/rrule-go"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// recurrenceHorizon limits expansion of endless recurrence rules
+const recurrenceHorizon = 365 * 24 * time.Hour
+
+// maxOccurrences limits expansion of too frequent rules
+const maxOccurrences = 1000
+
+// maxSeriesCount limits walking of COUNT rules to find end of series
+const maxSeriesCount = 100 * maxOccurrences
+
+// locations caches loaded time zones of events
+var locations sync.Map
+
+// eventLocation returns time zone of the event, all-day events and events without time zone are in UTC
+func eventLocation(event *models.Event) *time.Location {
+	if event.TimeZone == "" || event.AllDay {
+		return time.UTC
+	}
+	if loc, ok := locations.Load(event.TimeZone); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := loadLocation(event.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	locations.Store(event.TimeZone, loc)
+	return loc
+}
+
+// loadLocation loads IANA time zone, local time zone of the server isn't accepted
+func loadLocation(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, errors.ErrIncorrectTimeZone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.ErrIncorrectTimeZone
+	}
+	return loc, nil
+}
+
+func parseRecurrence(event *models.Event) (*rrule.RRule, error) {
+	opt, err := rrule.StrToROption(strings.TrimPrefix(event.Recurrence, "RRULE:"))
+	if err != nil {
+		log.Printf("can't parse recurrence rule `%s`: %s", event.Recurrence, err)
+		return nil, errors.ErrIncorrectRecurrence
+	}
+	// occurrences keep local time of the start in time zone of the event
+	opt.Dtstart = event.StartTime.In(eventLocation(event))
+	r, err := rrule.NewRRule(*opt)
+	if err != nil {
+		log.Printf("can't parse recurrence rule `%s`: %s", event.Recurrence, err)
+		return nil, errors.ErrIncorrectRecurrence
+	}
+	return r, nil
+}
+
+// occurrences returns copies of event for every occurrence started in [from, till] period
+func occurrences(event *models.Event, from, till time.Time) ([]*models.Event, error) {
+	if !event.IsRecurring() {
+		if event.StartTime.Before(from) || event.StartTime.After(till) {
+			return nil, nil
+		}
+		return []*models.Event{event}, nil
+	}
+	r, err := parseRecurrence(event)
+	if err != nil {
+		return nil, err
+	}
+	starts := recurrenceStarts(r, from, till, maxOccurrences)
+	duration := event.EndTime.Sub(*event.StartTime)
+	res := make([]*models.Event, 0, len(starts))
+	for _, st := range starts {
+		st := st.In(event.StartTime.Location())
+		et := st.Add(duration)
+		occurrence := *event
+		occurrence.StartTime = &st
+		occurrence.EndTime = &et
+		occurrence.SeriesId = &event.Id
+		occurrence.OriginalStartTime = &st
+		res = append(res, &occurrence)
+	}
+	return res, nil
+}
+
+// recurrenceStarts returns at most limit starts of the rule in [from, till] period. The rule is walked
+// from its first occurrence and expansion stops at the limit, not at the end of the period
+func recurrenceStarts(r *rrule.RRule, from, till time.Time, limit int) []time.Time {
+	var res []time.Time
+	next := r.Iterator()
+	for st, ok := next(); ok && !st.After(till) && len(res) < limit; st, ok = next() {
+		if !st.Before(from) {
+			res = append(res, st)
+		}
+	}
+	return res
+}
+
+// validateRecurrence rejects sub-hourly rules and rules with more than maxOccurrences occurrences
+// within recurrenceHorizon, they can't be expanded completely
+func validateRecurrence(event *models.Event) error {
+	r, err := parseRecurrence(event)
+	if err != nil {
+		return err
+	}
+	if r.OrigOptions.Freq == rrule.MINUTELY || r.OrigOptions.Freq == rrule.SECONDLY {
+		return errors.ErrIncorrectRecurrence
+	}
+	if len(recurrenceStarts(r, *event.StartTime, event.StartTime.Add(recurrenceHorizon), maxOccurrences+1)) > maxOccurrences {
+		return errors.ErrIncorrectRecurrence
+	}
+	return nil
+}
+
+// seriesEnd returns end of the last occurrence of recurring event, or nil if the series is endless.
+// Rules with UNTIL end before it, rules with too large COUNT are treated as endless
+func seriesEnd(event *models.Event) *time.Time {
+	if !event.IsRecurring() {
+		return nil
+	}
+	r, err := parseRecurrence(event)
+	if err != nil {
+		return nil
+	}
+	duration := event.EndTime.Sub(*event.StartTime)
+	if !r.OrigOptions.Until.IsZero() {
+		end := r.OrigOptions.Until.Add(duration)
+		return &end
+	}
+	if r.OrigOptions.Count == 0 || r.OrigOptions.Count > maxSeriesCount {
+		return nil
+	}
+	last := *event.StartTime
+	next := r.Iterator()
+	for st, ok := next(); ok; st, ok = next() {
+		last = st
+	}
+	end := last.In(event.StartTime.Location()).Add(duration)
+	return &end
+}
+
+// isOccurrence checks if recurring event has occurrence started at startTime
+func isOccurrence(event *models.Event, startTime time.Time) bool {
+	occs, err := occurrences(event, startTime, startTime)
+	return err == nil && len(occs) == 1
+}
+
+// seriesOverrides indexes original start times of stored overrides by series id
+func seriesOverrides(events []*models.Event) map[uuid.UUID]map[int64]bool {
+	res := make(map[uuid.UUID]map[int64]bool)
+	for _, e := range events {
+		if !e.IsOverride() || e.OriginalStartTime == nil {
+			continue
+		}
+		if res[*e.SeriesId] == nil {
+			res[*e.SeriesId] = make(map[int64]bool)
+		}
+		res[*e.SeriesId][e.OriginalStartTime.Unix()] = true
+	}
+	return res
+}
+
+// expandEvents replaces recurring events with their occurrences started in [from, till] period,
+// single events are filtered by till only if it's set.
+// Stored overrides replace occurrences of their series, cancelled ones are skipped
+func expandEvents(events []*models.Event, from time.Time, till *time.Time) ([]*models.Event, error) {
+	recurringTill := defaultTill(from)
+	if till != nil {
+		recurringTill = *till
+	}
+	overrides := seriesOverrides(events)
+	res := make([]*models.Event, 0, len(events))
+	for _, e := range events {
+		if !e.IsRecurring() {
+			if !e.Cancelled && !e.StartTime.Before(from) && (till == nil || !e.StartTime.After(*till)) {
+				res = append(res, e)
+			}
+			continue
+		}
+		occs, err := occurrences(e, from, recurringTill)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range occs {
+			if !overrides[e.Id][o.StartTime.Unix()] {
+				res = append(res, o)
+			}
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].StartTime.Before(*res[j].StartTime)
+	})
+	return res, nil
+}
+
+// defaultTill is the end of period to expand recurring events when period isn't set explicitly
+func defaultTill(from time.Time) time.Time {
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+	return from.Add(recurrenceHorizon)
+}
+
+// truncateRecurrence returns rule of the event finished before till
+func truncateRecurrence(event *models.Event, till time.Time) (string, error) {
+	opt, err := rrule.StrToROption(strings.TrimPrefix(event.Recurrence, "RRULE:"))
+	if err != nil {
+		return "", errors.ErrIncorrectRecurrence
+	}
+	opt.Count = 0
+	opt.Until = till.Add(-time.Second).UTC()
+	return opt.RRuleString(), nil
+}
+
+// remainingRecurrence returns rule of the event for occurrences started since from,
+// COUNT is decreased by number of passed occurrences
+func remainingRecurrence(event *models.Event, from time.Time) (string, error) {
+	opt, err := rrule.StrToROption(strings.TrimPrefix(event.Recurrence, "RRULE:"))
+	if err != nil {
+		return "", errors.ErrIncorrectRecurrence
+	}
+	if opt.Count > 0 {
+		r, err := parseRecurrence(event)
+		if err != nil {
+			return "", err
+		}
+		opt.Count -= len(recurrenceStarts(r, *event.StartTime, from.Add(-time.Second), opt.Count))
+	}
+	return opt.RRuleString(), nil
+}
+
+// overlaps checks if half-open intervals [start, end) of events intersect, so back-to-back events don't overlap
+func overlaps(e, another *models.Event) bool {
+	return e.StartTime.Before(*another.EndTime) && another.StartTime.Before(*e.EndTime)
+}
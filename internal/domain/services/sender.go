@@ -4,22 +4,75 @@ import (
 	"context"
 	"github.com/Brialius/calendar/internal/domain/interfaces"
 	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
 	"log"
+	"time"
 )
 
+const (
+	// processedRetention is how long ids of sent messages are kept, it's longer than outboxRetention,
+	// so they outlive messages, which can be published again
+	processedRetention = 2 * outboxRetention
+	// processedCleanupTick is how often expired ids of sent messages are deleted
+	processedCleanupTick = time.Hour
+)
+
+// SenderService sends notifications consumed from task queue. Notifications are published at least once,
+// so ids of sent messages are stored and duplicates of them are skipped by all replicas and after restart.
+// Message is marked processed after sending, if sender stops in between, the message is sent again,
+// so delivery is at least once as well
 type SenderService struct {
-	Sender    interfaces.EventSender
-	TaskQueue interfaces.TaskQueue
-	QName     string
+	EventStorage interfaces.EventStorage
+	Sender       interfaces.EventSender
+	TaskQueue    interfaces.TaskQueue
+	QName        string
+}
+
+func (s *SenderService) SendNotification(ctx context.Context, notification *models.Notification) error {
+	// messages published without id aren't deduplicated
+	if notification.MessageId != uuid.Nil {
+		processed, err := s.EventStorage.IsMessageProcessed(ctx, notification.MessageId.String())
+		if err != nil {
+			log.Printf("can't check if message `%s` is processed: %s", notification.MessageId, err)
+			return err
+		}
+		if processed {
+			log.Printf("Skipping duplicate of message `%s`", notification.MessageId)
+			return nil
+		}
+	}
+	log.Printf("Sending Notification to `%s`: %v", notification.Recipient, notification.Event)
+	if err := s.Sender.SendNotification(ctx, notification); err != nil {
+		return err
+	}
+	if notification.MessageId == uuid.Nil {
+		return nil
+	}
+	if err := s.EventStorage.MarkMessageProcessed(ctx, notification.MessageId.String(), time.Now()); err != nil {
+		log.Printf("can't mark message `%s` processed: %s", notification.MessageId, err)
+		return err
+	}
+	return nil
 }
 
-func (s *SenderService) SendNotification(ctx context.Context, event *models.Event) error {
-	log.Printf("Sending Notification %v", event)
-	err := s.Sender.SendEvent(ctx, event)
-	return err
+// cleanup deletes ids of messages processed before processedRetention till ctx is done
+func (s *SenderService) cleanup(ctx context.Context) {
+	tick := time.NewTicker(processedCleanupTick)
+	defer tick.Stop()
+	for {
+		if _, err := s.EventStorage.DeleteMessagesProcessedBefore(ctx, time.Now().Add(-processedRetention)); err != nil {
+			log.Printf("can't delete processed messages: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
 }
 
 func (s *SenderService) Serve(ctx context.Context) error {
+	go s.cleanup(ctx)
 	err := s.TaskQueue.ConsumeTasksFromQueue(ctx, s.QName, "", false, s.SendNotification)
 	if err != nil {
 		log.Printf("Can't send notification: %s", err)
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"log"
+	"time"
+)
+
+// seriesTarget is an event addressed by update or delete request
+type seriesTarget struct {
+	// event is stored event, it's an override if occurrence was already changed
+	event *models.Event
+	// series is parent of recurring series, nil for single events
+	series *models.Event
+	// occurrenceStart is original start time of addressed occurrence, nil for whole series
+	occurrenceStart *time.Time
+}
+
+// resolveTarget finds event and series addressed by id, override can be addressed by own id
+// or by id of the series and original start time of the occurrence
+func (es *EventService) resolveTarget(ctx context.Context, id, owner string, scope models.Scope, occurrenceStart *time.Time) (*seriesTarget, error) {
+	if _, err := parseUuid(id); err != nil {
+		return nil, err
+	}
+	event, err := es.EventStorage.GetEventByIdOwner(ctx, id, owner)
+	if err != nil {
+		log.Printf("can't get event `%s`: %s", id, err)
+		return nil, err
+	}
+	t := &seriesTarget{event: event}
+	switch {
+	case event.IsOverride():
+		t.series, err = es.EventStorage.GetEventByIdOwner(ctx, event.SeriesId.String(), owner)
+		if err != nil {
+			log.Printf("can't get series `%s` of event `%s`: %s", event.SeriesId, id, err)
+			return nil, err
+		}
+		t.occurrenceStart = event.OriginalStartTime
+	case event.IsRecurring():
+		t.series = event
+		if scope == models.ScopeAll {
+			return t, nil
+		}
+		if occurrenceStart == nil || !isOccurrence(event, *occurrenceStart) {
+			return nil, errors.ErrIncorrectOccurrence
+		}
+		t.occurrenceStart = occurrenceStart
+		override, err := es.EventStorage.GetEventBySeriesIdOwnerStartDate(ctx, id, owner, occurrenceStart)
+		switch err {
+		case nil:
+			t.event = override
+		case errors.ErrNotFound:
+		default:
+			log.Printf("can't get occurrence of event `%s` at %s: %s", id, occurrenceStart, err)
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// isFirst checks if addressed occurrence is the first one of the series
+func (t *seriesTarget) isFirst() bool {
+	return t.occurrenceStart == nil || t.occurrenceStart.Equal(*t.series.StartTime)
+}
+
+// replaces checks if existing event or occurrence is replaced by update of the target with the scope,
+// so it can't overlap with the updated event
+func (t *seriesTarget) replaces(e *models.Event, scope models.Scope) bool {
+	if t.series == nil {
+		return e.Id == t.event.Id
+	}
+	if e.Id != t.series.Id && (e.SeriesId == nil || *e.SeriesId != t.series.Id) {
+		return false
+	}
+	switch {
+	case scope == models.ScopeThis:
+		return e.OriginalStartTime != nil && e.OriginalStartTime.Equal(*t.occurrenceStart)
+	case scope == models.ScopeThisAndFollowing && !t.isFirst():
+		return e.OriginalStartTime != nil && !e.OriginalStartTime.Before(*t.occurrenceStart)
+	}
+	return true
+}
+
+// findUpdateOverlap returns the first owner's event, which overlaps with the event updated
+// with the scope, recurrence of the event is changed like by the update
+func (es *EventService) findUpdateOverlap(ctx context.Context, t *seriesTarget, scope models.Scope, event *models.Event) (*models.Event, error) {
+	updated := *event
+	switch {
+	case t.series == nil:
+	case scope == models.ScopeThis:
+		updated.Recurrence = ""
+	case scope == models.ScopeThisAndFollowing && !t.isFirst() && !event.IsRecurring():
+		rule, err := remainingRecurrence(t.series, *t.occurrenceStart)
+		if err != nil {
+			return nil, err
+		}
+		updated.Recurrence = rule
+	}
+	return es.findOverlap(ctx, &updated, func(e *models.Event) bool {
+		return t.replaces(e, scope)
+	})
+}
+
+// FindUpdateOverlap returns the first owner's event, which overlaps with the event updated by UpdateEvent
+// with the same arguments, or nil
+func (es *EventService) FindUpdateOverlap(ctx context.Context, id string, scope models.Scope, occurrenceStart *time.Time, event *models.Event) (*models.Event, error) {
+	t, err := es.resolveTarget(ctx, id, event.Owner, scope, occurrenceStart)
+	if err != nil {
+		return nil, err
+	}
+	return es.findUpdateOverlap(ctx, t, scope, event)
+}
+
+// UpdateEvent changes single event, or occurrences of recurring event selected by scope.
+// For ScopeThis and ScopeThisAndFollowing occurrenceStart is required unless id is an override's one.
+// ScopeThisAndFollowing keeps the rest of series rule if event has no recurrence
+func (es *EventService) UpdateEvent(ctx context.Context, id string, scope models.Scope, occurrenceStart *time.Time, event *models.Event) (*models.Event, error) {
+	normalizeEvent(event)
+	if err := validateEvent(event); err != nil {
+		return nil, err
+	}
+	event.SeriesEnd = seriesEnd(event)
+	t, err := es.resolveTarget(ctx, id, event.Owner, scope, occurrenceStart)
+	if err != nil {
+		return nil, err
+	}
+	overlapping, err := es.findUpdateOverlap(ctx, t, scope, event)
+	if err != nil {
+		return nil, err
+	}
+	if overlapping != nil {
+		return nil, errors.ErrOverlaping
+	}
+	before := t.snapshot(scope)
+	updated, err := es.newChange(ctx, models.NotificationUpdated, before)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case t.series == nil:
+		event.Id, event.Uid = t.event.Id, t.event.Uid
+		err = es.updateEvent(ctx, event, updated)
+	case scope == models.ScopeThis:
+		err = es.overrideOccurrence(ctx, t, event, updated)
+	case scope == models.ScopeThisAndFollowing && !t.isFirst():
+		err = es.splitSeries(ctx, t, event, updated)
+	default:
+		err = es.updateSeries(ctx, t.series, event, updated)
+	}
+	if err != nil {
+		log.Printf("can't update event `%s`: %s", id, err)
+		return nil, err
+	}
+	return event, nil
+}
+
+// DeleteEvent deletes single event, or occurrences of recurring event selected by scope,
+// single occurrence is kept as cancelled override
+func (es *EventService) DeleteEvent(ctx context.Context, id, owner string, scope models.Scope, occurrenceStart *time.Time) error {
+	t, err := es.resolveTarget(ctx, id, owner, scope, occurrenceStart)
+	if err != nil {
+		return err
+	}
+	cancelled, err := es.newChange(ctx, models.NotificationCancelled, t.snapshot(scope))
+	if err != nil {
+		return err
+	}
+	messages, err := cancelled.messages(nil)
+	if err != nil {
+		return err
+	}
+	switch {
+	case t.series == nil:
+		err = es.EventStorage.DeleteEventByIdOwner(ctx, id, owner, messages)
+	case scope == models.ScopeThis:
+		err = es.cancelOccurrence(ctx, t, messages)
+	case scope == models.ScopeThisAndFollowing && !t.isFirst():
+		err = es.truncateSeries(ctx, t.series, *t.occurrenceStart, messages)
+	default:
+		// overrides are deleted together with series
+		err = es.EventStorage.DeleteEventByIdOwner(ctx, t.series.Id.String(), owner, messages)
+	}
+	if err != nil {
+		log.Printf("can't delete event `%s`: %s", id, err)
+		return err
+	}
+	return nil
+}
+
+// updateEvent stores changed event together with notification about it
+func (es *EventService) updateEvent(ctx context.Context, event *models.Event, c *change) error {
+	messages, err := c.messages(event)
+	if err != nil {
+		return err
+	}
+	return es.EventStorage.UpdateEventByIdOwner(ctx, event.Id.String(), event, messages)
+}
+
+// overrideOccurrence stores changed occurrence, it replaces generated one in the series
+func (es *EventService) overrideOccurrence(ctx context.Context, t *seriesTarget, event *models.Event, c *change) error {
+	event.Recurrence = ""
+	event.SeriesEnd = nil
+	event.SeriesId = &t.series.Id
+	event.OriginalStartTime = t.occurrenceStart
+	if t.event.IsOverride() {
+		event.Id, event.Uid = t.event.Id, t.event.Uid
+		return es.updateEvent(ctx, event, c)
+	}
+	event.Id = uuid.NewV4()
+	event.Uid = event.Id.String()
+	messages, err := c.messages(event)
+	if err != nil {
+		return err
+	}
+	return es.EventStorage.SaveEvent(ctx, event, messages)
+}
+
+// cancelOccurrence stores cancelled override, so occurrence isn't generated anymore
+func (es *EventService) cancelOccurrence(ctx context.Context, t *seriesTarget, messages []*models.OutboxMessage) error {
+	if t.event.IsOverride() {
+		t.event.Cancelled = true
+		return es.EventStorage.UpdateEventByIdOwner(ctx, t.event.Id.String(), t.event, messages)
+	}
+	start := *t.occurrenceStart
+	end := start.Add(t.series.EndTime.Sub(*t.series.StartTime))
+	id := uuid.NewV4()
+	return es.EventStorage.SaveEvent(ctx, &models.Event{
+		Id:                id,
+		Uid:               id.String(),
+		Owner:             t.series.Owner,
+		Title:             t.series.Title,
+		Text:              t.series.Text,
+		StartTime:         &start,
+		EndTime:           &end,
+		SeriesId:          &t.series.Id,
+		OriginalStartTime: &start,
+		Cancelled:         true,
+		Transparency:      t.series.Transparency,
+		AllDay:            t.series.AllDay,
+		TimeZone:          t.series.TimeZone,
+		Reminders:         t.series.Reminders,
+	}, messages)
+}
+
+// splitSeries finishes series before the occurrence and starts new one from the event
+func (es *EventService) splitSeries(ctx context.Context, t *seriesTarget, event *models.Event, c *change) error {
+	if !event.IsRecurring() {
+		rule, err := remainingRecurrence(t.series, *t.occurrenceStart)
+		if err != nil {
+			return err
+		}
+		event.Recurrence = rule
+		event.SeriesEnd = seriesEnd(event)
+	}
+	event.Id = uuid.NewV4()
+	event.Uid = event.Id.String()
+	messages, err := c.messages(event)
+	if err != nil {
+		return err
+	}
+	if err := truncate(t.series, *t.occurrenceStart); err != nil {
+		return err
+	}
+	return es.EventStorage.SplitSeries(ctx, t.series, *t.occurrenceStart, event, messages)
+}
+
+// truncateSeries removes occurrences started since till and their overrides, messages are saved
+// together with truncated series
+func (es *EventService) truncateSeries(ctx context.Context, series *models.Event, till time.Time, messages []*models.OutboxMessage) error {
+	if err := truncate(series, till); err != nil {
+		return err
+	}
+	return es.EventStorage.UpdateSeries(ctx, series, &till, messages)
+}
+
+// truncate finishes series rule before till
+func truncate(series *models.Event, till time.Time) error {
+	rule, err := truncateRecurrence(series, till)
+	if err != nil {
+		return err
+	}
+	series.Recurrence = rule
+	series.SeriesEnd = seriesEnd(series)
+	return nil
+}
+
+// updateSeries changes whole series, overrides are dropped if occurrences are moved
+func (es *EventService) updateSeries(ctx context.Context, series *models.Event, event *models.Event, c *change) error {
+	event.Id, event.Uid = series.Id, series.Uid
+	messages, err := c.messages(event)
+	if err != nil {
+		return err
+	}
+	var since *time.Time
+	if !event.StartTime.Equal(*series.StartTime) || event.Recurrence != series.Recurrence {
+		since = series.StartTime
+	}
+	return es.EventStorage.UpdateSeries(ctx, event, since, messages)
+}
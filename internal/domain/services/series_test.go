@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"testing"
+	"time"
+)
+
+func listTitles(t *testing.T, es *EventService, from, till time.Time) []string {
+	events, err := es.ListEvents(context.Background(), "user", &from, &till)
+	if err != nil {
+		t.Fatalf("can't list events: %s", err)
+	}
+	titles := make([]string, 0, len(events))
+	for _, e := range events {
+		titles = append(titles, e.Title)
+	}
+	return titles
+}
+
+func TestEventService_SeriesScopes(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 10, 7, 10, 0, 0, 0, time.UTC)
+	till := day.AddDate(0, 0, 4*7)
+
+	standup := newTestEvent("user", "standup", day, 15*time.Minute)
+	standup.Recurrence = "FREQ=WEEKLY;COUNT=10"
+	series, err := es.CreateEvent(ctx, standup)
+	if err != nil {
+		t.Fatalf("can't create recurring event: %s", err)
+	}
+	id := series.Id.String()
+
+	second := day.AddDate(0, 0, 7)
+	moved := newTestEvent("user", "moved", second.Add(time.Hour), 15*time.Minute)
+	override, err := es.UpdateEvent(ctx, id, models.ScopeThis, &second, moved)
+	if err != nil {
+		t.Fatalf("can't update occurrence: %s", err)
+	}
+	third := day.AddDate(0, 0, 14)
+	if err := es.DeleteEvent(ctx, id, "user", models.ScopeThis, &third); err != nil {
+		t.Fatalf("can't cancel occurrence: %s", err)
+	}
+	expected := []string{"standup", "moved", "standup", "standup"}
+	if got := listTitles(t, es, day, till); !equalTitles(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	wrong := day.Add(time.Minute)
+	if err := es.DeleteEvent(ctx, id, "user", models.ScopeThis, &wrong); err != errors.ErrIncorrectOccurrence {
+		t.Errorf("expected %q, got %v", errors.ErrIncorrectOccurrence, err)
+	}
+
+	// override addressed by own id splits series at its original start
+	renamed := newTestEvent("user", "renamed", second, 15*time.Minute)
+	if _, err := es.UpdateEvent(ctx, override.Id.String(), models.ScopeThisAndFollowing, nil, renamed); err != nil {
+		t.Fatalf("can't update following occurrences: %s", err)
+	}
+	expected = []string{"standup", "renamed", "renamed", "renamed", "renamed"}
+	if got := listTitles(t, es, day, till); !equalTitles(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := listTitles(t, es, day, day.AddDate(1, 0, 0)); len(got) != 10 {
+		t.Errorf("expected 10 occurrences after split, got %d", len(got))
+	}
+	truncated, err := es.EventStorage.GetEventByIdOwner(ctx, id, "user")
+	if err != nil {
+		t.Fatalf("can't get truncated series: %s", err)
+	}
+	if truncated.SeriesEnd == nil || !truncated.SeriesEnd.Equal(second.Add(-time.Second).Add(15*time.Minute)) {
+		t.Errorf("truncated series should end before split, got %v", truncated.SeriesEnd)
+	}
+
+	if err := es.DeleteEvent(ctx, id, "user", models.ScopeAll, nil); err != nil {
+		t.Fatalf("can't delete series: %s", err)
+	}
+	expected = []string{"renamed", "renamed", "renamed", "renamed"}
+	if got := listTitles(t, es, day, till); !equalTitles(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func equalTitles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestEventService_SplitSeriesOverlap(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 10, 7, 10, 0, 0, 0, time.UTC)
+	till := day.AddDate(0, 0, 4*7)
+
+	standup := newTestEvent("user", "standup", day, 15*time.Minute)
+	standup.Recurrence = "FREQ=WEEKLY;COUNT=4"
+	series, err := es.CreateEvent(ctx, standup)
+	if err != nil {
+		t.Fatalf("can't create recurring event: %s", err)
+	}
+	id := series.Id.String()
+	third := day.AddDate(0, 0, 14)
+	if _, err := es.UpdateEvent(ctx, id, models.ScopeThis, &third, newTestEvent("user", "moved", third, 15*time.Minute)); err != nil {
+		t.Fatalf("can't update occurrence: %s", err)
+	}
+	if _, err := es.CreateEvent(ctx, newTestEvent("user", "meeting", third.Add(time.Hour), time.Hour)); err != nil {
+		t.Fatalf("can't create event: %s", err)
+	}
+	skipChangeNotifications(ctx, t, es.EventStorage)
+
+	second := day.AddDate(0, 0, 7)
+	later := newTestEvent("user", "later", second.Add(time.Hour), 15*time.Minute)
+	if _, err := es.UpdateEvent(ctx, id, models.ScopeThisAndFollowing, &second, later); err != errors.ErrOverlaping {
+		t.Fatalf("expected %q, got %v", errors.ErrOverlaping, err)
+	}
+	expected := []string{"standup", "standup", "moved", "meeting", "standup"}
+	if got := listTitles(t, es, day, till); !equalTitles(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	stored, err := es.EventStorage.GetEventByIdOwner(ctx, id, "user")
+	if err != nil {
+		t.Fatalf("can't get series: %s", err)
+	}
+	if stored.Recurrence != standup.Recurrence {
+		t.Errorf("series shouldn't be truncated, got rule %q", stored.Recurrence)
+	}
+	if pending := pendingNotifications(ctx, t, es.EventStorage); len(pending) != 0 {
+		t.Errorf("expected no notifications about rejected update, got %d", len(pending))
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"time"
+)
+
+// maxSlots limits number of slots returned at once
+const maxSlots = 100
+
+// FindSlots returns the earliest count slots of duration in [from, till) period, where owners have no events.
+// Consecutive slots of the same free time follow each other, slots are limited by working hours if they are set
+func (es *EventService) FindSlots(ctx context.Context, owners []string, from, till time.Time, duration time.Duration,
+	hours *models.WorkingHours, count int) ([]models.Interval, error) {
+	if duration <= 0 {
+		return nil, errors.ErrIncorrectDuration
+	}
+	if hours != nil && !validHours(hours) {
+		return nil, errors.ErrIncorrectHours
+	}
+	if count <= 0 {
+		count = 1
+	}
+	if count > maxSlots {
+		count = maxSlots
+	}
+	var busy []models.Interval
+	for _, owner := range owners {
+		intervals, err := es.GetFreeBusy(ctx, owner, from, till)
+		if err != nil {
+			return nil, err
+		}
+		busy = append(busy, intervals...)
+	}
+	slots := make([]models.Interval, 0, count)
+	for _, free := range freeIntervals(mergeIntervals(busy), from, till) {
+		for _, w := range workingIntervals(free, hours) {
+			for start := w.Start; !start.Add(duration).After(w.End); start = start.Add(duration) {
+				slots = append(slots, models.Interval{Start: start, End: start.Add(duration)})
+				if len(slots) == count {
+					return slots, nil
+				}
+			}
+		}
+	}
+	return slots, nil
+}
+
+func validHours(hours *models.WorkingHours) bool {
+	day := 24 * time.Hour
+	return hours.Location != nil && hours.Start != hours.End &&
+		hours.Start >= 0 && hours.Start < day && hours.End >= 0 && hours.End < day
+}
+
+// freeIntervals returns gaps between sorted busy intervals in [from, till) period
+func freeIntervals(busy []models.Interval, from, till time.Time) []models.Interval {
+	var res []models.Interval
+	start := from
+	for _, b := range busy {
+		if b.Start.After(start) {
+			res = append(res, models.Interval{Start: start, End: b.Start})
+		}
+		start = maxTime(start, b.End)
+	}
+	if till.After(start) {
+		res = append(res, models.Interval{Start: start, End: till})
+	}
+	return res
+}
+
+// workingIntervals cuts free interval by working hours of days it covers, all of it is returned if hours aren't set
+func workingIntervals(free models.Interval, hours *models.WorkingHours) []models.Interval {
+	if hours == nil {
+		return []models.Interval{free}
+	}
+	start := free.Start.In(hours.Location)
+	var res []models.Interval
+	// night shift of the previous day can cover beginning of the interval
+	for day := start.AddDate(0, 0, -1); ; day = day.AddDate(0, 0, 1) {
+		ws := atTime(day, hours.Start)
+		if !ws.Before(free.End) {
+			return res
+		}
+		we := atTime(day, hours.End)
+		if hours.End < hours.Start {
+			we = atTime(day.AddDate(0, 0, 1), hours.End)
+		}
+		if s, e := maxTime(ws, free.Start), minTime(we, free.End); e.After(s) {
+			res = append(res, models.Interval{Start: s, End: e})
+		}
+	}
+}
+
+// atTime returns wall clock time of the day, it's correct on days with daylight saving time changes
+func atTime(day time.Time, sinceMidnight time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, int(sinceMidnight/time.Second), 0, day.Location())
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"testing"
+	"time"
+)
+
+func TestEventService_FindSlots(t *testing.T) {
+	ctx := context.Background()
+	es := newTestEventService(t)
+	day := time.Date(2019, 11, 4, 0, 0, 0, 0, time.UTC)
+	for _, e := range []*models.Event{
+		newTestEvent("user", "meeting", day.Add(10*time.Hour), time.Hour),
+		newTestEvent("bob", "lunch", day.Add(11*time.Hour+30*time.Minute), 30*time.Minute),
+	} {
+		if _, err := es.CreateEvent(ctx, e); err != nil {
+			t.Fatalf("can't create event: %s", err)
+		}
+	}
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		till     time.Time
+		hours    *models.WorkingHours
+		count    int
+		expected []time.Time
+	}{
+		{"working hours", at(9, 0), at(13, 0), &models.WorkingHours{Start: 9*time.Hour + 30*time.Minute, End: 18 * time.Hour, Location: time.UTC},
+			3, []time.Time{at(9, 30), at(11, 0), at(12, 0)}},
+		{"without working hours", at(9, 0), at(12, 30), nil, 5, []time.Time{at(9, 0), at(9, 30), at(11, 0), at(12, 0)}},
+		{"night shift", at(0, 0), at(24+2, 0), &models.WorkingHours{Start: 23 * time.Hour, End: 1 * time.Hour, Location: time.UTC},
+			10, []time.Time{at(0, 0), at(0, 30), at(23, 0), at(23, 30), at(24, 0), at(24, 30)}},
+	}
+	for _, tt := range tests {
+		slots, err := es.FindSlots(ctx, []string{"user", "bob"}, tt.from, tt.till, 30*time.Minute, tt.hours, tt.count)
+		if err != nil {
+			t.Fatalf("%s: can't find slots: %s", tt.name, err)
+		}
+		if len(slots) != len(tt.expected) {
+			t.Errorf("%s: expected %d slots, got %v", tt.name, len(tt.expected), slots)
+			continue
+		}
+		for i, s := range slots {
+			if !s.Start.Equal(tt.expected[i]) || s.End.Sub(s.Start) != 30*time.Minute {
+				t.Errorf("%s: slot %d: expected start at %s, got %v", tt.name, i, tt.expected[i], s)
+			}
+		}
+	}
+}
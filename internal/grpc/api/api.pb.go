@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
+	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -25,12 +27,172 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Scope of changes of recurring event series
+type Scope int32
+
+const (
+	Scope_ALL                Scope = 0
+	Scope_THIS               Scope = 1
+	Scope_THIS_AND_FOLLOWING Scope = 2
+)
+
+var Scope_name = map[int32]string{
+	0: "ALL",
+	1: "THIS",
+	2: "THIS_AND_FOLLOWING",
+}
+
+var Scope_value = map[string]int32{
+	"ALL":                0,
+	"THIS":               1,
+	"THIS_AND_FOLLOWING": 2,
+}
+
+func (x Scope) String() string {
+	return proto.EnumName(Scope_name, int32(x))
+}
+
+func (Scope) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{0}
+}
+
+// Transparency is availability of owner during event, only busy events can't overlap
+// and are shown in free/busy time
+type Transparency int32
+
+const (
+	Transparency_BUSY      Transparency = 0
+	Transparency_FREE      Transparency = 1
+	Transparency_TENTATIVE Transparency = 2
+)
+
+var Transparency_name = map[int32]string{
+	0: "BUSY",
+	1: "FREE",
+	2: "TENTATIVE",
+}
+
+var Transparency_value = map[string]int32{
+	"BUSY":      0,
+	"FREE":      1,
+	"TENTATIVE": 2,
+}
+
+func (x Transparency) String() string {
+	return proto.EnumName(Transparency_name, int32(x))
+}
+
+func (Transparency) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{1}
+}
+
+type ImportStatus int32
+
+const (
+	ImportStatus_CREATED   ImportStatus = 0
+	ImportStatus_DUPLICATE ImportStatus = 1
+	ImportStatus_REJECTED  ImportStatus = 2
+)
+
+var ImportStatus_name = map[int32]string{
+	0: "CREATED",
+	1: "DUPLICATE",
+	2: "REJECTED",
+}
+
+var ImportStatus_value = map[string]int32{
+	"CREATED":   0,
+	"DUPLICATE": 1,
+	"REJECTED":  2,
+}
+
+func (x ImportStatus) String() string {
+	return proto.EnumName(ImportStatus_name, int32(x))
+}
+
+func (ImportStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{2}
+}
+
+// Role is access level to shared calendar, each role includes lower ones
+type Role int32
+
+const (
+	Role_FREE_BUSY Role = 0
+	Role_READ      Role = 1
+	Role_WRITE     Role = 2
+)
+
+var Role_name = map[int32]string{
+	0: "FREE_BUSY",
+	1: "READ",
+	2: "WRITE",
+}
+
+var Role_value = map[string]int32{
+	"FREE_BUSY": 0,
+	"READ":      1,
+	"WRITE":     2,
+}
+
+func (x Role) String() string {
+	return proto.EnumName(Role_name, int32(x))
+}
+
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{3}
+}
+
+// AttendeeStatus is response of attendee to invitation
+type AttendeeStatus int32
+
+const (
+	AttendeeStatus_NEEDS_ACTION AttendeeStatus = 0
+	AttendeeStatus_ACCEPTED     AttendeeStatus = 1
+	AttendeeStatus_DECLINED     AttendeeStatus = 2
+	AttendeeStatus_TENTATIVE    AttendeeStatus = 3
+)
+
+var AttendeeStatus_name = map[int32]string{
+	0: "NEEDS_ACTION",
+	1: "ACCEPTED",
+	2: "DECLINED",
+	3: "TENTATIVE",
+}
+
+var AttendeeStatus_value = map[string]int32{
+	"NEEDS_ACTION": 0,
+	"ACCEPTED":     1,
+	"DECLINED":     2,
+	"TENTATIVE":    3,
+}
+
+func (x AttendeeStatus) String() string {
+	return proto.EnumName(AttendeeStatus_name, int32(x))
+}
+
+func (AttendeeStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{4}
+}
+
 type Event struct {
-	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title                string               `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Text                 string               `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
-	StartTime            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime              *timestamp.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Id                string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title             string               `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Text              string               `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	StartTime         *timestamp.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime           *timestamp.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Recurrence        string               `protobuf:"bytes,6,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	SeriesId          string               `protobuf:"bytes,7,opt,name=series_id,json=seriesId,proto3" json:"series_id,omitempty"`
+	OriginalStartTime *timestamp.Timestamp `protobuf:"bytes,8,opt,name=original_start_time,json=originalStartTime,proto3" json:"original_start_time,omitempty"`
+	Owner             string               `protobuf:"bytes,9,opt,name=owner,proto3" json:"owner,omitempty"`
+	Attendees         []*Attendee          `protobuf:"bytes,10,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	Transparency      Transparency         `protobuf:"varint,11,opt,name=transparency,proto3,enum=Transparency" json:"transparency,omitempty"`
+	// all-day event lasts dates from start_time till end_time exclusive, times are midnights in UTC
+	AllDay bool `protobuf:"varint,12,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	// IANA time zone, recurring event keeps local time of its start in it. UTC if not set
+	TimeZone string `protobuf:"bytes,13,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// offsets of notifications before start_time, zero is reminder at start. One day if not set
+	Reminders            []*duration.Duration `protobuf:"bytes,14,rep,name=reminders,proto3" json:"reminders,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -96,11 +258,80 @@ func (m *Event) GetEndTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *Event) GetRecurrence() string {
+	if m != nil {
+		return m.Recurrence
+	}
+	return ""
+}
+
+func (m *Event) GetSeriesId() string {
+	if m != nil {
+		return m.SeriesId
+	}
+	return ""
+}
+
+func (m *Event) GetOriginalStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.OriginalStartTime
+	}
+	return nil
+}
+
+func (m *Event) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *Event) GetAttendees() []*Attendee {
+	if m != nil {
+		return m.Attendees
+	}
+	return nil
+}
+
+func (m *Event) GetTransparency() Transparency {
+	if m != nil {
+		return m.Transparency
+	}
+	return Transparency_BUSY
+}
+
+func (m *Event) GetAllDay() bool {
+	if m != nil {
+		return m.AllDay
+	}
+	return false
+}
+
+func (m *Event) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *Event) GetReminders() []*duration.Duration {
+	if m != nil {
+		return m.Reminders
+	}
+	return nil
+}
+
 type CreateEventRequest struct {
-	Title                string               `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Text                 string               `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
-	StartTime            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Title        string               `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Text         string               `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	StartTime    *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime      *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Recurrence   string               `protobuf:"bytes,5,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	Transparency Transparency         `protobuf:"varint,6,opt,name=transparency,proto3,enum=Transparency" json:"transparency,omitempty"`
+	// times of all-day event are truncated to dates
+	AllDay               bool                 `protobuf:"varint,7,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	TimeZone             string               `protobuf:"bytes,8,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Reminders            []*duration.Duration `protobuf:"bytes,9,rep,name=reminders,proto3" json:"reminders,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -159,6 +390,41 @@ func (m *CreateEventRequest) GetEndTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *CreateEventRequest) GetRecurrence() string {
+	if m != nil {
+		return m.Recurrence
+	}
+	return ""
+}
+
+func (m *CreateEventRequest) GetTransparency() Transparency {
+	if m != nil {
+		return m.Transparency
+	}
+	return Transparency_BUSY
+}
+
+func (m *CreateEventRequest) GetAllDay() bool {
+	if m != nil {
+		return m.AllDay
+	}
+	return false
+}
+
+func (m *CreateEventRequest) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *CreateEventRequest) GetReminders() []*duration.Duration {
+	if m != nil {
+		return m.Reminders
+	}
+	return nil
+}
+
 type CreateEventResponse struct {
 	// Types that are valid to be assigned to Result:
 	//	*CreateEventResponse_Event
@@ -240,11 +506,20 @@ func (*CreateEventResponse) XXX_OneofWrappers() []interface{} {
 }
 
 type UpdateEventRequest struct {
-	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title                string               `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Text                 string               `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
-	StartTime            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime              *timestamp.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Id                  string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title               string               `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Text                string               `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	StartTime           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Recurrence          string               `protobuf:"bytes,6,opt,name=recurrence,proto3" json:"recurrence,omitempty"`
+	Scope               Scope                `protobuf:"varint,7,opt,name=scope,proto3,enum=Scope" json:"scope,omitempty"`
+	OccurrenceStartTime *timestamp.Timestamp `protobuf:"bytes,8,opt,name=occurrence_start_time,json=occurrenceStartTime,proto3" json:"occurrence_start_time,omitempty"`
+	// owner of shared calendar, write role is required. Own calendar if not set
+	CalendarOwner        string               `protobuf:"bytes,9,opt,name=calendar_owner,json=calendarOwner,proto3" json:"calendar_owner,omitempty"`
+	Transparency         Transparency         `protobuf:"varint,10,opt,name=transparency,proto3,enum=Transparency" json:"transparency,omitempty"`
+	AllDay               bool                 `protobuf:"varint,11,opt,name=all_day,json=allDay,proto3" json:"all_day,omitempty"`
+	TimeZone             string               `protobuf:"bytes,12,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	Reminders            []*duration.Duration `protobuf:"bytes,13,rep,name=reminders,proto3" json:"reminders,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -310,6 +585,62 @@ func (m *UpdateEventRequest) GetEndTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *UpdateEventRequest) GetRecurrence() string {
+	if m != nil {
+		return m.Recurrence
+	}
+	return ""
+}
+
+func (m *UpdateEventRequest) GetScope() Scope {
+	if m != nil {
+		return m.Scope
+	}
+	return Scope_ALL
+}
+
+func (m *UpdateEventRequest) GetOccurrenceStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.OccurrenceStartTime
+	}
+	return nil
+}
+
+func (m *UpdateEventRequest) GetCalendarOwner() string {
+	if m != nil {
+		return m.CalendarOwner
+	}
+	return ""
+}
+
+func (m *UpdateEventRequest) GetTransparency() Transparency {
+	if m != nil {
+		return m.Transparency
+	}
+	return Transparency_BUSY
+}
+
+func (m *UpdateEventRequest) GetAllDay() bool {
+	if m != nil {
+		return m.AllDay
+	}
+	return false
+}
+
+func (m *UpdateEventRequest) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+func (m *UpdateEventRequest) GetReminders() []*duration.Duration {
+	if m != nil {
+		return m.Reminders
+	}
+	return nil
+}
+
 type UpdateEventResponse struct {
 	// Types that are valid to be assigned to Result:
 	//	*UpdateEventResponse_Event
@@ -391,7 +722,11 @@ func (*UpdateEventResponse) XXX_OneofWrappers() []interface{} {
 }
 
 type DeleteEventRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id                  string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Scope               Scope                `protobuf:"varint,2,opt,name=scope,proto3,enum=Scope" json:"scope,omitempty"`
+	OccurrenceStartTime *timestamp.Timestamp `protobuf:"bytes,3,opt,name=occurrence_start_time,json=occurrenceStartTime,proto3" json:"occurrence_start_time,omitempty"`
+	// owner of shared calendar, write role is required. Own calendar if not set
+	CalendarOwner        string   `protobuf:"bytes,4,opt,name=calendar_owner,json=calendarOwner,proto3" json:"calendar_owner,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -429,8 +764,31 @@ func (m *DeleteEventRequest) GetId() string {
 	return ""
 }
 
+func (m *DeleteEventRequest) GetScope() Scope {
+	if m != nil {
+		return m.Scope
+	}
+	return Scope_ALL
+}
+
+func (m *DeleteEventRequest) GetOccurrenceStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.OccurrenceStartTime
+	}
+	return nil
+}
+
+func (m *DeleteEventRequest) GetCalendarOwner() string {
+	if m != nil {
+		return m.CalendarOwner
+	}
+	return ""
+}
+
 type GetEventRequest struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// owner of shared calendar, read role is required. Own calendar if not set
+	CalendarOwner        string   `protobuf:"bytes,2,opt,name=calendar_owner,json=calendarOwner,proto3" json:"calendar_owner,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -468,6 +826,13 @@ func (m *GetEventRequest) GetId() string {
 	return ""
 }
 
+func (m *GetEventRequest) GetCalendarOwner() string {
+	if m != nil {
+		return m.CalendarOwner
+	}
+	return ""
+}
+
 type GetEventResponse struct {
 	// Types that are valid to be assigned to Result:
 	//	*GetEventResponse_Event
@@ -614,10 +979,13 @@ func (*DeleteEventResponse) XXX_OneofWrappers() []interface{} {
 }
 
 type ListEventsRequest struct {
-	StartTime            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	StartTime *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// owner of shared calendar, own calendar if not set. Only busy times of events are returned for free-busy role
+	CalendarOwner        string   `protobuf:"bytes,3,opt,name=calendar_owner,json=calendarOwner,proto3" json:"calendar_owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListEventsRequest) Reset()         { *m = ListEventsRequest{} }
@@ -652,6 +1020,20 @@ func (m *ListEventsRequest) GetStartTime() *timestamp.Timestamp {
 	return nil
 }
 
+func (m *ListEventsRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *ListEventsRequest) GetCalendarOwner() string {
+	if m != nil {
+		return m.CalendarOwner
+	}
+	return ""
+}
+
 type ListEventsResponse struct {
 	Events               []*Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -691,80 +1073,1838 @@ func (m *ListEventsResponse) GetEvents() []*Event {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Event)(nil), "Event")
-	proto.RegisterType((*CreateEventRequest)(nil), "CreateEventRequest")
-	proto.RegisterType((*CreateEventResponse)(nil), "CreateEventResponse")
-	proto.RegisterType((*UpdateEventRequest)(nil), "UpdateEventRequest")
-	proto.RegisterType((*UpdateEventResponse)(nil), "UpdateEventResponse")
-	proto.RegisterType((*DeleteEventRequest)(nil), "DeleteEventRequest")
-	proto.RegisterType((*GetEventRequest)(nil), "GetEventRequest")
-	proto.RegisterType((*GetEventResponse)(nil), "GetEventResponse")
-	proto.RegisterType((*DeleteEventResponse)(nil), "DeleteEventResponse")
-	proto.RegisterType((*ListEventsRequest)(nil), "ListEventsRequest")
-	proto.RegisterType((*ListEventsResponse)(nil), "ListEventsResponse")
+type ExportEventsRequest struct {
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func init() { proto.RegisterFile("api/api.proto", fileDescriptor_1b40cafcd4234784) }
+func (m *ExportEventsRequest) Reset()         { *m = ExportEventsRequest{} }
+func (m *ExportEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportEventsRequest) ProtoMessage()    {}
+func (*ExportEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{11}
+}
 
-var fileDescriptor_1b40cafcd4234784 = []byte{
-	// 457 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0xbd, 0x4e, 0x1c, 0xd2, 0x89, 0xa0, 0xed, 0x38, 0x42, 0x96, 0x0f, 0x10, 0x56, 0x1c,
-	0x7a, 0xda, 0x88, 0x00, 0xaa, 0xe0, 0x48, 0x41, 0x70, 0x40, 0x1c, 0x4c, 0x51, 0x8f, 0xc8, 0xc5,
-	0x43, 0xb5, 0x92, 0x6b, 0x9b, 0xdd, 0x4d, 0xc5, 0x63, 0x81, 0x78, 0x01, 0x1e, 0x0d, 0x79, 0xb7,
-	0x69, 0xec, 0xd8, 0x2d, 0x7f, 0x94, 0x0b, 0x37, 0xcf, 0x78, 0x66, 0xf7, 0x37, 0xdf, 0x7c, 0x0b,
-	0xb7, 0xd3, 0x4a, 0xce, 0xd3, 0x4a, 0x8a, 0x4a, 0x95, 0xa6, 0x8c, 0xef, 0x9f, 0x95, 0xe5, 0x59,
-	0x4e, 0x73, 0x1b, 0x9d, 0x2e, 0x3f, 0xcf, 0x8d, 0x3c, 0x27, 0x6d, 0xd2, 0xf3, 0xca, 0x15, 0xf0,
-	0x1f, 0x0c, 0x82, 0x57, 0x17, 0x54, 0x18, 0xbc, 0x03, 0xbe, 0xcc, 0x22, 0x36, 0x63, 0x07, 0x3b,
-	0x89, 0x2f, 0x33, 0x9c, 0x42, 0x60, 0xa4, 0xc9, 0x29, 0xf2, 0x6d, 0xca, 0x05, 0x88, 0x30, 0x34,
-	0xf4, 0xd5, 0x44, 0x03, 0x9b, 0xb4, 0xdf, 0xf8, 0x0c, 0x40, 0x9b, 0x54, 0x99, 0x8f, 0xf5, 0xe1,
-	0xd1, 0x70, 0xc6, 0x0e, 0x26, 0x8b, 0x58, 0xb8, 0x9b, 0xc5, 0xea, 0x66, 0x71, 0xbc, 0xba, 0x39,
-	0xd9, 0xb1, 0xd5, 0x75, 0x8c, 0x4f, 0x61, 0x4c, 0x45, 0xe6, 0x1a, 0x83, 0xdf, 0x36, 0xde, 0xa2,
-	0x22, 0xab, 0x23, 0xfe, 0x8d, 0x01, 0x1e, 0x29, 0x4a, 0x0d, 0x59, 0xf6, 0x84, 0xbe, 0x2c, 0x49,
-	0x9b, 0x35, 0x32, 0xeb, 0x43, 0xf6, 0xaf, 0x45, 0x1e, 0xfc, 0x2b, 0xf2, 0xf0, 0xcf, 0x91, 0x4f,
-	0x20, 0x6c, 0x11, 0xeb, 0xaa, 0x2c, 0x34, 0xe1, 0x3d, 0x08, 0xa8, 0x4e, 0x58, 0xe4, 0xc9, 0x62,
-	0x24, 0xec, 0xef, 0x37, 0x5e, 0xe2, 0xd2, 0x78, 0x17, 0x02, 0x52, 0xaa, 0x54, 0x8e, 0xde, 0xe6,
-	0xeb, 0xf0, 0xc5, 0x18, 0x46, 0x8a, 0xf4, 0x32, 0x37, 0xfc, 0x27, 0x03, 0xfc, 0x50, 0x65, 0x9b,
-	0x5a, 0xfc, 0x4f, 0xeb, 0x3c, 0x81, 0xb0, 0x35, 0xc1, 0xd6, 0xb4, 0x79, 0x08, 0xf8, 0x92, 0x72,
-	0xba, 0x59, 0x1a, 0xfe, 0x00, 0x76, 0x5f, 0x93, 0xb9, 0xb1, 0xe4, 0x18, 0xf6, 0xd6, 0x25, 0x5b,
-	0xc3, 0x3b, 0x84, 0xb0, 0x85, 0x77, 0x79, 0xf0, 0x55, 0x23, 0xbb, 0xae, 0xf1, 0x1d, 0xec, 0xbf,
-	0x95, 0xda, 0xf1, 0xe8, 0x15, 0x73, 0x7b, 0x6f, 0xec, 0x2f, 0xf6, 0xc6, 0x9f, 0x00, 0x36, 0xcf,
-	0xbb, 0x1a, 0x70, 0x64, 0x27, 0xd1, 0x11, 0x9b, 0x0d, 0xd6, 0x13, 0x26, 0x97, 0xd9, 0xc5, 0x77,
-	0x1f, 0x76, 0x8f, 0xd2, 0x9c, 0x8a, 0x2c, 0x55, 0xef, 0x49, 0x5d, 0xc8, 0x4f, 0x84, 0xcf, 0x61,
-	0xd2, 0xb0, 0x39, 0x86, 0xa2, 0xfb, 0x4c, 0xe3, 0xa9, 0xe8, 0x79, 0x09, 0xdc, 0xab, 0x7b, 0x1b,
-	0x72, 0x60, 0x28, 0xba, 0xbb, 0x8b, 0xa7, 0xa2, 0x47, 0x31, 0xd7, 0xdb, 0xb0, 0x10, 0x86, 0xa2,
-	0xfb, 0x24, 0xe2, 0xa9, 0xe8, 0x71, 0x19, 0xf7, 0xf0, 0x10, 0x60, 0x3d, 0x3d, 0xa2, 0xe8, 0x48,
-	0x1b, 0x87, 0xa2, 0x2b, 0x0f, 0xf7, 0xf0, 0x11, 0x8c, 0x57, 0xae, 0xc0, 0x3d, 0xb1, 0xe1, 0xa1,
-	0x78, 0x5f, 0x6c, 0x5a, 0x86, 0x7b, 0xa7, 0x23, 0xbb, 0x88, 0xc7, 0xbf, 0x02, 0x00, 0x00, 0xff,
-	0xff, 0x45, 0x89, 0x73, 0xb2, 0xa8, 0x05, 0x00, 0x00,
+func (m *ExportEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportEventsRequest.Unmarshal(m, b)
+}
+func (m *ExportEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportEventsRequest.Merge(m, src)
+}
+func (m *ExportEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportEventsRequest.Size(m)
+}
+func (m *ExportEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportEventsRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_ExportEventsRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *ExportEventsRequest) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
 
-// CalendarServiceClient is the client API for CalendarService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CalendarServiceClient interface {
-	CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*CreateEventResponse, error)
-	DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*DeleteEventResponse, error)
-	UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*UpdateEventResponse, error)
-	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
-	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*GetEventResponse, error)
+func (m *ExportEventsRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
 }
 
-type calendarServiceClient struct {
-	cc *grpc.ClientConn
+// ExportEventsResponse contains RFC 5545 VCALENDAR object
+type ExportEventsResponse struct {
+	Calendar             []byte   `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func NewCalendarServiceClient(cc *grpc.ClientConn) CalendarServiceClient {
-	return &calendarServiceClient{cc}
+func (m *ExportEventsResponse) Reset()         { *m = ExportEventsResponse{} }
+func (m *ExportEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportEventsResponse) ProtoMessage()    {}
+func (*ExportEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{12}
+}
+
+func (m *ExportEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportEventsResponse.Unmarshal(m, b)
+}
+func (m *ExportEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportEventsResponse.Merge(m, src)
+}
+func (m *ExportEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportEventsResponse.Size(m)
+}
+func (m *ExportEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportEventsResponse proto.InternalMessageInfo
+
+func (m *ExportEventsResponse) GetCalendar() []byte {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
+}
+
+type ImportEventsRequest struct {
+	// RFC 5545 VCALENDAR object
+	Calendar             []byte   `protobuf:"bytes,1,opt,name=calendar,proto3" json:"calendar,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportEventsRequest) Reset()         { *m = ImportEventsRequest{} }
+func (m *ImportEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportEventsRequest) ProtoMessage()    {}
+func (*ImportEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{13}
+}
+
+func (m *ImportEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportEventsRequest.Unmarshal(m, b)
+}
+func (m *ImportEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportEventsRequest.Merge(m, src)
+}
+func (m *ImportEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportEventsRequest.Size(m)
+}
+func (m *ImportEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportEventsRequest proto.InternalMessageInfo
+
+func (m *ImportEventsRequest) GetCalendar() []byte {
+	if m != nil {
+		return m.Calendar
+	}
+	return nil
+}
+
+type ImportResult struct {
+	Uid   string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// set for overrides of recurring events
+	OriginalStartTime    *timestamp.Timestamp `protobuf:"bytes,3,opt,name=original_start_time,json=originalStartTime,proto3" json:"original_start_time,omitempty"`
+	Status               ImportStatus         `protobuf:"varint,4,opt,name=status,proto3,enum=ImportStatus" json:"status,omitempty"`
+	EventId              string               `protobuf:"bytes,5,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Reason               string               `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ImportResult) Reset()         { *m = ImportResult{} }
+func (m *ImportResult) String() string { return proto.CompactTextString(m) }
+func (*ImportResult) ProtoMessage()    {}
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{14}
+}
+
+func (m *ImportResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportResult.Unmarshal(m, b)
+}
+func (m *ImportResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportResult.Marshal(b, m, deterministic)
+}
+func (m *ImportResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportResult.Merge(m, src)
+}
+func (m *ImportResult) XXX_Size() int {
+	return xxx_messageInfo_ImportResult.Size(m)
+}
+func (m *ImportResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportResult proto.InternalMessageInfo
+
+func (m *ImportResult) GetUid() string {
+	if m != nil {
+		return m.Uid
+	}
+	return ""
+}
+
+func (m *ImportResult) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *ImportResult) GetOriginalStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.OriginalStartTime
+	}
+	return nil
+}
+
+func (m *ImportResult) GetStatus() ImportStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ImportStatus_CREATED
+}
+
+func (m *ImportResult) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *ImportResult) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type ImportEventsResponse struct {
+	Results              []*ImportResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ImportEventsResponse) Reset()         { *m = ImportEventsResponse{} }
+func (m *ImportEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportEventsResponse) ProtoMessage()    {}
+func (*ImportEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{15}
+}
+
+func (m *ImportEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportEventsResponse.Unmarshal(m, b)
+}
+func (m *ImportEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportEventsResponse.Merge(m, src)
+}
+func (m *ImportEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportEventsResponse.Size(m)
+}
+func (m *ImportEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportEventsResponse proto.InternalMessageInfo
+
+func (m *ImportEventsResponse) GetResults() []*ImportResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// FeedToken gives read-only access to owner's iCalendar feed served over HTTP by path
+type FeedToken struct {
+	Token                string               `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Path                 string               `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *FeedToken) Reset()         { *m = FeedToken{} }
+func (m *FeedToken) String() string { return proto.CompactTextString(m) }
+func (*FeedToken) ProtoMessage()    {}
+func (*FeedToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{16}
+}
+
+func (m *FeedToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FeedToken.Unmarshal(m, b)
+}
+func (m *FeedToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FeedToken.Marshal(b, m, deterministic)
+}
+func (m *FeedToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeedToken.Merge(m, src)
+}
+func (m *FeedToken) XXX_Size() int {
+	return xxx_messageInfo_FeedToken.Size(m)
+}
+func (m *FeedToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeedToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeedToken proto.InternalMessageInfo
+
+func (m *FeedToken) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *FeedToken) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FeedToken) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type CreateFeedTokenRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateFeedTokenRequest) Reset()         { *m = CreateFeedTokenRequest{} }
+func (m *CreateFeedTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateFeedTokenRequest) ProtoMessage()    {}
+func (*CreateFeedTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{17}
+}
+
+func (m *CreateFeedTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFeedTokenRequest.Unmarshal(m, b)
+}
+func (m *CreateFeedTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFeedTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateFeedTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFeedTokenRequest.Merge(m, src)
+}
+func (m *CreateFeedTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateFeedTokenRequest.Size(m)
+}
+func (m *CreateFeedTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFeedTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFeedTokenRequest proto.InternalMessageInfo
+
+type CreateFeedTokenResponse struct {
+	FeedToken            *FeedToken `protobuf:"bytes,1,opt,name=feed_token,json=feedToken,proto3" json:"feed_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateFeedTokenResponse) Reset()         { *m = CreateFeedTokenResponse{} }
+func (m *CreateFeedTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateFeedTokenResponse) ProtoMessage()    {}
+func (*CreateFeedTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{18}
+}
+
+func (m *CreateFeedTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateFeedTokenResponse.Unmarshal(m, b)
+}
+func (m *CreateFeedTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateFeedTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateFeedTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateFeedTokenResponse.Merge(m, src)
+}
+func (m *CreateFeedTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateFeedTokenResponse.Size(m)
+}
+func (m *CreateFeedTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateFeedTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateFeedTokenResponse proto.InternalMessageInfo
+
+func (m *CreateFeedTokenResponse) GetFeedToken() *FeedToken {
+	if m != nil {
+		return m.FeedToken
+	}
+	return nil
+}
+
+type RevokeFeedTokenRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeFeedTokenRequest) Reset()         { *m = RevokeFeedTokenRequest{} }
+func (m *RevokeFeedTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeFeedTokenRequest) ProtoMessage()    {}
+func (*RevokeFeedTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{19}
+}
+
+func (m *RevokeFeedTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeFeedTokenRequest.Unmarshal(m, b)
+}
+func (m *RevokeFeedTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeFeedTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeFeedTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeFeedTokenRequest.Merge(m, src)
+}
+func (m *RevokeFeedTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeFeedTokenRequest.Size(m)
+}
+func (m *RevokeFeedTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeFeedTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeFeedTokenRequest proto.InternalMessageInfo
+
+func (m *RevokeFeedTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type RevokeFeedTokenResponse struct {
+	// Types that are valid to be assigned to Result:
+	//	*RevokeFeedTokenResponse_Error
+	Result               isRevokeFeedTokenResponse_Result `protobuf_oneof:"result"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *RevokeFeedTokenResponse) Reset()         { *m = RevokeFeedTokenResponse{} }
+func (m *RevokeFeedTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeFeedTokenResponse) ProtoMessage()    {}
+func (*RevokeFeedTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{20}
+}
+
+func (m *RevokeFeedTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeFeedTokenResponse.Unmarshal(m, b)
+}
+func (m *RevokeFeedTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeFeedTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeFeedTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeFeedTokenResponse.Merge(m, src)
+}
+func (m *RevokeFeedTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeFeedTokenResponse.Size(m)
+}
+func (m *RevokeFeedTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeFeedTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeFeedTokenResponse proto.InternalMessageInfo
+
+type isRevokeFeedTokenResponse_Result interface {
+	isRevokeFeedTokenResponse_Result()
+}
+
+type RevokeFeedTokenResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+func (*RevokeFeedTokenResponse_Error) isRevokeFeedTokenResponse_Result() {}
+
+func (m *RevokeFeedTokenResponse) GetResult() isRevokeFeedTokenResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *RevokeFeedTokenResponse) GetError() string {
+	if x, ok := m.GetResult().(*RevokeFeedTokenResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*RevokeFeedTokenResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*RevokeFeedTokenResponse_Error)(nil),
+	}
+}
+
+type ListFeedTokensRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListFeedTokensRequest) Reset()         { *m = ListFeedTokensRequest{} }
+func (m *ListFeedTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*ListFeedTokensRequest) ProtoMessage()    {}
+func (*ListFeedTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{21}
+}
+
+func (m *ListFeedTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFeedTokensRequest.Unmarshal(m, b)
+}
+func (m *ListFeedTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFeedTokensRequest.Marshal(b, m, deterministic)
+}
+func (m *ListFeedTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFeedTokensRequest.Merge(m, src)
+}
+func (m *ListFeedTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_ListFeedTokensRequest.Size(m)
+}
+func (m *ListFeedTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFeedTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFeedTokensRequest proto.InternalMessageInfo
+
+type ListFeedTokensResponse struct {
+	FeedTokens           []*FeedToken `protobuf:"bytes,1,rep,name=feed_tokens,json=feedTokens,proto3" json:"feed_tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListFeedTokensResponse) Reset()         { *m = ListFeedTokensResponse{} }
+func (m *ListFeedTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*ListFeedTokensResponse) ProtoMessage()    {}
+func (*ListFeedTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{22}
+}
+
+func (m *ListFeedTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListFeedTokensResponse.Unmarshal(m, b)
+}
+func (m *ListFeedTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListFeedTokensResponse.Marshal(b, m, deterministic)
+}
+func (m *ListFeedTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListFeedTokensResponse.Merge(m, src)
+}
+func (m *ListFeedTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_ListFeedTokensResponse.Size(m)
+}
+func (m *ListFeedTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListFeedTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListFeedTokensResponse proto.InternalMessageInfo
+
+func (m *ListFeedTokensResponse) GetFeedTokens() []*FeedToken {
+	if m != nil {
+		return m.FeedTokens
+	}
+	return nil
+}
+
+// Grant gives grantee access to owner's calendar
+type Grant struct {
+	Grantee              string               `protobuf:"bytes,1,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	Role                 Role                 `protobuf:"varint,2,opt,name=role,proto3,enum=Role" json:"role,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Grant) Reset()         { *m = Grant{} }
+func (m *Grant) String() string { return proto.CompactTextString(m) }
+func (*Grant) ProtoMessage()    {}
+func (*Grant) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{23}
+}
+
+func (m *Grant) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Grant.Unmarshal(m, b)
+}
+func (m *Grant) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Grant.Marshal(b, m, deterministic)
+}
+func (m *Grant) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Grant.Merge(m, src)
+}
+func (m *Grant) XXX_Size() int {
+	return xxx_messageInfo_Grant.Size(m)
+}
+func (m *Grant) XXX_DiscardUnknown() {
+	xxx_messageInfo_Grant.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Grant proto.InternalMessageInfo
+
+func (m *Grant) GetGrantee() string {
+	if m != nil {
+		return m.Grantee
+	}
+	return ""
+}
+
+func (m *Grant) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_FREE_BUSY
+}
+
+func (m *Grant) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+// CreateGrantRequest grants role in own calendar, role of existing grant is replaced
+type CreateGrantRequest struct {
+	Grantee              string   `protobuf:"bytes,1,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	Role                 Role     `protobuf:"varint,2,opt,name=role,proto3,enum=Role" json:"role,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateGrantRequest) Reset()         { *m = CreateGrantRequest{} }
+func (m *CreateGrantRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateGrantRequest) ProtoMessage()    {}
+func (*CreateGrantRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{24}
+}
+
+func (m *CreateGrantRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateGrantRequest.Unmarshal(m, b)
+}
+func (m *CreateGrantRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateGrantRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateGrantRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateGrantRequest.Merge(m, src)
+}
+func (m *CreateGrantRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateGrantRequest.Size(m)
+}
+func (m *CreateGrantRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateGrantRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateGrantRequest proto.InternalMessageInfo
+
+func (m *CreateGrantRequest) GetGrantee() string {
+	if m != nil {
+		return m.Grantee
+	}
+	return ""
+}
+
+func (m *CreateGrantRequest) GetRole() Role {
+	if m != nil {
+		return m.Role
+	}
+	return Role_FREE_BUSY
+}
+
+type CreateGrantResponse struct {
+	// Types that are valid to be assigned to Result:
+	//	*CreateGrantResponse_Grant
+	//	*CreateGrantResponse_Error
+	Result               isCreateGrantResponse_Result `protobuf_oneof:"result"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *CreateGrantResponse) Reset()         { *m = CreateGrantResponse{} }
+func (m *CreateGrantResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateGrantResponse) ProtoMessage()    {}
+func (*CreateGrantResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{25}
+}
+
+func (m *CreateGrantResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateGrantResponse.Unmarshal(m, b)
+}
+func (m *CreateGrantResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateGrantResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateGrantResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateGrantResponse.Merge(m, src)
+}
+func (m *CreateGrantResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateGrantResponse.Size(m)
+}
+func (m *CreateGrantResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateGrantResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateGrantResponse proto.InternalMessageInfo
+
+type isCreateGrantResponse_Result interface {
+	isCreateGrantResponse_Result()
+}
+
+type CreateGrantResponse_Grant struct {
+	Grant *Grant `protobuf:"bytes,1,opt,name=grant,proto3,oneof"`
+}
+
+type CreateGrantResponse_Error struct {
+	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*CreateGrantResponse_Grant) isCreateGrantResponse_Result() {}
+
+func (*CreateGrantResponse_Error) isCreateGrantResponse_Result() {}
+
+func (m *CreateGrantResponse) GetResult() isCreateGrantResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *CreateGrantResponse) GetGrant() *Grant {
+	if x, ok := m.GetResult().(*CreateGrantResponse_Grant); ok {
+		return x.Grant
+	}
+	return nil
+}
+
+func (m *CreateGrantResponse) GetError() string {
+	if x, ok := m.GetResult().(*CreateGrantResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*CreateGrantResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*CreateGrantResponse_Grant)(nil),
+		(*CreateGrantResponse_Error)(nil),
+	}
+}
+
+type RevokeGrantRequest struct {
+	Grantee              string   `protobuf:"bytes,1,opt,name=grantee,proto3" json:"grantee,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeGrantRequest) Reset()         { *m = RevokeGrantRequest{} }
+func (m *RevokeGrantRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeGrantRequest) ProtoMessage()    {}
+func (*RevokeGrantRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{26}
+}
+
+func (m *RevokeGrantRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeGrantRequest.Unmarshal(m, b)
+}
+func (m *RevokeGrantRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeGrantRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeGrantRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeGrantRequest.Merge(m, src)
+}
+func (m *RevokeGrantRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeGrantRequest.Size(m)
+}
+func (m *RevokeGrantRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeGrantRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeGrantRequest proto.InternalMessageInfo
+
+func (m *RevokeGrantRequest) GetGrantee() string {
+	if m != nil {
+		return m.Grantee
+	}
+	return ""
+}
+
+type RevokeGrantResponse struct {
+	// Types that are valid to be assigned to Result:
+	//	*RevokeGrantResponse_Error
+	Result               isRevokeGrantResponse_Result `protobuf_oneof:"result"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *RevokeGrantResponse) Reset()         { *m = RevokeGrantResponse{} }
+func (m *RevokeGrantResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeGrantResponse) ProtoMessage()    {}
+func (*RevokeGrantResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{27}
+}
+
+func (m *RevokeGrantResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeGrantResponse.Unmarshal(m, b)
+}
+func (m *RevokeGrantResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeGrantResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeGrantResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeGrantResponse.Merge(m, src)
+}
+func (m *RevokeGrantResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeGrantResponse.Size(m)
+}
+func (m *RevokeGrantResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeGrantResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeGrantResponse proto.InternalMessageInfo
+
+type isRevokeGrantResponse_Result interface {
+	isRevokeGrantResponse_Result()
+}
+
+type RevokeGrantResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+func (*RevokeGrantResponse_Error) isRevokeGrantResponse_Result() {}
+
+func (m *RevokeGrantResponse) GetResult() isRevokeGrantResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *RevokeGrantResponse) GetError() string {
+	if x, ok := m.GetResult().(*RevokeGrantResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*RevokeGrantResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*RevokeGrantResponse_Error)(nil),
+	}
+}
+
+type ListGrantsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGrantsRequest) Reset()         { *m = ListGrantsRequest{} }
+func (m *ListGrantsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGrantsRequest) ProtoMessage()    {}
+func (*ListGrantsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{28}
+}
+
+func (m *ListGrantsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGrantsRequest.Unmarshal(m, b)
+}
+func (m *ListGrantsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGrantsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListGrantsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGrantsRequest.Merge(m, src)
+}
+func (m *ListGrantsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListGrantsRequest.Size(m)
+}
+func (m *ListGrantsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGrantsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGrantsRequest proto.InternalMessageInfo
+
+type ListGrantsResponse struct {
+	Grants               []*Grant `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListGrantsResponse) Reset()         { *m = ListGrantsResponse{} }
+func (m *ListGrantsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGrantsResponse) ProtoMessage()    {}
+func (*ListGrantsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{29}
+}
+
+func (m *ListGrantsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListGrantsResponse.Unmarshal(m, b)
+}
+func (m *ListGrantsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListGrantsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListGrantsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGrantsResponse.Merge(m, src)
+}
+func (m *ListGrantsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListGrantsResponse.Size(m)
+}
+func (m *ListGrantsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGrantsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListGrantsResponse proto.InternalMessageInfo
+
+func (m *ListGrantsResponse) GetGrants() []*Grant {
+	if m != nil {
+		return m.Grants
+	}
+	return nil
+}
+
+// GetFreeBusyRequest queries busy time of several calendars in [start_time, end_time) period,
+// free-busy role is required for calendars of other owners
+type GetFreeBusyRequest struct {
+	Owners               []string             `protobuf:"bytes,1,rep,name=owners,proto3" json:"owners,omitempty"`
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetFreeBusyRequest) Reset()         { *m = GetFreeBusyRequest{} }
+func (m *GetFreeBusyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFreeBusyRequest) ProtoMessage()    {}
+func (*GetFreeBusyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{30}
+}
+
+func (m *GetFreeBusyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFreeBusyRequest.Unmarshal(m, b)
+}
+func (m *GetFreeBusyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFreeBusyRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFreeBusyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFreeBusyRequest.Merge(m, src)
+}
+func (m *GetFreeBusyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFreeBusyRequest.Size(m)
+}
+func (m *GetFreeBusyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFreeBusyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFreeBusyRequest proto.InternalMessageInfo
+
+func (m *GetFreeBusyRequest) GetOwners() []string {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
+func (m *GetFreeBusyRequest) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *GetFreeBusyRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+// BusyInterval is time occupied by events, titles and texts of events are not exposed
+type BusyInterval struct {
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BusyInterval) Reset()         { *m = BusyInterval{} }
+func (m *BusyInterval) String() string { return proto.CompactTextString(m) }
+func (*BusyInterval) ProtoMessage()    {}
+func (*BusyInterval) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{31}
+}
+
+func (m *BusyInterval) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BusyInterval.Unmarshal(m, b)
+}
+func (m *BusyInterval) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BusyInterval.Marshal(b, m, deterministic)
+}
+func (m *BusyInterval) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BusyInterval.Merge(m, src)
+}
+func (m *BusyInterval) XXX_Size() int {
+	return xxx_messageInfo_BusyInterval.Size(m)
+}
+func (m *BusyInterval) XXX_DiscardUnknown() {
+	xxx_messageInfo_BusyInterval.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BusyInterval proto.InternalMessageInfo
+
+func (m *BusyInterval) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *BusyInterval) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+// FreeBusy contains merged busy intervals of owner's calendar
+type FreeBusy struct {
+	Owner                string          `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Busy                 []*BusyInterval `protobuf:"bytes,2,rep,name=busy,proto3" json:"busy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FreeBusy) Reset()         { *m = FreeBusy{} }
+func (m *FreeBusy) String() string { return proto.CompactTextString(m) }
+func (*FreeBusy) ProtoMessage()    {}
+func (*FreeBusy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{32}
+}
+
+func (m *FreeBusy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FreeBusy.Unmarshal(m, b)
+}
+func (m *FreeBusy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FreeBusy.Marshal(b, m, deterministic)
+}
+func (m *FreeBusy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FreeBusy.Merge(m, src)
+}
+func (m *FreeBusy) XXX_Size() int {
+	return xxx_messageInfo_FreeBusy.Size(m)
+}
+func (m *FreeBusy) XXX_DiscardUnknown() {
+	xxx_messageInfo_FreeBusy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FreeBusy proto.InternalMessageInfo
+
+func (m *FreeBusy) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *FreeBusy) GetBusy() []*BusyInterval {
+	if m != nil {
+		return m.Busy
+	}
+	return nil
+}
+
+type GetFreeBusyResponse struct {
+	Calendars            []*FreeBusy `protobuf:"bytes,1,rep,name=calendars,proto3" json:"calendars,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetFreeBusyResponse) Reset()         { *m = GetFreeBusyResponse{} }
+func (m *GetFreeBusyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetFreeBusyResponse) ProtoMessage()    {}
+func (*GetFreeBusyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{33}
+}
+
+func (m *GetFreeBusyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFreeBusyResponse.Unmarshal(m, b)
+}
+func (m *GetFreeBusyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFreeBusyResponse.Marshal(b, m, deterministic)
+}
+func (m *GetFreeBusyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFreeBusyResponse.Merge(m, src)
+}
+func (m *GetFreeBusyResponse) XXX_Size() int {
+	return xxx_messageInfo_GetFreeBusyResponse.Size(m)
+}
+func (m *GetFreeBusyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFreeBusyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFreeBusyResponse proto.InternalMessageInfo
+
+func (m *GetFreeBusyResponse) GetCalendars() []*FreeBusy {
+	if m != nil {
+		return m.Calendars
+	}
+	return nil
+}
+
+// WorkingHours limits found slots to the same hours of every day, end before start means night shift
+type WorkingHours struct {
+	// start and end of working hours in HH:MM format
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   string `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	// IANA time zone of working hours, UTC if not set
+	TimeZone             string   `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkingHours) Reset()         { *m = WorkingHours{} }
+func (m *WorkingHours) String() string { return proto.CompactTextString(m) }
+func (*WorkingHours) ProtoMessage()    {}
+func (*WorkingHours) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{34}
+}
+
+func (m *WorkingHours) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkingHours.Unmarshal(m, b)
+}
+func (m *WorkingHours) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkingHours.Marshal(b, m, deterministic)
+}
+func (m *WorkingHours) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkingHours.Merge(m, src)
+}
+func (m *WorkingHours) XXX_Size() int {
+	return xxx_messageInfo_WorkingHours.Size(m)
+}
+func (m *WorkingHours) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkingHours.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkingHours proto.InternalMessageInfo
+
+func (m *WorkingHours) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *WorkingHours) GetEnd() string {
+	if m != nil {
+		return m.End
+	}
+	return ""
+}
+
+func (m *WorkingHours) GetTimeZone() string {
+	if m != nil {
+		return m.TimeZone
+	}
+	return ""
+}
+
+// FindSlotsRequest searches the earliest slots in [start_time, end_time) period, where nobody of attendees
+// has an event. Free-busy role is required for calendars of other attendees
+type FindSlotsRequest struct {
+	Attendees    []string             `protobuf:"bytes,1,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	Duration     *duration.Duration   `protobuf:"bytes,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	StartTime    *timestamp.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime      *timestamp.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	WorkingHours *WorkingHours        `protobuf:"bytes,5,opt,name=working_hours,json=workingHours,proto3" json:"working_hours,omitempty"`
+	// number of returned slots, 1 if not set
+	Count                int32    `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindSlotsRequest) Reset()         { *m = FindSlotsRequest{} }
+func (m *FindSlotsRequest) String() string { return proto.CompactTextString(m) }
+func (*FindSlotsRequest) ProtoMessage()    {}
+func (*FindSlotsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{35}
+}
+
+func (m *FindSlotsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindSlotsRequest.Unmarshal(m, b)
+}
+func (m *FindSlotsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindSlotsRequest.Marshal(b, m, deterministic)
+}
+func (m *FindSlotsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindSlotsRequest.Merge(m, src)
+}
+func (m *FindSlotsRequest) XXX_Size() int {
+	return xxx_messageInfo_FindSlotsRequest.Size(m)
+}
+func (m *FindSlotsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindSlotsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindSlotsRequest proto.InternalMessageInfo
+
+func (m *FindSlotsRequest) GetAttendees() []string {
+	if m != nil {
+		return m.Attendees
+	}
+	return nil
+}
+
+func (m *FindSlotsRequest) GetDuration() *duration.Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *FindSlotsRequest) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *FindSlotsRequest) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *FindSlotsRequest) GetWorkingHours() *WorkingHours {
+	if m != nil {
+		return m.WorkingHours
+	}
+	return nil
+}
+
+func (m *FindSlotsRequest) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type Slot struct {
+	StartTime            *timestamp.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              *timestamp.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Slot) Reset()         { *m = Slot{} }
+func (m *Slot) String() string { return proto.CompactTextString(m) }
+func (*Slot) ProtoMessage()    {}
+func (*Slot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{36}
+}
+
+func (m *Slot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Slot.Unmarshal(m, b)
+}
+func (m *Slot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Slot.Marshal(b, m, deterministic)
+}
+func (m *Slot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Slot.Merge(m, src)
+}
+func (m *Slot) XXX_Size() int {
+	return xxx_messageInfo_Slot.Size(m)
+}
+func (m *Slot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Slot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Slot proto.InternalMessageInfo
+
+func (m *Slot) GetStartTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *Slot) GetEndTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+type FindSlotsResponse struct {
+	Slots                []*Slot  `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindSlotsResponse) Reset()         { *m = FindSlotsResponse{} }
+func (m *FindSlotsResponse) String() string { return proto.CompactTextString(m) }
+func (*FindSlotsResponse) ProtoMessage()    {}
+func (*FindSlotsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{37}
+}
+
+func (m *FindSlotsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FindSlotsResponse.Unmarshal(m, b)
+}
+func (m *FindSlotsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FindSlotsResponse.Marshal(b, m, deterministic)
+}
+func (m *FindSlotsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindSlotsResponse.Merge(m, src)
+}
+func (m *FindSlotsResponse) XXX_Size() int {
+	return xxx_messageInfo_FindSlotsResponse.Size(m)
+}
+func (m *FindSlotsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindSlotsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindSlotsResponse proto.InternalMessageInfo
+
+func (m *FindSlotsResponse) GetSlots() []*Slot {
+	if m != nil {
+		return m.Slots
+	}
+	return nil
+}
+
+type Attendee struct {
+	Name                 string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Status               AttendeeStatus `protobuf:"varint,2,opt,name=status,proto3,enum=AttendeeStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Attendee) Reset()         { *m = Attendee{} }
+func (m *Attendee) String() string { return proto.CompactTextString(m) }
+func (*Attendee) ProtoMessage()    {}
+func (*Attendee) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{38}
+}
+
+func (m *Attendee) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Attendee.Unmarshal(m, b)
+}
+func (m *Attendee) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Attendee.Marshal(b, m, deterministic)
+}
+func (m *Attendee) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Attendee.Merge(m, src)
+}
+func (m *Attendee) XXX_Size() int {
+	return xxx_messageInfo_Attendee.Size(m)
+}
+func (m *Attendee) XXX_DiscardUnknown() {
+	xxx_messageInfo_Attendee.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Attendee proto.InternalMessageInfo
+
+func (m *Attendee) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Attendee) GetStatus() AttendeeStatus {
+	if m != nil {
+		return m.Status
+	}
+	return AttendeeStatus_NEEDS_ACTION
+}
+
+// InviteAttendeesRequest invites users to event, attendees of occurrence are invited to the whole series.
+// Write role is required for events of shared calendar
+type InviteAttendeesRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Attendees            []string `protobuf:"bytes,2,rep,name=attendees,proto3" json:"attendees,omitempty"`
+	CalendarOwner        string   `protobuf:"bytes,3,opt,name=calendar_owner,json=calendarOwner,proto3" json:"calendar_owner,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InviteAttendeesRequest) Reset()         { *m = InviteAttendeesRequest{} }
+func (m *InviteAttendeesRequest) String() string { return proto.CompactTextString(m) }
+func (*InviteAttendeesRequest) ProtoMessage()    {}
+func (*InviteAttendeesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{39}
+}
+
+func (m *InviteAttendeesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InviteAttendeesRequest.Unmarshal(m, b)
+}
+func (m *InviteAttendeesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InviteAttendeesRequest.Marshal(b, m, deterministic)
+}
+func (m *InviteAttendeesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InviteAttendeesRequest.Merge(m, src)
+}
+func (m *InviteAttendeesRequest) XXX_Size() int {
+	return xxx_messageInfo_InviteAttendeesRequest.Size(m)
+}
+func (m *InviteAttendeesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InviteAttendeesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InviteAttendeesRequest proto.InternalMessageInfo
+
+func (m *InviteAttendeesRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *InviteAttendeesRequest) GetAttendees() []string {
+	if m != nil {
+		return m.Attendees
+	}
+	return nil
+}
+
+func (m *InviteAttendeesRequest) GetCalendarOwner() string {
+	if m != nil {
+		return m.CalendarOwner
+	}
+	return ""
+}
+
+type InviteAttendeesResponse struct {
+	// Types that are valid to be assigned to Result:
+	//	*InviteAttendeesResponse_Event
+	//	*InviteAttendeesResponse_Error
+	Result               isInviteAttendeesResponse_Result `protobuf_oneof:"result"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *InviteAttendeesResponse) Reset()         { *m = InviteAttendeesResponse{} }
+func (m *InviteAttendeesResponse) String() string { return proto.CompactTextString(m) }
+func (*InviteAttendeesResponse) ProtoMessage()    {}
+func (*InviteAttendeesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{40}
+}
+
+func (m *InviteAttendeesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InviteAttendeesResponse.Unmarshal(m, b)
+}
+func (m *InviteAttendeesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InviteAttendeesResponse.Marshal(b, m, deterministic)
+}
+func (m *InviteAttendeesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InviteAttendeesResponse.Merge(m, src)
+}
+func (m *InviteAttendeesResponse) XXX_Size() int {
+	return xxx_messageInfo_InviteAttendeesResponse.Size(m)
+}
+func (m *InviteAttendeesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InviteAttendeesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InviteAttendeesResponse proto.InternalMessageInfo
+
+type isInviteAttendeesResponse_Result interface {
+	isInviteAttendeesResponse_Result()
+}
+
+type InviteAttendeesResponse_Event struct {
+	Event *Event `protobuf:"bytes,1,opt,name=event,proto3,oneof"`
+}
+
+type InviteAttendeesResponse_Error struct {
+	Error string `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*InviteAttendeesResponse_Event) isInviteAttendeesResponse_Result() {}
+
+func (*InviteAttendeesResponse_Error) isInviteAttendeesResponse_Result() {}
+
+func (m *InviteAttendeesResponse) GetResult() isInviteAttendeesResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *InviteAttendeesResponse) GetEvent() *Event {
+	if x, ok := m.GetResult().(*InviteAttendeesResponse_Event); ok {
+		return x.Event
+	}
+	return nil
+}
+
+func (m *InviteAttendeesResponse) GetError() string {
+	if x, ok := m.GetResult().(*InviteAttendeesResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*InviteAttendeesResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*InviteAttendeesResponse_Event)(nil),
+		(*InviteAttendeesResponse_Error)(nil),
+	}
+}
+
+// RespondToInvitationRequest sets status of the user in event, which the user is invited to
+type RespondToInvitationRequest struct {
+	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status               AttendeeStatus `protobuf:"varint,2,opt,name=status,proto3,enum=AttendeeStatus" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RespondToInvitationRequest) Reset()         { *m = RespondToInvitationRequest{} }
+func (m *RespondToInvitationRequest) String() string { return proto.CompactTextString(m) }
+func (*RespondToInvitationRequest) ProtoMessage()    {}
+func (*RespondToInvitationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{41}
+}
+
+func (m *RespondToInvitationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespondToInvitationRequest.Unmarshal(m, b)
+}
+func (m *RespondToInvitationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespondToInvitationRequest.Marshal(b, m, deterministic)
+}
+func (m *RespondToInvitationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespondToInvitationRequest.Merge(m, src)
+}
+func (m *RespondToInvitationRequest) XXX_Size() int {
+	return xxx_messageInfo_RespondToInvitationRequest.Size(m)
+}
+func (m *RespondToInvitationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespondToInvitationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespondToInvitationRequest proto.InternalMessageInfo
+
+func (m *RespondToInvitationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RespondToInvitationRequest) GetStatus() AttendeeStatus {
+	if m != nil {
+		return m.Status
+	}
+	return AttendeeStatus_NEEDS_ACTION
+}
+
+type RespondToInvitationResponse struct {
+	// Types that are valid to be assigned to Result:
+	//	*RespondToInvitationResponse_Error
+	Result               isRespondToInvitationResponse_Result `protobuf_oneof:"result"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *RespondToInvitationResponse) Reset()         { *m = RespondToInvitationResponse{} }
+func (m *RespondToInvitationResponse) String() string { return proto.CompactTextString(m) }
+func (*RespondToInvitationResponse) ProtoMessage()    {}
+func (*RespondToInvitationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1b40cafcd4234784, []int{42}
+}
+
+func (m *RespondToInvitationResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespondToInvitationResponse.Unmarshal(m, b)
+}
+func (m *RespondToInvitationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespondToInvitationResponse.Marshal(b, m, deterministic)
+}
+func (m *RespondToInvitationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespondToInvitationResponse.Merge(m, src)
+}
+func (m *RespondToInvitationResponse) XXX_Size() int {
+	return xxx_messageInfo_RespondToInvitationResponse.Size(m)
+}
+func (m *RespondToInvitationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespondToInvitationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespondToInvitationResponse proto.InternalMessageInfo
+
+type isRespondToInvitationResponse_Result interface {
+	isRespondToInvitationResponse_Result()
+}
+
+type RespondToInvitationResponse_Error struct {
+	Error string `protobuf:"bytes,1,opt,name=error,proto3,oneof"`
+}
+
+func (*RespondToInvitationResponse_Error) isRespondToInvitationResponse_Result() {}
+
+func (m *RespondToInvitationResponse) GetResult() isRespondToInvitationResponse_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *RespondToInvitationResponse) GetError() string {
+	if x, ok := m.GetResult().(*RespondToInvitationResponse_Error); ok {
+		return x.Error
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*RespondToInvitationResponse) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*RespondToInvitationResponse_Error)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterEnum("Scope", Scope_name, Scope_value)
+	proto.RegisterEnum("Transparency", Transparency_name, Transparency_value)
+	proto.RegisterEnum("ImportStatus", ImportStatus_name, ImportStatus_value)
+	proto.RegisterEnum("Role", Role_name, Role_value)
+	proto.RegisterEnum("AttendeeStatus", AttendeeStatus_name, AttendeeStatus_value)
+	proto.RegisterType((*Event)(nil), "Event")
+	proto.RegisterType((*CreateEventRequest)(nil), "CreateEventRequest")
+	proto.RegisterType((*CreateEventResponse)(nil), "CreateEventResponse")
+	proto.RegisterType((*UpdateEventRequest)(nil), "UpdateEventRequest")
+	proto.RegisterType((*UpdateEventResponse)(nil), "UpdateEventResponse")
+	proto.RegisterType((*DeleteEventRequest)(nil), "DeleteEventRequest")
+	proto.RegisterType((*GetEventRequest)(nil), "GetEventRequest")
+	proto.RegisterType((*GetEventResponse)(nil), "GetEventResponse")
+	proto.RegisterType((*DeleteEventResponse)(nil), "DeleteEventResponse")
+	proto.RegisterType((*ListEventsRequest)(nil), "ListEventsRequest")
+	proto.RegisterType((*ListEventsResponse)(nil), "ListEventsResponse")
+	proto.RegisterType((*ExportEventsRequest)(nil), "ExportEventsRequest")
+	proto.RegisterType((*ExportEventsResponse)(nil), "ExportEventsResponse")
+	proto.RegisterType((*ImportEventsRequest)(nil), "ImportEventsRequest")
+	proto.RegisterType((*ImportResult)(nil), "ImportResult")
+	proto.RegisterType((*ImportEventsResponse)(nil), "ImportEventsResponse")
+	proto.RegisterType((*FeedToken)(nil), "FeedToken")
+	proto.RegisterType((*CreateFeedTokenRequest)(nil), "CreateFeedTokenRequest")
+	proto.RegisterType((*CreateFeedTokenResponse)(nil), "CreateFeedTokenResponse")
+	proto.RegisterType((*RevokeFeedTokenRequest)(nil), "RevokeFeedTokenRequest")
+	proto.RegisterType((*RevokeFeedTokenResponse)(nil), "RevokeFeedTokenResponse")
+	proto.RegisterType((*ListFeedTokensRequest)(nil), "ListFeedTokensRequest")
+	proto.RegisterType((*ListFeedTokensResponse)(nil), "ListFeedTokensResponse")
+	proto.RegisterType((*Grant)(nil), "Grant")
+	proto.RegisterType((*CreateGrantRequest)(nil), "CreateGrantRequest")
+	proto.RegisterType((*CreateGrantResponse)(nil), "CreateGrantResponse")
+	proto.RegisterType((*RevokeGrantRequest)(nil), "RevokeGrantRequest")
+	proto.RegisterType((*RevokeGrantResponse)(nil), "RevokeGrantResponse")
+	proto.RegisterType((*ListGrantsRequest)(nil), "ListGrantsRequest")
+	proto.RegisterType((*ListGrantsResponse)(nil), "ListGrantsResponse")
+	proto.RegisterType((*GetFreeBusyRequest)(nil), "GetFreeBusyRequest")
+	proto.RegisterType((*BusyInterval)(nil), "BusyInterval")
+	proto.RegisterType((*FreeBusy)(nil), "FreeBusy")
+	proto.RegisterType((*GetFreeBusyResponse)(nil), "GetFreeBusyResponse")
+	proto.RegisterType((*WorkingHours)(nil), "WorkingHours")
+	proto.RegisterType((*FindSlotsRequest)(nil), "FindSlotsRequest")
+	proto.RegisterType((*Slot)(nil), "Slot")
+	proto.RegisterType((*FindSlotsResponse)(nil), "FindSlotsResponse")
+	proto.RegisterType((*Attendee)(nil), "Attendee")
+	proto.RegisterType((*InviteAttendeesRequest)(nil), "InviteAttendeesRequest")
+	proto.RegisterType((*InviteAttendeesResponse)(nil), "InviteAttendeesResponse")
+	proto.RegisterType((*RespondToInvitationRequest)(nil), "RespondToInvitationRequest")
+	proto.RegisterType((*RespondToInvitationResponse)(nil), "RespondToInvitationResponse")
+}
+
+func init() { proto.RegisterFile("api/api.proto", fileDescriptor_1b40cafcd4234784) }
+
+var fileDescriptor_1b40cafcd4234784 = []byte{
+	// 1897 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x59, 0xcd, 0x72, 0xdb, 0xc8,
+	0x11, 0x26, 0xf8, 0xcf, 0x26, 0x29, 0x53, 0x43, 0x9a, 0x84, 0x21, 0xd7, 0x46, 0x99, 0xaa, 0x8d,
+	0x15, 0x65, 0x6b, 0x1c, 0x33, 0x4e, 0x39, 0xff, 0x5b, 0x34, 0x09, 0xc9, 0xdc, 0x52, 0xe4, 0x2d,
+	0x90, 0x5e, 0x57, 0x92, 0x03, 0x0b, 0x16, 0xc6, 0x5a, 0x94, 0x29, 0x80, 0x01, 0x40, 0xd9, 0xca,
+	0x29, 0xb7, 0x3c, 0x42, 0x4e, 0xc9, 0x25, 0xd7, 0xbc, 0x40, 0xce, 0x79, 0x8d, 0x3c, 0x45, 0x8e,
+	0x39, 0xa5, 0x66, 0x06, 0xbf, 0x04, 0x28, 0x51, 0x8c, 0xaa, 0x5c, 0xf1, 0x6d, 0xa6, 0x67, 0x7a,
+	0xa6, 0xa7, 0xbb, 0xf9, 0xf5, 0xd7, 0x20, 0x34, 0xf5, 0x85, 0xf9, 0x58, 0x5f, 0x98, 0x64, 0xe1,
+	0xd8, 0x9e, 0xad, 0x7c, 0x76, 0x6e, 0xdb, 0xe7, 0x73, 0xfa, 0x98, 0xcf, 0xde, 0x2c, 0xdf, 0x3e,
+	0x36, 0x96, 0x8e, 0xee, 0x99, 0xb6, 0xe5, 0xaf, 0xef, 0xad, 0xae, 0xd3, 0x8b, 0x85, 0x77, 0xe5,
+	0x2f, 0x7e, 0x67, 0x75, 0xd1, 0x33, 0x2f, 0xa8, 0xeb, 0xe9, 0x17, 0x0b, 0xb1, 0x01, 0xff, 0xa5,
+	0x08, 0x25, 0xf5, 0x92, 0x5a, 0x1e, 0xda, 0x81, 0xbc, 0x69, 0xc8, 0xd2, 0xbe, 0x74, 0x50, 0xd3,
+	0xf2, 0xa6, 0x81, 0x3a, 0x50, 0xf2, 0x4c, 0x6f, 0x4e, 0xe5, 0x3c, 0x17, 0x89, 0x09, 0x42, 0x50,
+	0xf4, 0xe8, 0x07, 0x4f, 0x2e, 0x70, 0x21, 0x1f, 0xa3, 0x9f, 0x02, 0xb8, 0x9e, 0xee, 0x78, 0x33,
+	0x76, 0xb8, 0x5c, 0xdc, 0x97, 0x0e, 0xea, 0x7d, 0x85, 0x88, 0x9b, 0x49, 0x70, 0x33, 0x99, 0x06,
+	0x37, 0x6b, 0x35, 0xbe, 0x9b, 0xcd, 0xd1, 0x8f, 0xa1, 0x4a, 0x2d, 0x43, 0x28, 0x96, 0x6e, 0x54,
+	0xac, 0x50, 0xcb, 0xe0, 0x6a, 0x9f, 0x01, 0x38, 0xf4, 0x6c, 0xe9, 0x38, 0xd4, 0x3a, 0xa3, 0x72,
+	0x99, 0xdb, 0x12, 0x93, 0xa0, 0x3d, 0xa8, 0xb9, 0xd4, 0x31, 0xa9, 0x3b, 0x33, 0x0d, 0xb9, 0xc2,
+	0x97, 0xab, 0x42, 0x30, 0x36, 0xd0, 0x57, 0xd0, 0xb6, 0x1d, 0xf3, 0xdc, 0xb4, 0xf4, 0xf9, 0x2c,
+	0x66, 0x77, 0xf5, 0xc6, 0xeb, 0x77, 0x03, 0xb5, 0x49, 0x68, 0x7f, 0x07, 0x4a, 0xf6, 0x7b, 0x8b,
+	0x3a, 0x72, 0x4d, 0x38, 0x89, 0x4f, 0xd0, 0x23, 0xa8, 0xe9, 0x9e, 0x47, 0x2d, 0x83, 0x52, 0x57,
+	0x86, 0xfd, 0xc2, 0x41, 0xbd, 0x5f, 0x23, 0x03, 0x5f, 0xa2, 0x45, 0x6b, 0xe8, 0x09, 0x34, 0x3c,
+	0x47, 0xb7, 0xdc, 0x85, 0xce, 0xec, 0xbe, 0x92, 0xeb, 0xfb, 0xd2, 0xc1, 0x4e, 0xbf, 0x49, 0xa6,
+	0x31, 0xa1, 0x96, 0xd8, 0x82, 0x7a, 0x50, 0xd1, 0xe7, 0xf3, 0x99, 0xa1, 0x5f, 0xc9, 0x8d, 0x7d,
+	0xe9, 0xa0, 0xaa, 0x95, 0xf5, 0xf9, 0x7c, 0xa4, 0x5f, 0xb1, 0x37, 0xb3, 0x77, 0xcc, 0xfe, 0x60,
+	0x5b, 0x54, 0x6e, 0x8a, 0x37, 0x33, 0xc1, 0x6f, 0x6d, 0x8b, 0xa2, 0x67, 0x50, 0x73, 0xe8, 0x85,
+	0x69, 0x19, 0xd4, 0x71, 0xe5, 0x1d, 0x6e, 0xd1, 0x83, 0xd4, 0x4b, 0x47, 0x7e, 0x62, 0x69, 0xd1,
+	0x5e, 0xfc, 0xef, 0x3c, 0xa0, 0xa1, 0x43, 0x75, 0x8f, 0xf2, 0x2c, 0xd1, 0xe8, 0xef, 0x97, 0xd4,
+	0xf5, 0xa2, 0xe4, 0x90, 0xb2, 0x92, 0x23, 0xbf, 0x36, 0x39, 0x0a, 0xdb, 0x26, 0x47, 0x71, 0xdb,
+	0xe4, 0x28, 0xa5, 0x92, 0x63, 0xd5, 0xe9, 0xe5, 0x5b, 0x39, 0xbd, 0xb2, 0xde, 0xe9, 0xd5, 0xeb,
+	0x9c, 0x5e, 0xbb, 0x85, 0xd3, 0x5f, 0x43, 0x3b, 0xe1, 0x73, 0x77, 0x61, 0x5b, 0x2e, 0x7b, 0x58,
+	0x89, 0x32, 0x01, 0x77, 0x7a, 0xbd, 0x5f, 0x26, 0x7c, 0xf9, 0x45, 0x4e, 0x13, 0x62, 0xd4, 0x85,
+	0x12, 0x75, 0x1c, 0xdb, 0x11, 0xfe, 0xe7, 0x72, 0x36, 0x7d, 0x5e, 0x85, 0xb2, 0x43, 0xdd, 0xe5,
+	0xdc, 0xc3, 0x7f, 0x2e, 0x02, 0x7a, 0xb5, 0x30, 0x56, 0xa3, 0xf9, 0x29, 0xfd, 0xf4, 0x1f, 0x42,
+	0xc9, 0x3d, 0xb3, 0x17, 0x94, 0x07, 0x6a, 0xa7, 0x5f, 0x26, 0x13, 0x36, 0xd3, 0x84, 0x10, 0x9d,
+	0xc2, 0x7d, 0xfb, 0x2c, 0xd8, 0x7b, 0xbb, 0x5f, 0x7f, 0x3b, 0x52, 0x8c, 0x7e, 0xff, 0x9f, 0xc3,
+	0xce, 0x99, 0x3e, 0xa7, 0x96, 0xa1, 0x3b, 0xb3, 0x38, 0x10, 0x34, 0x03, 0xe9, 0x4b, 0x26, 0x4c,
+	0xa5, 0x1c, 0xdc, 0x2a, 0xe5, 0xea, 0xeb, 0x53, 0xae, 0x71, 0x5d, 0xca, 0x35, 0x6f, 0x97, 0x72,
+	0x89, 0xc4, 0xb8, 0xb3, 0x94, 0xfb, 0x87, 0x04, 0x68, 0x44, 0xe7, 0xf4, 0x86, 0x94, 0x0b, 0xc3,
+	0x96, 0xbf, 0x55, 0xd8, 0x0a, 0x77, 0x15, 0xb6, 0x62, 0x46, 0xd8, 0xf0, 0x0b, 0xb8, 0x77, 0x4c,
+	0xbd, 0x6b, 0xed, 0x4e, 0x9f, 0x94, 0xcf, 0x3a, 0x69, 0x0a, 0xad, 0xe8, 0xa4, 0x3b, 0xf3, 0xed,
+	0x33, 0x68, 0x27, 0x5c, 0xeb, 0x1f, 0x1c, 0x2a, 0x4a, 0xeb, 0x14, 0xff, 0x2e, 0xc1, 0xee, 0x89,
+	0xe9, 0x0a, 0x83, 0xdc, 0xe0, 0x6d, 0xc9, 0x1f, 0xb3, 0xb4, 0xed, 0x8f, 0x39, 0xbf, 0xf9, 0x8f,
+	0x39, 0xed, 0xbd, 0x42, 0x96, 0xf7, 0x9e, 0x02, 0x8a, 0x5b, 0x1b, 0xfa, 0xaf, 0xcc, 0x1d, 0xe5,
+	0xca, 0xd2, 0x7e, 0x21, 0x72, 0xa0, 0xe6, 0x4b, 0xf1, 0x9f, 0x24, 0x68, 0xab, 0x1f, 0x16, 0xb6,
+	0xf3, 0xb1, 0x9f, 0x89, 0xfb, 0xd0, 0x49, 0x1a, 0xe2, 0xbf, 0x40, 0x81, 0x6a, 0xf0, 0x50, 0x6e,
+	0x47, 0x43, 0x0b, 0xe7, 0xf8, 0x09, 0xb4, 0xc7, 0x17, 0x69, 0xe3, 0xaf, 0x53, 0xf9, 0x97, 0x04,
+	0x0d, 0xa1, 0xa3, 0xf1, 0x30, 0xa3, 0x16, 0x14, 0x96, 0x61, 0xb6, 0x16, 0x96, 0x6b, 0x91, 0x7d,
+	0x0d, 0x23, 0x2a, 0x6c, 0xc3, 0x88, 0x3e, 0x87, 0xb2, 0xeb, 0xe9, 0xde, 0xd2, 0x95, 0x8b, 0x3e,
+	0xc8, 0x09, 0x93, 0x26, 0x5c, 0xa8, 0xf9, 0x8b, 0xe8, 0x01, 0x54, 0x79, 0x98, 0x18, 0x41, 0x13,
+	0x25, 0xba, 0xc2, 0xe7, 0x63, 0x03, 0x75, 0x59, 0x9a, 0xea, 0xae, 0x6d, 0xf9, 0xe8, 0xee, 0xcf,
+	0xf0, 0x97, 0xd0, 0x19, 0x5f, 0x64, 0x78, 0xf1, 0x11, 0x54, 0x44, 0x5a, 0x07, 0x89, 0xd0, 0x24,
+	0x71, 0x2f, 0x68, 0xc1, 0x2a, 0x5e, 0x40, 0xed, 0x88, 0x52, 0x63, 0x6a, 0xbf, 0xa3, 0x16, 0xf7,
+	0x04, 0x1b, 0x84, 0x0c, 0x86, 0x4b, 0x11, 0x14, 0x17, 0xba, 0xf7, 0x6d, 0xc0, 0x60, 0xd8, 0x98,
+	0xe5, 0xcb, 0x19, 0xaf, 0xc6, 0xc6, 0x4c, 0xf7, 0x36, 0x61, 0x30, 0xfe, 0xee, 0x81, 0x87, 0x65,
+	0xe8, 0x8a, 0x42, 0x1e, 0xde, 0xeb, 0xc7, 0x11, 0x8f, 0xa0, 0x97, 0x5a, 0xf1, 0xdf, 0xf3, 0x7d,
+	0x80, 0xb7, 0x94, 0x1a, 0xb3, 0xc8, 0xbc, 0x7a, 0x1f, 0x48, 0xb4, 0xaf, 0xf6, 0x36, 0x18, 0x62,
+	0x02, 0x5d, 0x8d, 0x5e, 0xda, 0xef, 0x52, 0xe7, 0x67, 0x3f, 0x0f, 0xff, 0x1c, 0x7a, 0xa9, 0xfd,
+	0x1b, 0x83, 0x46, 0x0f, 0xee, 0xb3, 0x5f, 0x61, 0xa8, 0x1a, 0xe4, 0x24, 0x56, 0xa1, 0xbb, 0xba,
+	0xe0, 0x1f, 0xfa, 0x03, 0xa8, 0x47, 0x4f, 0x09, 0xc2, 0x13, 0x7f, 0x0b, 0x84, 0x6f, 0x71, 0xf1,
+	0x7b, 0x28, 0x1d, 0x3b, 0xba, 0xe5, 0x21, 0x19, 0x2a, 0xe7, 0x6c, 0x40, 0x03, 0x7a, 0x19, 0x4c,
+	0xd1, 0x03, 0x28, 0x3a, 0xf6, 0x3c, 0x28, 0x12, 0x25, 0xa2, 0xd9, 0x73, 0xaa, 0x71, 0xd1, 0xff,
+	0x12, 0xa5, 0x71, 0x40, 0x71, 0xf9, 0xf5, 0x81, 0x07, 0xb7, 0xb1, 0x22, 0x62, 0x6e, 0xfe, 0x51,
+	0x11, 0xd4, 0x73, 0xe5, 0x10, 0xea, 0xf9, 0x32, 0x73, 0x32, 0x17, 0x6f, 0x00, 0xf5, 0x04, 0x90,
+	0x88, 0xdc, 0x66, 0x36, 0xb2, 0xd2, 0x90, 0xd8, 0xbf, 0x71, 0x94, 0xdb, 0xa2, 0x32, 0x70, 0xb5,
+	0x30, 0xc2, 0x3e, 0x00, 0x07, 0xc2, 0x08, 0x80, 0xf9, 0x75, 0x11, 0x00, 0x8b, 0xcb, 0x7c, 0x29,
+	0xfe, 0xab, 0x04, 0xe8, 0x98, 0x7a, 0x47, 0x0e, 0xa5, 0xcf, 0x97, 0xee, 0x55, 0x60, 0x74, 0x17,
+	0xca, 0x1c, 0xeb, 0x85, 0x5a, 0x4d, 0xf3, 0x67, 0x2b, 0xb8, 0x9c, 0xdf, 0x16, 0x97, 0x0b, 0x9b,
+	0xe3, 0xf2, 0x1f, 0x25, 0x68, 0x30, 0xcb, 0xc6, 0x96, 0x47, 0x9d, 0x4b, 0x7d, 0xfe, 0x11, 0x4a,
+	0xc3, 0x10, 0xaa, 0x81, 0x7f, 0xa2, 0x66, 0x52, 0x8a, 0x37, 0x93, 0xdf, 0x85, 0xe2, 0x9b, 0xa5,
+	0x7b, 0x25, 0xe7, 0x7d, 0x6c, 0x8b, 0x1b, 0xac, 0xf1, 0x25, 0xfc, 0x2b, 0x68, 0x27, 0xfc, 0x1c,
+	0x02, 0x63, 0x2d, 0xa8, 0x0d, 0x41, 0x88, 0x6a, 0x24, 0xdc, 0x15, 0xad, 0xe1, 0x09, 0x34, 0x5e,
+	0xdb, 0xce, 0x3b, 0xd3, 0x3a, 0x7f, 0x61, 0x2f, 0x1d, 0x97, 0x19, 0xc2, 0x1f, 0x16, 0x18, 0xc2,
+	0x27, 0xac, 0x9a, 0x50, 0xcb, 0xf0, 0xa1, 0x91, 0x0d, 0x93, 0x54, 0xb4, 0x90, 0xa4, 0xa2, 0xf8,
+	0x6f, 0x79, 0x68, 0x1d, 0x99, 0x96, 0x31, 0x99, 0xdb, 0x51, 0xf9, 0x7a, 0x18, 0xef, 0x8c, 0x45,
+	0xf8, 0x23, 0x01, 0xf3, 0x61, 0xf0, 0x71, 0xc3, 0xf7, 0xe1, 0x35, 0xe4, 0x35, 0xdc, 0xfa, 0x11,
+	0x5a, 0xcc, 0x3e, 0x34, 0xdf, 0x0b, 0x87, 0xcd, 0xbe, 0x65, 0x1e, 0xf3, 0x1b, 0x98, 0x26, 0x89,
+	0xbb, 0x51, 0x6b, 0xbc, 0x5f, 0x71, 0xea, 0x99, 0xbd, 0xb4, 0x3c, 0x5e, 0xd5, 0x4a, 0x9a, 0x98,
+	0xe0, 0x0f, 0x50, 0x64, 0x0e, 0xfa, 0x08, 0x99, 0xf7, 0x43, 0xd8, 0x8d, 0x85, 0xc7, 0x4f, 0x99,
+	0x3d, 0x28, 0xb9, 0x4c, 0xe0, 0xa7, 0x4b, 0x89, 0xb0, 0x65, 0x4d, 0xc8, 0xf0, 0x31, 0x54, 0x83,
+	0x8f, 0x18, 0xac, 0x50, 0x5a, 0xfa, 0x45, 0x00, 0x3b, 0x7c, 0x8c, 0x1e, 0x85, 0xa5, 0x5f, 0x20,
+	0xe3, 0xbd, 0xf0, 0x9b, 0x47, 0xb2, 0xf8, 0xe3, 0x0b, 0xe8, 0x8e, 0xad, 0x4b, 0xd3, 0xa3, 0xc1,
+	0xba, 0xbb, 0xbe, 0x2d, 0x88, 0xe5, 0x4b, 0x7e, 0x35, 0x5f, 0x36, 0xa4, 0x8f, 0xbf, 0x83, 0x5e,
+	0xea, 0xba, 0x3b, 0xe3, 0xe0, 0xaf, 0x40, 0x11, 0xa7, 0x19, 0x53, 0x9b, 0xdf, 0x22, 0xb2, 0x73,
+	0xcd, 0x7b, 0x36, 0x76, 0xd1, 0x97, 0xb0, 0x97, 0x79, 0xec, 0xa6, 0x38, 0x7e, 0xd8, 0x87, 0x12,
+	0x6f, 0xa1, 0x50, 0x05, 0x0a, 0x83, 0x93, 0x93, 0x56, 0x0e, 0x55, 0xa1, 0x38, 0x7d, 0x31, 0x9e,
+	0xb4, 0x24, 0xd4, 0x05, 0xc4, 0x46, 0xb3, 0xc1, 0xe9, 0x68, 0x76, 0xf4, 0xf2, 0xe4, 0xe4, 0xe5,
+	0xeb, 0xf1, 0xe9, 0x71, 0x2b, 0x7f, 0xf8, 0x04, 0x1a, 0xf1, 0x8e, 0x94, 0x69, 0x3c, 0x7f, 0x35,
+	0xf9, 0x8d, 0xd0, 0x3d, 0xd2, 0x54, 0xb5, 0x25, 0xa1, 0x26, 0xd4, 0xa6, 0xea, 0xe9, 0x74, 0x30,
+	0x1d, 0x7f, 0xa3, 0xb6, 0xf2, 0x87, 0x3f, 0x09, 0x28, 0xa7, 0xb0, 0x1f, 0xd5, 0xa1, 0x32, 0xd4,
+	0xd4, 0xc1, 0x54, 0x1d, 0xb5, 0x72, 0x6c, 0xef, 0xe8, 0xd5, 0xd7, 0x27, 0xe3, 0xe1, 0x60, 0xca,
+	0x54, 0x1b, 0x50, 0xd5, 0xd4, 0xaf, 0xd4, 0x21, 0x5b, 0xcc, 0x1f, 0x1e, 0x42, 0x91, 0x15, 0x4e,
+	0xb6, 0x89, 0x1d, 0x3d, 0x8b, 0x6e, 0xd2, 0xd4, 0xc1, 0xa8, 0x25, 0xa1, 0x1a, 0x94, 0x5e, 0x6b,
+	0xe3, 0x29, 0xbb, 0xe5, 0xd7, 0xb0, 0x93, 0xf4, 0x13, 0x6a, 0x41, 0xe3, 0x54, 0x55, 0x47, 0x93,
+	0xd9, 0x60, 0x38, 0x1d, 0xbf, 0x3c, 0x6d, 0xe5, 0xd8, 0xe9, 0x83, 0xe1, 0x50, 0xfd, 0x9a, 0x9d,
+	0xce, 0xef, 0x1a, 0xa9, 0xc3, 0x93, 0xf1, 0x29, 0xbb, 0x2b, 0x69, 0x74, 0xa1, 0xff, 0xcf, 0x2a,
+	0xdc, 0x1b, 0xfa, 0x29, 0x32, 0xa1, 0xce, 0xa5, 0x79, 0x46, 0xd1, 0xcf, 0xa0, 0x1e, 0xfb, 0xe6,
+	0x82, 0xda, 0x24, 0xfd, 0xd5, 0x4b, 0xe9, 0x90, 0x8c, 0xcf, 0x32, 0x38, 0xc7, 0x74, 0x63, 0x7d,
+	0x18, 0x6a, 0x93, 0x74, 0xc3, 0xab, 0x74, 0x48, 0x46, 0xab, 0x26, 0x74, 0x63, 0x8d, 0x37, 0x6a,
+	0x93, 0xf4, 0xf7, 0x19, 0xa5, 0x43, 0x32, 0x7a, 0x73, 0x9c, 0x43, 0xcf, 0x00, 0xa2, 0xbe, 0x08,
+	0x21, 0x92, 0x6a, 0xe9, 0x94, 0x36, 0x49, 0x37, 0x4e, 0x38, 0x87, 0x9e, 0x40, 0x35, 0x68, 0x47,
+	0x51, 0x8b, 0xac, 0xf4, 0xb8, 0xca, 0x2e, 0x59, 0xed, 0x55, 0x71, 0x0e, 0xfd, 0x12, 0x1a, 0xf1,
+	0x1e, 0x06, 0x75, 0x48, 0x46, 0x6f, 0xa5, 0xdc, 0x27, 0x59, 0x8d, 0x8e, 0x50, 0x8f, 0x93, 0x77,
+	0xd4, 0x21, 0x19, 0xdd, 0x8d, 0x72, 0x9f, 0x64, 0x31, 0x7c, 0x9c, 0x43, 0x47, 0x70, 0x6f, 0x85,
+	0x2e, 0xa3, 0x1e, 0xc9, 0xa6, 0xd6, 0x8a, 0x4c, 0xd6, 0x30, 0x6b, 0x71, 0xce, 0x0a, 0x01, 0x46,
+	0x3d, 0x92, 0x4d, 0xa1, 0x15, 0x99, 0xac, 0xe1, 0xca, 0x38, 0x87, 0x86, 0xb0, 0x93, 0xa4, 0xbc,
+	0xa8, 0x4b, 0x32, 0xc9, 0xb1, 0xd2, 0x23, 0xd9, 0xdc, 0x58, 0x84, 0x3e, 0x46, 0x16, 0xc3, 0x94,
+	0x8b, 0x33, 0x3c, 0xa5, 0x93, 0x14, 0xc6, 0x75, 0x63, 0xfc, 0x0e, 0xb5, 0x49, 0x9a, 0x1d, 0x2a,
+	0x1d, 0x92, 0x41, 0x01, 0xa3, 0xb4, 0xe1, 0xe2, 0x20, 0x6d, 0x12, 0x7c, 0x4f, 0x69, 0x27, 0x64,
+	0xf1, 0x4b, 0x63, 0x3c, 0x03, 0xb5, 0x49, 0x9a, 0xdd, 0x29, 0x1d, 0x92, 0x41, 0x45, 0x70, 0x0e,
+	0x3d, 0x85, 0x5a, 0x58, 0x6e, 0xd0, 0x2e, 0x59, 0x65, 0x06, 0x0a, 0x22, 0xa9, 0x6a, 0x24, 0xe2,
+	0xb5, 0x02, 0xdd, 0xa8, 0x47, 0xb2, 0x6b, 0x87, 0x22, 0x93, 0x35, 0x28, 0x8f, 0x73, 0x48, 0x83,
+	0xb6, 0x98, 0x25, 0xe0, 0x14, 0xed, 0x91, 0xf5, 0xd8, 0xad, 0x3c, 0x24, 0xd7, 0x20, 0x30, 0xce,
+	0xf5, 0xff, 0x53, 0x81, 0xdd, 0x15, 0x14, 0xf9, 0xa6, 0x8f, 0xbe, 0xd8, 0x00, 0x47, 0xfc, 0x32,
+	0x83, 0x73, 0xe8, 0x17, 0x1b, 0x20, 0x47, 0x37, 0x55, 0xcd, 0x55, 0xf6, 0x0f, 0x0f, 0xce, 0xb1,
+	0xbb, 0x6e, 0xc4, 0x8e, 0xe8, 0xae, 0xad, 0xd1, 0xe2, 0x7b, 0xd7, 0xa2, 0x45, 0x74, 0xc1, 0xa7,
+	0x01, 0x11, 0xa3, 0x5b, 0x40, 0xc4, 0xfa, 0xd0, 0xdc, 0x09, 0x40, 0x7c, 0xb1, 0x01, 0x40, 0xf8,
+	0x4d, 0x97, 0xc8, 0xa5, 0x1b, 0x21, 0x61, 0xbd, 0xc1, 0xff, 0x47, 0xa0, 0xf0, 0xf4, 0x16, 0xa0,
+	0x10, 0x65, 0xe7, 0xc9, 0x16, 0x10, 0xb0, 0xd6, 0x5d, 0x6f, 0xca, 0x5c, 0xf2, 0xa3, 0xff, 0x0e,
+	0x00, 0x2b, 0xa4, 0xfd, 0x68, 0xad, 0x1d, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CalendarServiceClient is the client API for CalendarService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CalendarServiceClient interface {
+	CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*CreateEventResponse, error)
+	DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*DeleteEventResponse, error)
+	UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*UpdateEventResponse, error)
+	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*GetEventResponse, error)
+	ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (*ExportEventsResponse, error)
+	ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (*ImportEventsResponse, error)
+	CreateFeedToken(ctx context.Context, in *CreateFeedTokenRequest, opts ...grpc.CallOption) (*CreateFeedTokenResponse, error)
+	RevokeFeedToken(ctx context.Context, in *RevokeFeedTokenRequest, opts ...grpc.CallOption) (*RevokeFeedTokenResponse, error)
+	ListFeedTokens(ctx context.Context, in *ListFeedTokensRequest, opts ...grpc.CallOption) (*ListFeedTokensResponse, error)
+	CreateGrant(ctx context.Context, in *CreateGrantRequest, opts ...grpc.CallOption) (*CreateGrantResponse, error)
+	RevokeGrant(ctx context.Context, in *RevokeGrantRequest, opts ...grpc.CallOption) (*RevokeGrantResponse, error)
+	ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error)
+	GetFreeBusy(ctx context.Context, in *GetFreeBusyRequest, opts ...grpc.CallOption) (*GetFreeBusyResponse, error)
+	FindSlots(ctx context.Context, in *FindSlotsRequest, opts ...grpc.CallOption) (*FindSlotsResponse, error)
+	InviteAttendees(ctx context.Context, in *InviteAttendeesRequest, opts ...grpc.CallOption) (*InviteAttendeesResponse, error)
+	RespondToInvitation(ctx context.Context, in *RespondToInvitationRequest, opts ...grpc.CallOption) (*RespondToInvitationResponse, error)
+}
+
+type calendarServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCalendarServiceClient(cc *grpc.ClientConn) CalendarServiceClient {
+	return &calendarServiceClient{cc}
 }
 
 func (c *calendarServiceClient) CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*CreateEventResponse, error) {
@@ -776,188 +2916,1256 @@ func (c *calendarServiceClient) CreateEvent(ctx context.Context, in *CreateEvent
 	return out, nil
 }
 
-func (c *calendarServiceClient) DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*DeleteEventResponse, error) {
-	out := new(DeleteEventResponse)
-	err := c.cc.Invoke(ctx, "/CalendarService/DeleteEvent", in, out, opts...)
+func (c *calendarServiceClient) DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*DeleteEventResponse, error) {
+	out := new(DeleteEventResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/DeleteEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*UpdateEventResponse, error) {
+	out := new(UpdateEventResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/UpdateEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
+	out := new(ListEventsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/ListEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*GetEventResponse, error) {
+	out := new(GetEventResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/GetEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (*ExportEventsResponse, error) {
+	out := new(ExportEventsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/ExportEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (*ImportEventsResponse, error) {
+	out := new(ImportEventsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/ImportEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) CreateFeedToken(ctx context.Context, in *CreateFeedTokenRequest, opts ...grpc.CallOption) (*CreateFeedTokenResponse, error) {
+	out := new(CreateFeedTokenResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/CreateFeedToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) RevokeFeedToken(ctx context.Context, in *RevokeFeedTokenRequest, opts ...grpc.CallOption) (*RevokeFeedTokenResponse, error) {
+	out := new(RevokeFeedTokenResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/RevokeFeedToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListFeedTokens(ctx context.Context, in *ListFeedTokensRequest, opts ...grpc.CallOption) (*ListFeedTokensResponse, error) {
+	out := new(ListFeedTokensResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/ListFeedTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) CreateGrant(ctx context.Context, in *CreateGrantRequest, opts ...grpc.CallOption) (*CreateGrantResponse, error) {
+	out := new(CreateGrantResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/CreateGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) RevokeGrant(ctx context.Context, in *RevokeGrantRequest, opts ...grpc.CallOption) (*RevokeGrantResponse, error) {
+	out := new(RevokeGrantResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/RevokeGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error) {
+	out := new(ListGrantsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/ListGrants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) GetFreeBusy(ctx context.Context, in *GetFreeBusyRequest, opts ...grpc.CallOption) (*GetFreeBusyResponse, error) {
+	out := new(GetFreeBusyResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/GetFreeBusy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) FindSlots(ctx context.Context, in *FindSlotsRequest, opts ...grpc.CallOption) (*FindSlotsResponse, error) {
+	out := new(FindSlotsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/FindSlots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) InviteAttendees(ctx context.Context, in *InviteAttendeesRequest, opts ...grpc.CallOption) (*InviteAttendeesResponse, error) {
+	out := new(InviteAttendeesResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/InviteAttendees", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceClient) RespondToInvitation(ctx context.Context, in *RespondToInvitationRequest, opts ...grpc.CallOption) (*RespondToInvitationResponse, error) {
+	out := new(RespondToInvitationResponse)
+	err := c.cc.Invoke(ctx, "/CalendarService/RespondToInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalendarServiceServer is the server API for CalendarService service.
+type CalendarServiceServer interface {
+	CreateEvent(context.Context, *CreateEventRequest) (*CreateEventResponse, error)
+	DeleteEvent(context.Context, *DeleteEventRequest) (*DeleteEventResponse, error)
+	UpdateEvent(context.Context, *UpdateEventRequest) (*UpdateEventResponse, error)
+	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	GetEvent(context.Context, *GetEventRequest) (*GetEventResponse, error)
+	ExportEvents(context.Context, *ExportEventsRequest) (*ExportEventsResponse, error)
+	ImportEvents(context.Context, *ImportEventsRequest) (*ImportEventsResponse, error)
+	CreateFeedToken(context.Context, *CreateFeedTokenRequest) (*CreateFeedTokenResponse, error)
+	RevokeFeedToken(context.Context, *RevokeFeedTokenRequest) (*RevokeFeedTokenResponse, error)
+	ListFeedTokens(context.Context, *ListFeedTokensRequest) (*ListFeedTokensResponse, error)
+	CreateGrant(context.Context, *CreateGrantRequest) (*CreateGrantResponse, error)
+	RevokeGrant(context.Context, *RevokeGrantRequest) (*RevokeGrantResponse, error)
+	ListGrants(context.Context, *ListGrantsRequest) (*ListGrantsResponse, error)
+	GetFreeBusy(context.Context, *GetFreeBusyRequest) (*GetFreeBusyResponse, error)
+	FindSlots(context.Context, *FindSlotsRequest) (*FindSlotsResponse, error)
+	InviteAttendees(context.Context, *InviteAttendeesRequest) (*InviteAttendeesResponse, error)
+	RespondToInvitation(context.Context, *RespondToInvitationRequest) (*RespondToInvitationResponse, error)
+}
+
+// UnimplementedCalendarServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCalendarServiceServer struct {
+}
+
+func (*UnimplementedCalendarServiceServer) CreateEvent(ctx context.Context, req *CreateEventRequest) (*CreateEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEvent not implemented")
+}
+func (*UnimplementedCalendarServiceServer) DeleteEvent(ctx context.Context, req *DeleteEventRequest) (*DeleteEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEvent not implemented")
+}
+func (*UnimplementedCalendarServiceServer) UpdateEvent(ctx context.Context, req *UpdateEventRequest) (*UpdateEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEvent not implemented")
+}
+func (*UnimplementedCalendarServiceServer) ListEvents(ctx context.Context, req *ListEventsRequest) (*ListEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
+}
+func (*UnimplementedCalendarServiceServer) GetEvent(ctx context.Context, req *GetEventRequest) (*GetEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvent not implemented")
+}
+func (*UnimplementedCalendarServiceServer) ExportEvents(ctx context.Context, req *ExportEventsRequest) (*ExportEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportEvents not implemented")
+}
+func (*UnimplementedCalendarServiceServer) ImportEvents(ctx context.Context, req *ImportEventsRequest) (*ImportEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportEvents not implemented")
+}
+func (*UnimplementedCalendarServiceServer) CreateFeedToken(ctx context.Context, req *CreateFeedTokenRequest) (*CreateFeedTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFeedToken not implemented")
+}
+func (*UnimplementedCalendarServiceServer) RevokeFeedToken(ctx context.Context, req *RevokeFeedTokenRequest) (*RevokeFeedTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeFeedToken not implemented")
+}
+func (*UnimplementedCalendarServiceServer) ListFeedTokens(ctx context.Context, req *ListFeedTokensRequest) (*ListFeedTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeedTokens not implemented")
+}
+func (*UnimplementedCalendarServiceServer) CreateGrant(ctx context.Context, req *CreateGrantRequest) (*CreateGrantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGrant not implemented")
+}
+func (*UnimplementedCalendarServiceServer) RevokeGrant(ctx context.Context, req *RevokeGrantRequest) (*RevokeGrantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeGrant not implemented")
+}
+func (*UnimplementedCalendarServiceServer) ListGrants(ctx context.Context, req *ListGrantsRequest) (*ListGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGrants not implemented")
+}
+func (*UnimplementedCalendarServiceServer) GetFreeBusy(ctx context.Context, req *GetFreeBusyRequest) (*GetFreeBusyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFreeBusy not implemented")
+}
+func (*UnimplementedCalendarServiceServer) FindSlots(ctx context.Context, req *FindSlotsRequest) (*FindSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSlots not implemented")
+}
+func (*UnimplementedCalendarServiceServer) InviteAttendees(ctx context.Context, req *InviteAttendeesRequest) (*InviteAttendeesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteAttendees not implemented")
+}
+func (*UnimplementedCalendarServiceServer) RespondToInvitation(ctx context.Context, req *RespondToInvitationRequest) (*RespondToInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondToInvitation not implemented")
+}
+
+func RegisterCalendarServiceServer(s *grpc.Server, srv CalendarServiceServer) {
+	s.RegisterService(&_CalendarService_serviceDesc, srv)
+}
+
+func _CalendarService_CreateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/CreateEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateEvent(ctx, req.(*CreateEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).DeleteEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/DeleteEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).DeleteEvent(ctx, req.(*DeleteEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).UpdateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/UpdateEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).UpdateEvent(ctx, req.(*UpdateEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/ListEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListEvents(ctx, req.(*ListEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/GetEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetEvent(ctx, req.(*GetEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ExportEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ExportEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/ExportEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ExportEvents(ctx, req.(*ExportEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ImportEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ImportEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/ImportEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ImportEvents(ctx, req.(*ImportEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_CreateFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/CreateFeedToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateFeedToken(ctx, req.(*CreateFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_RevokeFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).RevokeFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/RevokeFeedToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).RevokeFeedToken(ctx, req.(*RevokeFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListFeedTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeedTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListFeedTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/ListFeedTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListFeedTokens(ctx, req.(*ListFeedTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_CreateGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGrantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).CreateGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/CreateGrant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).CreateGrant(ctx, req.(*CreateGrantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_RevokeGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeGrantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).RevokeGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/RevokeGrant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).RevokeGrant(ctx, req.(*RevokeGrantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_ListGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).ListGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/ListGrants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).ListGrants(ctx, req.(*ListGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_GetFreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFreeBusyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).GetFreeBusy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/GetFreeBusy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).GetFreeBusy(ctx, req.(*GetFreeBusyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_FindSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).FindSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/FindSlots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).FindSlots(ctx, req.(*FindSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_InviteAttendees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteAttendeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).InviteAttendees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/InviteAttendees",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).InviteAttendees(ctx, req.(*InviteAttendeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarService_RespondToInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondToInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceServer).RespondToInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarService/RespondToInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceServer).RespondToInvitation(ctx, req.(*RespondToInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CalendarService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "CalendarService",
+	HandlerType: (*CalendarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateEvent",
+			Handler:    _CalendarService_CreateEvent_Handler,
+		},
+		{
+			MethodName: "DeleteEvent",
+			Handler:    _CalendarService_DeleteEvent_Handler,
+		},
+		{
+			MethodName: "UpdateEvent",
+			Handler:    _CalendarService_UpdateEvent_Handler,
+		},
+		{
+			MethodName: "ListEvents",
+			Handler:    _CalendarService_ListEvents_Handler,
+		},
+		{
+			MethodName: "GetEvent",
+			Handler:    _CalendarService_GetEvent_Handler,
+		},
+		{
+			MethodName: "ExportEvents",
+			Handler:    _CalendarService_ExportEvents_Handler,
+		},
+		{
+			MethodName: "ImportEvents",
+			Handler:    _CalendarService_ImportEvents_Handler,
+		},
+		{
+			MethodName: "CreateFeedToken",
+			Handler:    _CalendarService_CreateFeedToken_Handler,
+		},
+		{
+			MethodName: "RevokeFeedToken",
+			Handler:    _CalendarService_RevokeFeedToken_Handler,
+		},
+		{
+			MethodName: "ListFeedTokens",
+			Handler:    _CalendarService_ListFeedTokens_Handler,
+		},
+		{
+			MethodName: "CreateGrant",
+			Handler:    _CalendarService_CreateGrant_Handler,
+		},
+		{
+			MethodName: "RevokeGrant",
+			Handler:    _CalendarService_RevokeGrant_Handler,
+		},
+		{
+			MethodName: "ListGrants",
+			Handler:    _CalendarService_ListGrants_Handler,
+		},
+		{
+			MethodName: "GetFreeBusy",
+			Handler:    _CalendarService_GetFreeBusy_Handler,
+		},
+		{
+			MethodName: "FindSlots",
+			Handler:    _CalendarService_FindSlots_Handler,
+		},
+		{
+			MethodName: "InviteAttendees",
+			Handler:    _CalendarService_InviteAttendees_Handler,
+		},
+		{
+			MethodName: "RespondToInvitation",
+			Handler:    _CalendarService_RespondToInvitation_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/api.proto",
+}
+
+// CalendarServiceV2Client is the client API for CalendarServiceV2 service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CalendarServiceV2Client interface {
+	CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*Event, error)
+	DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*Event, error)
+	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error)
+	ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (*ExportEventsResponse, error)
+	ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (*ImportEventsResponse, error)
+	CreateFeedToken(ctx context.Context, in *CreateFeedTokenRequest, opts ...grpc.CallOption) (*CreateFeedTokenResponse, error)
+	RevokeFeedToken(ctx context.Context, in *RevokeFeedTokenRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListFeedTokens(ctx context.Context, in *ListFeedTokensRequest, opts ...grpc.CallOption) (*ListFeedTokensResponse, error)
+	CreateGrant(ctx context.Context, in *CreateGrantRequest, opts ...grpc.CallOption) (*Grant, error)
+	RevokeGrant(ctx context.Context, in *RevokeGrantRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error)
+	GetFreeBusy(ctx context.Context, in *GetFreeBusyRequest, opts ...grpc.CallOption) (*GetFreeBusyResponse, error)
+	FindSlots(ctx context.Context, in *FindSlotsRequest, opts ...grpc.CallOption) (*FindSlotsResponse, error)
+	InviteAttendees(ctx context.Context, in *InviteAttendeesRequest, opts ...grpc.CallOption) (*Event, error)
+	RespondToInvitation(ctx context.Context, in *RespondToInvitationRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type calendarServiceV2Client struct {
+	cc *grpc.ClientConn
+}
+
+func NewCalendarServiceV2Client(cc *grpc.ClientConn) CalendarServiceV2Client {
+	return &calendarServiceV2Client{cc}
+}
+
+func (c *calendarServiceV2Client) CreateEvent(ctx context.Context, in *CreateEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/CreateEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/DeleteEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/UpdateEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
+	out := new(ListEventsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/ListEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/GetEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (*ExportEventsResponse, error) {
+	out := new(ExportEventsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/ExportEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) ImportEvents(ctx context.Context, in *ImportEventsRequest, opts ...grpc.CallOption) (*ImportEventsResponse, error) {
+	out := new(ImportEventsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/ImportEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) CreateFeedToken(ctx context.Context, in *CreateFeedTokenRequest, opts ...grpc.CallOption) (*CreateFeedTokenResponse, error) {
+	out := new(CreateFeedTokenResponse)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/CreateFeedToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) RevokeFeedToken(ctx context.Context, in *RevokeFeedTokenRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/RevokeFeedToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) ListFeedTokens(ctx context.Context, in *ListFeedTokensRequest, opts ...grpc.CallOption) (*ListFeedTokensResponse, error) {
+	out := new(ListFeedTokensResponse)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/ListFeedTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) CreateGrant(ctx context.Context, in *CreateGrantRequest, opts ...grpc.CallOption) (*Grant, error) {
+	out := new(Grant)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/CreateGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) RevokeGrant(ctx context.Context, in *RevokeGrantRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/RevokeGrant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarServiceV2Client) ListGrants(ctx context.Context, in *ListGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error) {
+	out := new(ListGrantsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/ListGrants", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *calendarServiceClient) UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*UpdateEventResponse, error) {
-	out := new(UpdateEventResponse)
-	err := c.cc.Invoke(ctx, "/CalendarService/UpdateEvent", in, out, opts...)
+func (c *calendarServiceV2Client) GetFreeBusy(ctx context.Context, in *GetFreeBusyRequest, opts ...grpc.CallOption) (*GetFreeBusyResponse, error) {
+	out := new(GetFreeBusyResponse)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/GetFreeBusy", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *calendarServiceClient) ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error) {
-	out := new(ListEventsResponse)
-	err := c.cc.Invoke(ctx, "/CalendarService/ListEvents", in, out, opts...)
+func (c *calendarServiceV2Client) FindSlots(ctx context.Context, in *FindSlotsRequest, opts ...grpc.CallOption) (*FindSlotsResponse, error) {
+	out := new(FindSlotsResponse)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/FindSlots", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *calendarServiceClient) GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*GetEventResponse, error) {
-	out := new(GetEventResponse)
-	err := c.cc.Invoke(ctx, "/CalendarService/GetEvent", in, out, opts...)
+func (c *calendarServiceV2Client) InviteAttendees(ctx context.Context, in *InviteAttendeesRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/InviteAttendees", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// CalendarServiceServer is the server API for CalendarService service.
-type CalendarServiceServer interface {
-	CreateEvent(context.Context, *CreateEventRequest) (*CreateEventResponse, error)
-	DeleteEvent(context.Context, *DeleteEventRequest) (*DeleteEventResponse, error)
-	UpdateEvent(context.Context, *UpdateEventRequest) (*UpdateEventResponse, error)
+func (c *calendarServiceV2Client) RespondToInvitation(ctx context.Context, in *RespondToInvitationRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/CalendarServiceV2/RespondToInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalendarServiceV2Server is the server API for CalendarServiceV2 service.
+type CalendarServiceV2Server interface {
+	CreateEvent(context.Context, *CreateEventRequest) (*Event, error)
+	DeleteEvent(context.Context, *DeleteEventRequest) (*empty.Empty, error)
+	UpdateEvent(context.Context, *UpdateEventRequest) (*Event, error)
 	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
-	GetEvent(context.Context, *GetEventRequest) (*GetEventResponse, error)
+	GetEvent(context.Context, *GetEventRequest) (*Event, error)
+	ExportEvents(context.Context, *ExportEventsRequest) (*ExportEventsResponse, error)
+	ImportEvents(context.Context, *ImportEventsRequest) (*ImportEventsResponse, error)
+	CreateFeedToken(context.Context, *CreateFeedTokenRequest) (*CreateFeedTokenResponse, error)
+	RevokeFeedToken(context.Context, *RevokeFeedTokenRequest) (*empty.Empty, error)
+	ListFeedTokens(context.Context, *ListFeedTokensRequest) (*ListFeedTokensResponse, error)
+	CreateGrant(context.Context, *CreateGrantRequest) (*Grant, error)
+	RevokeGrant(context.Context, *RevokeGrantRequest) (*empty.Empty, error)
+	ListGrants(context.Context, *ListGrantsRequest) (*ListGrantsResponse, error)
+	GetFreeBusy(context.Context, *GetFreeBusyRequest) (*GetFreeBusyResponse, error)
+	FindSlots(context.Context, *FindSlotsRequest) (*FindSlotsResponse, error)
+	InviteAttendees(context.Context, *InviteAttendeesRequest) (*Event, error)
+	RespondToInvitation(context.Context, *RespondToInvitationRequest) (*empty.Empty, error)
 }
 
-// UnimplementedCalendarServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedCalendarServiceServer struct {
+// UnimplementedCalendarServiceV2Server can be embedded to have forward compatible implementations.
+type UnimplementedCalendarServiceV2Server struct {
 }
 
-func (*UnimplementedCalendarServiceServer) CreateEvent(ctx context.Context, req *CreateEventRequest) (*CreateEventResponse, error) {
+func (*UnimplementedCalendarServiceV2Server) CreateEvent(ctx context.Context, req *CreateEventRequest) (*Event, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateEvent not implemented")
 }
-func (*UnimplementedCalendarServiceServer) DeleteEvent(ctx context.Context, req *DeleteEventRequest) (*DeleteEventResponse, error) {
+func (*UnimplementedCalendarServiceV2Server) DeleteEvent(ctx context.Context, req *DeleteEventRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteEvent not implemented")
 }
-func (*UnimplementedCalendarServiceServer) UpdateEvent(ctx context.Context, req *UpdateEventRequest) (*UpdateEventResponse, error) {
+func (*UnimplementedCalendarServiceV2Server) UpdateEvent(ctx context.Context, req *UpdateEventRequest) (*Event, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateEvent not implemented")
 }
-func (*UnimplementedCalendarServiceServer) ListEvents(ctx context.Context, req *ListEventsRequest) (*ListEventsResponse, error) {
+func (*UnimplementedCalendarServiceV2Server) ListEvents(ctx context.Context, req *ListEventsRequest) (*ListEventsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
 }
-func (*UnimplementedCalendarServiceServer) GetEvent(ctx context.Context, req *GetEventRequest) (*GetEventResponse, error) {
+func (*UnimplementedCalendarServiceV2Server) GetEvent(ctx context.Context, req *GetEventRequest) (*Event, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEvent not implemented")
 }
+func (*UnimplementedCalendarServiceV2Server) ExportEvents(ctx context.Context, req *ExportEventsRequest) (*ExportEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportEvents not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) ImportEvents(ctx context.Context, req *ImportEventsRequest) (*ImportEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportEvents not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) CreateFeedToken(ctx context.Context, req *CreateFeedTokenRequest) (*CreateFeedTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFeedToken not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) RevokeFeedToken(ctx context.Context, req *RevokeFeedTokenRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeFeedToken not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) ListFeedTokens(ctx context.Context, req *ListFeedTokensRequest) (*ListFeedTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeedTokens not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) CreateGrant(ctx context.Context, req *CreateGrantRequest) (*Grant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGrant not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) RevokeGrant(ctx context.Context, req *RevokeGrantRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeGrant not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) ListGrants(ctx context.Context, req *ListGrantsRequest) (*ListGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGrants not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) GetFreeBusy(ctx context.Context, req *GetFreeBusyRequest) (*GetFreeBusyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFreeBusy not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) FindSlots(ctx context.Context, req *FindSlotsRequest) (*FindSlotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSlots not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) InviteAttendees(ctx context.Context, req *InviteAttendeesRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteAttendees not implemented")
+}
+func (*UnimplementedCalendarServiceV2Server) RespondToInvitation(ctx context.Context, req *RespondToInvitationRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondToInvitation not implemented")
+}
 
-func RegisterCalendarServiceServer(s *grpc.Server, srv CalendarServiceServer) {
-	s.RegisterService(&_CalendarService_serviceDesc, srv)
+func RegisterCalendarServiceV2Server(s *grpc.Server, srv CalendarServiceV2Server) {
+	s.RegisterService(&_CalendarServiceV2_serviceDesc, srv)
 }
 
-func _CalendarService_CreateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _CalendarServiceV2_CreateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateEventRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).CreateEvent(ctx, in)
+		return srv.(CalendarServiceV2Server).CreateEvent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/CalendarService/CreateEvent",
+		FullMethod: "/CalendarServiceV2/CreateEvent",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).CreateEvent(ctx, req.(*CreateEventRequest))
+		return srv.(CalendarServiceV2Server).CreateEvent(ctx, req.(*CreateEventRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _CalendarServiceV2_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteEventRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).DeleteEvent(ctx, in)
+		return srv.(CalendarServiceV2Server).DeleteEvent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/CalendarService/DeleteEvent",
+		FullMethod: "/CalendarServiceV2/DeleteEvent",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).DeleteEvent(ctx, req.(*DeleteEventRequest))
+		return srv.(CalendarServiceV2Server).DeleteEvent(ctx, req.(*DeleteEventRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _CalendarServiceV2_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateEventRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).UpdateEvent(ctx, in)
+		return srv.(CalendarServiceV2Server).UpdateEvent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/CalendarService/UpdateEvent",
+		FullMethod: "/CalendarServiceV2/UpdateEvent",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).UpdateEvent(ctx, req.(*UpdateEventRequest))
+		return srv.(CalendarServiceV2Server).UpdateEvent(ctx, req.(*UpdateEventRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _CalendarServiceV2_ListEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).ListEvents(ctx, in)
+		return srv.(CalendarServiceV2Server).ListEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/CalendarService/ListEvents",
+		FullMethod: "/CalendarServiceV2/ListEvents",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).ListEvents(ctx, req.(*ListEventsRequest))
+		return srv.(CalendarServiceV2Server).ListEvents(ctx, req.(*ListEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _CalendarService_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _CalendarServiceV2_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetEventRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalendarServiceServer).GetEvent(ctx, in)
+		return srv.(CalendarServiceV2Server).GetEvent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/CalendarService/GetEvent",
+		FullMethod: "/CalendarServiceV2/GetEvent",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalendarServiceServer).GetEvent(ctx, req.(*GetEventRequest))
+		return srv.(CalendarServiceV2Server).GetEvent(ctx, req.(*GetEventRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _CalendarService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "CalendarService",
-	HandlerType: (*CalendarServiceServer)(nil),
+func _CalendarServiceV2_ExportEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).ExportEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/ExportEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).ExportEvents(ctx, req.(*ExportEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_ImportEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).ImportEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/ImportEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).ImportEvents(ctx, req.(*ImportEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_CreateFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).CreateFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/CreateFeedToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).CreateFeedToken(ctx, req.(*CreateFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_RevokeFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeFeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).RevokeFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/RevokeFeedToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).RevokeFeedToken(ctx, req.(*RevokeFeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_ListFeedTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeedTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).ListFeedTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/ListFeedTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).ListFeedTokens(ctx, req.(*ListFeedTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_CreateGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGrantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).CreateGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/CreateGrant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).CreateGrant(ctx, req.(*CreateGrantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_RevokeGrant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeGrantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).RevokeGrant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/RevokeGrant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).RevokeGrant(ctx, req.(*RevokeGrantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_ListGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).ListGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/ListGrants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).ListGrants(ctx, req.(*ListGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_GetFreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFreeBusyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).GetFreeBusy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/GetFreeBusy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).GetFreeBusy(ctx, req.(*GetFreeBusyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_FindSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).FindSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/FindSlots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).FindSlots(ctx, req.(*FindSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_InviteAttendees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteAttendeesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).InviteAttendees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/InviteAttendees",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).InviteAttendees(ctx, req.(*InviteAttendeesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalendarServiceV2_RespondToInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondToInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServiceV2Server).RespondToInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/CalendarServiceV2/RespondToInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServiceV2Server).RespondToInvitation(ctx, req.(*RespondToInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CalendarServiceV2_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "CalendarServiceV2",
+	HandlerType: (*CalendarServiceV2Server)(nil),
 	Methods: []grpc.MethodDesc{
 		{
 			MethodName: "CreateEvent",
-			Handler:    _CalendarService_CreateEvent_Handler,
+			Handler:    _CalendarServiceV2_CreateEvent_Handler,
 		},
 		{
 			MethodName: "DeleteEvent",
-			Handler:    _CalendarService_DeleteEvent_Handler,
+			Handler:    _CalendarServiceV2_DeleteEvent_Handler,
 		},
 		{
 			MethodName: "UpdateEvent",
-			Handler:    _CalendarService_UpdateEvent_Handler,
+			Handler:    _CalendarServiceV2_UpdateEvent_Handler,
 		},
 		{
 			MethodName: "ListEvents",
-			Handler:    _CalendarService_ListEvents_Handler,
+			Handler:    _CalendarServiceV2_ListEvents_Handler,
 		},
 		{
 			MethodName: "GetEvent",
-			Handler:    _CalendarService_GetEvent_Handler,
+			Handler:    _CalendarServiceV2_GetEvent_Handler,
+		},
+		{
+			MethodName: "ExportEvents",
+			Handler:    _CalendarServiceV2_ExportEvents_Handler,
+		},
+		{
+			MethodName: "ImportEvents",
+			Handler:    _CalendarServiceV2_ImportEvents_Handler,
+		},
+		{
+			MethodName: "CreateFeedToken",
+			Handler:    _CalendarServiceV2_CreateFeedToken_Handler,
+		},
+		{
+			MethodName: "RevokeFeedToken",
+			Handler:    _CalendarServiceV2_RevokeFeedToken_Handler,
+		},
+		{
+			MethodName: "ListFeedTokens",
+			Handler:    _CalendarServiceV2_ListFeedTokens_Handler,
+		},
+		{
+			MethodName: "CreateGrant",
+			Handler:    _CalendarServiceV2_CreateGrant_Handler,
+		},
+		{
+			MethodName: "RevokeGrant",
+			Handler:    _CalendarServiceV2_RevokeGrant_Handler,
+		},
+		{
+			MethodName: "ListGrants",
+			Handler:    _CalendarServiceV2_ListGrants_Handler,
+		},
+		{
+			MethodName: "GetFreeBusy",
+			Handler:    _CalendarServiceV2_GetFreeBusy_Handler,
+		},
+		{
+			MethodName: "FindSlots",
+			Handler:    _CalendarServiceV2_FindSlots_Handler,
+		},
+		{
+			MethodName: "InviteAttendees",
+			Handler:    _CalendarServiceV2_InviteAttendees_Handler,
+		},
+		{
+			MethodName: "RespondToInvitation",
+			Handler:    _CalendarServiceV2_RespondToInvitation_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
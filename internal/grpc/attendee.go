@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+var protoAttendeeStatuses = map[api.AttendeeStatus]models.AttendeeStatus{
+	api.AttendeeStatus_NEEDS_ACTION: models.StatusNeedsAction,
+	api.AttendeeStatus_ACCEPTED:     models.StatusAccepted,
+	api.AttendeeStatus_DECLINED:     models.StatusDeclined,
+	api.AttendeeStatus_TENTATIVE:    models.StatusTentative,
+}
+
+func (cs *CalendarServer) InviteAttendees(ctx context.Context, req *api.InviteAttendeesRequest) (*api.InviteAttendeesResponse, error) {
+	apiInviteAttendeesCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiInviteAttendeesErrorCounter.Inc()
+		return nil, err
+	}
+	if owner, _, err = cs.calendarOwner(ctx, owner, req.GetCalendarOwner(), models.RoleWrite); err != nil {
+		apiInviteAttendeesErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Inviting %v to event `%s`: Owner: `%s`...", req.GetAttendees(), req.GetId(), owner)
+	event, err := cs.EventService.InviteAttendees(ctx, req.GetId(), owner, req.GetAttendees())
+	if err != nil {
+		apiInviteAttendeesErrorCounter.Inc()
+		if berr, ok := err.(errors.EventError); ok {
+			return &api.InviteAttendeesResponse{
+				Result: &api.InviteAttendeesResponse_Error{
+					Error: string(berr),
+				},
+			}, nil
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	protoEvent, err := EventToProto(event)
+	if err != nil {
+		apiInviteAttendeesErrorCounter.Inc()
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.InviteAttendeesResponse{
+		Result: &api.InviteAttendeesResponse_Event{
+			Event: protoEvent,
+		},
+	}, nil
+}
+
+func (cs *CalendarServer) RespondToInvitation(ctx context.Context, req *api.RespondToInvitationRequest) (*api.RespondToInvitationResponse, error) {
+	apiRespondToInvitationCounter.Inc()
+	attendee, err := getOwner(ctx)
+	if err != nil {
+		apiRespondToInvitationErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Responding %s to event `%s`: Attendee: `%s`...", req.GetStatus(), req.GetId(), attendee)
+	err = cs.EventService.RespondToInvitation(ctx, req.GetId(), attendee, protoAttendeeStatuses[req.GetStatus()])
+	if err != nil {
+		apiRespondToInvitationErrorCounter.Inc()
+		if berr, ok := err.(errors.EventError); ok {
+			return &api.RespondToInvitationResponse{
+				Result: &api.RespondToInvitationResponse_Error{
+					Error: string(berr),
+				},
+			}, nil
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.RespondToInvitationResponse{}, nil
+}
+
+func (cs *CalendarServerV2) InviteAttendees(ctx context.Context, req *api.InviteAttendeesRequest) (*api.Event, error) {
+	apiInviteAttendeesCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiInviteAttendeesErrorCounter.Inc()
+		return nil, err
+	}
+	if owner, _, err = cs.calendarOwner(ctx, owner, req.GetCalendarOwner(), models.RoleWrite); err != nil {
+		apiInviteAttendeesErrorCounter.Inc()
+		return nil, err
+	}
+	if err := checkId(req.GetId()); err != nil {
+		apiInviteAttendeesErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Inviting %v to event `%s`: Owner: `%s`...", req.GetAttendees(), req.GetId(), owner)
+	event, err := cs.EventService.InviteAttendees(ctx, req.GetId(), owner, req.GetAttendees())
+	if err != nil {
+		apiInviteAttendeesErrorCounter.Inc()
+		return nil, errorStatus(err, eventResourceType, req.GetId())
+	}
+	return EventToProto(event)
+}
+
+func (cs *CalendarServerV2) RespondToInvitation(ctx context.Context, req *api.RespondToInvitationRequest) (*empty.Empty, error) {
+	apiRespondToInvitationCounter.Inc()
+	attendee, err := getOwner(ctx)
+	if err != nil {
+		apiRespondToInvitationErrorCounter.Inc()
+		return nil, err
+	}
+	if err := checkId(req.GetId()); err != nil {
+		apiRespondToInvitationErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Responding %s to event `%s`: Attendee: `%s`...", req.GetStatus(), req.GetId(), attendee)
+	err = cs.EventService.RespondToInvitation(ctx, req.GetId(), attendee, protoAttendeeStatuses[req.GetStatus()])
+	if err != nil {
+		apiRespondToInvitationErrorCounter.Inc()
+		return nil, errorStatus(err, eventResourceType, req.GetId())
+	}
+	return &empty.Empty{}, nil
+}
+
+func AttendeeToProto(attendee *models.Attendee) *api.Attendee {
+	protoAttendee := &api.Attendee{
+		Name: attendee.Name,
+	}
+	for s, st := range protoAttendeeStatuses {
+		if st == attendee.Status {
+			protoAttendee.Status = s
+		}
+	}
+	return protoAttendee
+}
@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log"
+	"strings"
+)
+
+const (
+	authorizationKey = "authorization"
+	apiKeyKey        = "x-api-key"
+	bearerPrefix     = "Bearer "
+)
+
+// AuthInterceptor authenticates requests by bearer token or API key,
+// owner of verified identity replaces owner metadata. Requests with owner of client certificate are skipped
+func AuthInterceptor(a *auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := auth.OwnerFromContext(ctx); ok {
+			return handler(ctx, req)
+		}
+		owner, err := authenticate(ctx, a)
+		if err != nil {
+			log.Printf("Authentication error: %s - %s", info.FullMethod, err)
+			return nil, status.Error(codes.Unauthenticated, "Unauthenticated")
+		}
+		return handler(auth.NewContext(ctx, owner), req)
+	}
+}
+
+func authenticate(ctx context.Context, a *auth.Authenticator) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(authorizationKey); len(v) > 0 && strings.HasPrefix(v[0], bearerPrefix) {
+		return a.VerifyToken(strings.TrimPrefix(v[0], bearerPrefix))
+	}
+	if v := md.Get(apiKeyKey); len(v) > 0 {
+		return a.VerifyApiKey(v[0])
+	}
+	return "", auth.ErrUnauthenticated
+}
+
+// chainUnaryInterceptors calls interceptors in order, the last one calls handler
+func chainUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, h := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, h)
+			}
+		}
+		return next(ctx, req)
+	}
+}
@@ -0,0 +1,97 @@
+package grpc
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/Brialius/calendar/internal/web"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+func (cs *CalendarServer) CreateFeedToken(ctx context.Context, req *api.CreateFeedTokenRequest) (*api.CreateFeedTokenResponse, error) {
+	apiCreateFeedTokenCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiCreateFeedTokenErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Creating feed token: Owner: `%s`...", owner)
+	token, err := cs.EventService.CreateFeedToken(ctx, owner)
+	if err != nil {
+		apiCreateFeedTokenErrorCounter.Inc()
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	protoToken, err := FeedTokenToProto(token)
+	if err != nil {
+		apiCreateFeedTokenErrorCounter.Inc()
+		return nil, err
+	}
+	return &api.CreateFeedTokenResponse{
+		FeedToken: protoToken,
+	}, nil
+}
+
+func (cs *CalendarServer) RevokeFeedToken(ctx context.Context, req *api.RevokeFeedTokenRequest) (*api.RevokeFeedTokenResponse, error) {
+	apiRevokeFeedTokenCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiRevokeFeedTokenErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Revoking feed token: Owner: `%s`...", owner)
+	err = cs.EventService.RevokeFeedToken(ctx, req.GetToken(), owner)
+	if err != nil {
+		apiRevokeFeedTokenErrorCounter.Inc()
+		if berr, ok := err.(errors.EventError); ok {
+			return &api.RevokeFeedTokenResponse{
+				Result: &api.RevokeFeedTokenResponse_Error{
+					Error: string(berr),
+				},
+			}, nil
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.RevokeFeedTokenResponse{}, nil
+}
+
+func (cs *CalendarServer) ListFeedTokens(ctx context.Context, req *api.ListFeedTokensRequest) (*api.ListFeedTokensResponse, error) {
+	apiListFeedTokensCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiListFeedTokensErrorCounter.Inc()
+		return nil, err
+	}
+	tokens, err := cs.EventService.ListFeedTokens(ctx, owner)
+	if err != nil {
+		apiListFeedTokensErrorCounter.Inc()
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &api.ListFeedTokensResponse{
+		FeedTokens: make([]*api.FeedToken, 0, len(tokens)),
+	}
+	for _, t := range tokens {
+		protoToken, err := FeedTokenToProto(t)
+		if err != nil {
+			apiListFeedTokensErrorCounter.Inc()
+			return nil, err
+		}
+		resp.FeedTokens = append(resp.FeedTokens, protoToken)
+	}
+	return resp, nil
+}
+
+func FeedTokenToProto(token *models.FeedToken) (*api.FeedToken, error) {
+	protoToken := &api.FeedToken{
+		Token: token.Token,
+		Path:  web.FeedPath(token.Token),
+	}
+	var err error
+	if protoToken.CreatedAt, err = ptypes.TimestampProto(*token.CreatedAt); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return protoToken, nil
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+// GetFreeBusy returns busy intervals of requested calendars, request fails if any calendar isn't accessible
+func (cs *CalendarServer) GetFreeBusy(ctx context.Context, req *api.GetFreeBusyRequest) (*api.GetFreeBusyResponse, error) {
+	apiGetFreeBusyCounter.Inc()
+	user, err := getOwner(ctx)
+	if err != nil {
+		apiGetFreeBusyErrorCounter.Inc()
+		return nil, err
+	}
+	if len(req.GetOwners()) == 0 {
+		apiGetFreeBusyErrorCounter.Inc()
+		return nil, invalidArgument("owners", fmt.Errorf("owners are not set"))
+	}
+	st, err := requiredTimestamp("start_time", req.GetStartTime())
+	if err != nil {
+		apiGetFreeBusyErrorCounter.Inc()
+		return nil, err
+	}
+	et, err := requiredTimestamp("end_time", req.GetEndTime())
+	if err != nil {
+		apiGetFreeBusyErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Getting free/busy of %v: User: `%s`, period: %s - %s ...", req.GetOwners(), user, st, et)
+	resp := &api.GetFreeBusyResponse{
+		Calendars: make([]*api.FreeBusy, 0, len(req.GetOwners())),
+	}
+	for _, owner := range req.GetOwners() {
+		if _, _, err := cs.calendarOwner(ctx, user, owner, models.RoleFreeBusy); err != nil {
+			apiGetFreeBusyErrorCounter.Inc()
+			return nil, err
+		}
+		intervals, err := cs.EventService.GetFreeBusy(ctx, owner, st, et)
+		if err != nil {
+			apiGetFreeBusyErrorCounter.Inc()
+			log.Printf("Error during free/busy preparing for owner: `%s` - %s", owner, err)
+			return nil, errorStatus(err, "", "")
+		}
+		fb := &api.FreeBusy{
+			Owner: owner,
+			Busy:  make([]*api.BusyInterval, 0, len(intervals)),
+		}
+		for _, in := range intervals {
+			protoInterval, err := BusyIntervalToProto(in)
+			if err != nil {
+				apiGetFreeBusyErrorCounter.Inc()
+				return nil, err
+			}
+			fb.Busy = append(fb.Busy, protoInterval)
+		}
+		resp.Calendars = append(resp.Calendars, fb)
+	}
+	return resp, nil
+}
+
+func BusyIntervalToProto(in models.Interval) (*api.BusyInterval, error) {
+	protoInterval := &api.BusyInterval{}
+	var err error
+	if protoInterval.StartTime, err = ptypes.TimestampProto(in.Start); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if protoInterval.EndTime, err = ptypes.TimestampProto(in.End); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return protoInterval, nil
+}
@@ -0,0 +1,183 @@
+package grpc
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+var protoRoles = map[api.Role]models.Role{
+	api.Role_FREE_BUSY: models.RoleFreeBusy,
+	api.Role_READ:      models.RoleRead,
+	api.Role_WRITE:     models.RoleWrite,
+}
+
+// calendarOwner returns owner of requested calendar, user's own calendar if it's not set.
+// Access to shared calendar is checked for required role, granted role is returned
+func (cs *CalendarServer) calendarOwner(ctx context.Context, user, calendarOwner string, required models.Role) (string, models.Role, error) {
+	if calendarOwner == "" {
+		calendarOwner = user
+	}
+	role, err := cs.EventService.Authorize(ctx, user, calendarOwner, required)
+	if err == errors.ErrAccessDenied {
+		log.Printf("Access of `%s` to calendar of `%s` is denied", user, calendarOwner)
+		return "", "", status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return "", "", status.Error(codes.Internal, err.Error())
+	}
+	return calendarOwner, role, nil
+}
+
+// busyEventToProto exposes only busy time of event for free-busy role, its id, owner, details, recurrence
+// and reminders are hidden
+func busyEventToProto(event *models.Event) (*api.Event, error) {
+	protoEvent := &api.Event{}
+	for t, transparency := range protoTransparencies {
+		if transparency == event.Transparency {
+			protoEvent.Transparency = t
+		}
+	}
+	var err error
+	if protoEvent.StartTime, err = ptypes.TimestampProto(*event.StartTime); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if protoEvent.EndTime, err = ptypes.TimestampProto(*event.EndTime); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return protoEvent, nil
+}
+
+func (cs *CalendarServer) CreateGrant(ctx context.Context, req *api.CreateGrantRequest) (*api.CreateGrantResponse, error) {
+	apiCreateGrantCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiCreateGrantErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Granting %s to `%s`: Owner: `%s`...", req.GetRole(), req.GetGrantee(), owner)
+	grant, err := cs.EventService.GrantAccess(ctx, owner, req.GetGrantee(), protoRoles[req.GetRole()])
+	if err != nil {
+		apiCreateGrantErrorCounter.Inc()
+		if berr, ok := err.(errors.EventError); ok {
+			return &api.CreateGrantResponse{
+				Result: &api.CreateGrantResponse_Error{
+					Error: string(berr),
+				},
+			}, nil
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	protoGrant, err := GrantToProto(grant)
+	if err != nil {
+		apiCreateGrantErrorCounter.Inc()
+		return nil, err
+	}
+	return &api.CreateGrantResponse{
+		Result: &api.CreateGrantResponse_Grant{
+			Grant: protoGrant,
+		},
+	}, nil
+}
+
+func (cs *CalendarServer) RevokeGrant(ctx context.Context, req *api.RevokeGrantRequest) (*api.RevokeGrantResponse, error) {
+	apiRevokeGrantCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiRevokeGrantErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Revoking grant of `%s`: Owner: `%s`...", req.GetGrantee(), owner)
+	err = cs.EventService.RevokeGrant(ctx, owner, req.GetGrantee())
+	if err != nil {
+		apiRevokeGrantErrorCounter.Inc()
+		if berr, ok := err.(errors.EventError); ok {
+			return &api.RevokeGrantResponse{
+				Result: &api.RevokeGrantResponse_Error{
+					Error: string(berr),
+				},
+			}, nil
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.RevokeGrantResponse{}, nil
+}
+
+func (cs *CalendarServer) ListGrants(ctx context.Context, req *api.ListGrantsRequest) (*api.ListGrantsResponse, error) {
+	apiListGrantsCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiListGrantsErrorCounter.Inc()
+		return nil, err
+	}
+	grants, err := cs.EventService.ListGrants(ctx, owner)
+	if err != nil {
+		apiListGrantsErrorCounter.Inc()
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &api.ListGrantsResponse{
+		Grants: make([]*api.Grant, 0, len(grants)),
+	}
+	for _, g := range grants {
+		protoGrant, err := GrantToProto(g)
+		if err != nil {
+			apiListGrantsErrorCounter.Inc()
+			return nil, err
+		}
+		resp.Grants = append(resp.Grants, protoGrant)
+	}
+	return resp, nil
+}
+
+func (cs *CalendarServerV2) CreateGrant(ctx context.Context, req *api.CreateGrantRequest) (*api.Grant, error) {
+	apiCreateGrantCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiCreateGrantErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Granting %s to `%s`: Owner: `%s`...", req.GetRole(), req.GetGrantee(), owner)
+	grant, err := cs.EventService.GrantAccess(ctx, owner, req.GetGrantee(), protoRoles[req.GetRole()])
+	if err != nil {
+		apiCreateGrantErrorCounter.Inc()
+		return nil, errorStatus(err, grantResourceType, req.GetGrantee())
+	}
+	return GrantToProto(grant)
+}
+
+func (cs *CalendarServerV2) RevokeGrant(ctx context.Context, req *api.RevokeGrantRequest) (*empty.Empty, error) {
+	apiRevokeGrantCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiRevokeGrantErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Revoking grant of `%s`: Owner: `%s`...", req.GetGrantee(), owner)
+	if err := cs.EventService.RevokeGrant(ctx, owner, req.GetGrantee()); err != nil {
+		apiRevokeGrantErrorCounter.Inc()
+		return nil, errorStatus(err, grantResourceType, req.GetGrantee())
+	}
+	return &empty.Empty{}, nil
+}
+
+func GrantToProto(grant *models.Grant) (*api.Grant, error) {
+	protoGrant := &api.Grant{
+		Grantee: grant.Grantee,
+	}
+	for r, role := range protoRoles {
+		if role == grant.Role {
+			protoGrant.Role = r
+		}
+	}
+	var err error
+	if protoGrant.CreatedAt, err = ptypes.TimestampProto(*grant.CreatedAt); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return protoGrant, nil
+}
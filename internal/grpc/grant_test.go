@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/domain/services"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/Brialius/calendar/internal/memdb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/metadata"
+	"testing"
+	"time"
+)
+
+func TestCalendarServer_ListEventsFreeBusy(t *testing.T) {
+	storage, _ := memdb.NewMemEventStorage()
+	es := &services.EventService{EventStorage: storage}
+	cs := &CalendarServer{EventService: es}
+	ctx := context.Background()
+	start := time.Date(2019, 11, 4, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	if _, err := es.CreateEvent(ctx, &models.Event{
+		Owner:        "manager",
+		Title:        "interview",
+		Text:         "salary review",
+		StartTime:    &start,
+		EndTime:      &end,
+		Recurrence:   "FREQ=DAILY;COUNT=1",
+		Transparency: models.TransparencyTentative,
+		TimeZone:     "Europe/Moscow",
+		Reminders:    models.Reminders{time.Hour},
+	}); err != nil {
+		t.Fatalf("can't create event: %s", err)
+	}
+	if _, err := es.GrantAccess(ctx, "manager", "assistant", models.RoleFreeBusy); err != nil {
+		t.Fatalf("can't grant access: %s", err)
+	}
+
+	st, _ := ptypes.TimestampProto(start)
+	et, _ := ptypes.TimestampProto(end)
+	resp, err := cs.ListEvents(metadata.NewIncomingContext(ctx, metadata.Pairs("owner", "assistant")),
+		&api.ListEventsRequest{StartTime: st, EndTime: et, CalendarOwner: "manager"})
+	if err != nil {
+		t.Fatalf("can't list events: %s", err)
+	}
+	expected := &api.Event{StartTime: st, EndTime: et, Transparency: api.Transparency_TENTATIVE}
+	if len(resp.Events) != 1 || !proto.Equal(resp.Events[0], expected) {
+		t.Errorf("expected only busy time %v, got %v", expected, resp.Events)
+	}
+}
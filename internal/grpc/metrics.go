@@ -35,6 +35,78 @@ var (
 		ConstLabels: prometheus.Labels{"api": "get"},
 	})
 
+	apiExportEventsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_export_events_count",
+		Help:        "API export events",
+		ConstLabels: prometheus.Labels{"api": "export"},
+	})
+
+	apiImportEventsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_import_events_count",
+		Help:        "API import events",
+		ConstLabels: prometheus.Labels{"api": "import"},
+	})
+
+	apiCreateFeedTokenCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_create_feed_token_count",
+		Help:        "API create feed token",
+		ConstLabels: prometheus.Labels{"api": "create_feed_token"},
+	})
+
+	apiRevokeFeedTokenCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_revoke_feed_token_count",
+		Help:        "API revoke feed token",
+		ConstLabels: prometheus.Labels{"api": "revoke_feed_token"},
+	})
+
+	apiListFeedTokensCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_list_feed_tokens_count",
+		Help:        "API list feed tokens",
+		ConstLabels: prometheus.Labels{"api": "list_feed_tokens"},
+	})
+
+	apiCreateGrantCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_create_grant_count",
+		Help:        "API create grant",
+		ConstLabels: prometheus.Labels{"api": "create_grant"},
+	})
+
+	apiRevokeGrantCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_revoke_grant_count",
+		Help:        "API revoke grant",
+		ConstLabels: prometheus.Labels{"api": "revoke_grant"},
+	})
+
+	apiListGrantsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_list_grants_count",
+		Help:        "API list grants",
+		ConstLabels: prometheus.Labels{"api": "list_grants"},
+	})
+
+	apiGetFreeBusyCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_get_free_busy_count",
+		Help:        "API get free busy",
+		ConstLabels: prometheus.Labels{"api": "get_free_busy"},
+	})
+
+	apiFindSlotsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_find_slots_count",
+		Help:        "API find slots",
+		ConstLabels: prometheus.Labels{"api": "find_slots"},
+	})
+
+	apiInviteAttendeesCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_invite_attendees_count",
+		Help:        "API invite attendees",
+		ConstLabels: prometheus.Labels{"api": "invite_attendees"},
+	})
+
+	apiRespondToInvitationCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_respond_to_invitation_count",
+		Help:        "API respond to invitation",
+		ConstLabels: prometheus.Labels{"api": "respond_to_invitation"},
+	})
+
 	apiCreateEventErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
 		Name:        "api_create_event_error_count",
 		Help:        "API create event error",
@@ -64,6 +136,78 @@ var (
 		Help:        "API get event error",
 		ConstLabels: prometheus.Labels{"api": "get"},
 	})
+
+	apiExportEventsErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_export_events_error_count",
+		Help:        "API export events error",
+		ConstLabels: prometheus.Labels{"api": "export"},
+	})
+
+	apiImportEventsErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_import_events_error_count",
+		Help:        "API import events error",
+		ConstLabels: prometheus.Labels{"api": "import"},
+	})
+
+	apiCreateFeedTokenErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_create_feed_token_error_count",
+		Help:        "API create feed token error",
+		ConstLabels: prometheus.Labels{"api": "create_feed_token"},
+	})
+
+	apiRevokeFeedTokenErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_revoke_feed_token_error_count",
+		Help:        "API revoke feed token error",
+		ConstLabels: prometheus.Labels{"api": "revoke_feed_token"},
+	})
+
+	apiListFeedTokensErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_list_feed_tokens_error_count",
+		Help:        "API list feed tokens error",
+		ConstLabels: prometheus.Labels{"api": "list_feed_tokens"},
+	})
+
+	apiCreateGrantErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_create_grant_error_count",
+		Help:        "API create grant error",
+		ConstLabels: prometheus.Labels{"api": "create_grant"},
+	})
+
+	apiRevokeGrantErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_revoke_grant_error_count",
+		Help:        "API revoke grant error",
+		ConstLabels: prometheus.Labels{"api": "revoke_grant"},
+	})
+
+	apiListGrantsErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_list_grants_error_count",
+		Help:        "API list grants error",
+		ConstLabels: prometheus.Labels{"api": "list_grants"},
+	})
+
+	apiGetFreeBusyErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_get_free_busy_error_count",
+		Help:        "API get free busy error",
+		ConstLabels: prometheus.Labels{"api": "get_free_busy"},
+	})
+
+	apiFindSlotsErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_find_slots_error_count",
+		Help:        "API find slots error",
+		ConstLabels: prometheus.Labels{"api": "find_slots"},
+	})
+
+	apiInviteAttendeesErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_invite_attendees_error_count",
+		Help:        "API invite attendees error",
+		ConstLabels: prometheus.Labels{"api": "invite_attendees"},
+	})
+
+	apiRespondToInvitationErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "api_respond_to_invitation_error_count",
+		Help:        "API respond to invitation error",
+		ConstLabels: prometheus.Labels{"api": "respond_to_invitation"},
+	})
 )
 
 func init() {
@@ -72,9 +216,33 @@ func init() {
 	prometheus.MustRegister(apiDeleteEventCounter)
 	prometheus.MustRegister(apiUpdateEventCounter)
 	prometheus.MustRegister(apiListEventsCounter)
+	prometheus.MustRegister(apiExportEventsCounter)
+	prometheus.MustRegister(apiImportEventsCounter)
+	prometheus.MustRegister(apiCreateFeedTokenCounter)
+	prometheus.MustRegister(apiRevokeFeedTokenCounter)
+	prometheus.MustRegister(apiListFeedTokensCounter)
+	prometheus.MustRegister(apiCreateGrantCounter)
+	prometheus.MustRegister(apiRevokeGrantCounter)
+	prometheus.MustRegister(apiListGrantsCounter)
+	prometheus.MustRegister(apiGetFreeBusyCounter)
+	prometheus.MustRegister(apiFindSlotsCounter)
+	prometheus.MustRegister(apiInviteAttendeesCounter)
+	prometheus.MustRegister(apiRespondToInvitationCounter)
 	prometheus.MustRegister(apiCreateEventErrorCounter)
 	prometheus.MustRegister(apiGetEventErrorCounter)
 	prometheus.MustRegister(apiDeleteEventErrorCounter)
 	prometheus.MustRegister(apiUpdateEventErrorCounter)
 	prometheus.MustRegister(apiListEventsErrorCounter)
+	prometheus.MustRegister(apiExportEventsErrorCounter)
+	prometheus.MustRegister(apiImportEventsErrorCounter)
+	prometheus.MustRegister(apiCreateFeedTokenErrorCounter)
+	prometheus.MustRegister(apiRevokeFeedTokenErrorCounter)
+	prometheus.MustRegister(apiListFeedTokensErrorCounter)
+	prometheus.MustRegister(apiCreateGrantErrorCounter)
+	prometheus.MustRegister(apiRevokeGrantErrorCounter)
+	prometheus.MustRegister(apiListGrantsErrorCounter)
+	prometheus.MustRegister(apiGetFreeBusyErrorCounter)
+	prometheus.MustRegister(apiFindSlotsErrorCounter)
+	prometheus.MustRegister(apiInviteAttendeesErrorCounter)
+	prometheus.MustRegister(apiRespondToInvitationErrorCounter)
 }
@@ -1,16 +1,23 @@
 package grpc
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"github.com/Brialius/calendar/internal/auth"
 	"github.com/Brialius/calendar/internal/config"
 	"github.com/Brialius/calendar/internal/domain/errors"
 	"github.com/Brialius/calendar/internal/domain/models"
 	"github.com/Brialius/calendar/internal/domain/services"
 	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/Brialius/calendar/internal/ical"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"log"
@@ -23,6 +30,12 @@ import (
 
 type CalendarServer struct {
 	EventService *services.EventService
+	// Authenticator verifies credentials of requests, owner metadata is trusted if it's nil
+	Authenticator *auth.Authenticator
+	// Tls is used by listener if it's set
+	Tls *tls.Config
+	// CertOwner takes owner from client certificate, it requires mutual TLS
+	CertOwner bool
 }
 
 // implements CalendarServiceServer
@@ -46,12 +59,23 @@ func (cs *CalendarServer) CreateEvent(ctx context.Context, req *api.CreateEventR
 		apiCreateEventErrorCounter.Inc()
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	reminders, err := RemindersFromProto(req.GetReminders())
+	if err != nil {
+		log.Printf("reminders are incorrect: %s", err)
+		apiCreateEventErrorCounter.Inc()
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	event, err := cs.EventService.CreateEvent(ctx, &models.Event{
-		Owner:     owner,
-		Title:     req.GetTitle(),
-		Text:      req.GetText(),
-		StartTime: &st,
-		EndTime:   &et,
+		Owner:        owner,
+		Title:        req.GetTitle(),
+		Text:         req.GetText(),
+		StartTime:    &st,
+		EndTime:      &et,
+		Recurrence:   req.GetRecurrence(),
+		Transparency: protoTransparencies[req.GetTransparency()],
+		AllDay:       req.GetAllDay(),
+		TimeZone:     req.GetTimeZone(),
+		Reminders:    reminders,
 	})
 	if err != nil {
 		apiCreateEventErrorCounter.Inc()
@@ -80,11 +104,32 @@ func (cs *CalendarServer) CreateEvent(ctx context.Context, req *api.CreateEventR
 	return resp, nil
 }
 
+var protoTransparencies = map[api.Transparency]models.Transparency{
+	api.Transparency_BUSY:      models.TransparencyBusy,
+	api.Transparency_FREE:      models.TransparencyFree,
+	api.Transparency_TENTATIVE: models.TransparencyTentative,
+}
+
 func EventToProto(event *models.Event) (*api.Event, error) {
 	protoEvent := &api.Event{
-		Id:    event.Id.String(),
-		Title: event.Title,
-		Text:  event.Text,
+		Id:         event.Id.String(),
+		Title:      event.Title,
+		Text:       event.Text,
+		Recurrence: event.Recurrence,
+		Owner:      event.Owner,
+		AllDay:     event.AllDay,
+		TimeZone:   event.TimeZone,
+	}
+	for t, transparency := range protoTransparencies {
+		if transparency == event.Transparency {
+			protoEvent.Transparency = t
+		}
+	}
+	for _, a := range event.Attendees {
+		protoEvent.Attendees = append(protoEvent.Attendees, AttendeeToProto(a))
+	}
+	for _, r := range event.EventReminders() {
+		protoEvent.Reminders = append(protoEvent.Reminders, ptypes.DurationProto(r))
 	}
 	var err error
 	if protoEvent.StartTime, err = ptypes.TimestampProto(*event.StartTime); err != nil {
@@ -93,13 +138,51 @@ func EventToProto(event *models.Event) (*api.Event, error) {
 	if protoEvent.EndTime, err = ptypes.TimestampProto(*event.EndTime); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if event.SeriesId != nil {
+		protoEvent.SeriesId = event.SeriesId.String()
+	}
+	if event.OriginalStartTime != nil {
+		if protoEvent.OriginalStartTime, err = ptypes.TimestampProto(*event.OriginalStartTime); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
 	return protoEvent, nil
 }
 
+// RemindersFromProto converts reminders of request, they are validated by event service
+func RemindersFromProto(durations []*duration.Duration) (models.Reminders, error) {
+	var reminders models.Reminders
+	for _, d := range durations {
+		r, err := ptypes.Duration(d)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, r)
+	}
+	return reminders, nil
+}
+
+// optionalTimestamp converts timestamp, which is not set in request, to nil
+func optionalTimestamp(ts *timestamp.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &t, nil
+}
+
 func (cs *CalendarServer) DeleteEvent(ctx context.Context, req *api.DeleteEventRequest) (*api.DeleteEventResponse, error) {
 	apiDeleteEventCounter.Inc()
 	owner, err := getOwner(ctx)
 	if err != nil {
+		apiDeleteEventErrorCounter.Inc()
+		return nil, err
+	}
+	if owner, _, err = cs.calendarOwner(ctx, owner, req.GetCalendarOwner(), models.RoleWrite); err != nil {
+		apiDeleteEventErrorCounter.Inc()
 		return nil, err
 	}
 	if req.GetId() == "" {
@@ -123,7 +206,13 @@ func (cs *CalendarServer) DeleteEvent(ctx context.Context, req *api.DeleteEventR
 		return &api.DeleteEventResponse{}, nil
 	}
 	log.Printf("Deleting event: `%s`...", req.GetId())
-	err = cs.EventService.DeleteEvent(ctx, req.GetId(), owner)
+	ot, err := optionalTimestamp(req.GetOccurrenceStartTime())
+	if err != nil {
+		apiDeleteEventErrorCounter.Inc()
+		log.Printf("occurrence start time is incorrect: %s", err)
+		return nil, err
+	}
+	err = cs.EventService.DeleteEvent(ctx, req.GetId(), owner, models.Scope(req.GetScope()), ot)
 	if err != nil {
 		apiDeleteEventErrorCounter.Inc()
 		if berr, ok := err.(errors.EventError); ok {
@@ -152,6 +241,10 @@ func (cs *CalendarServer) GetEvent(ctx context.Context, req *api.GetEventRequest
 		apiGetEventErrorCounter.Inc()
 		return nil, err
 	}
+	if owner, _, err = cs.calendarOwner(ctx, owner, req.GetCalendarOwner(), models.RoleRead); err != nil {
+		apiGetEventErrorCounter.Inc()
+		return nil, err
+	}
 	event, err := cs.EventService.GetEvent(ctx, req.GetId(), owner)
 	if err != nil {
 		apiGetEventErrorCounter.Inc()
@@ -184,6 +277,12 @@ func (cs *CalendarServer) ListEvents(ctx context.Context, req *api.ListEventsReq
 	apiListEventsCounter.Inc()
 	owner, err := getOwner(ctx)
 	if err != nil {
+		apiListEventsErrorCounter.Inc()
+		return nil, err
+	}
+	owner, role, err := cs.calendarOwner(ctx, owner, req.GetCalendarOwner(), models.RoleFreeBusy)
+	if err != nil {
+		apiListEventsErrorCounter.Inc()
 		return nil, err
 	}
 	st, err := ptypes.Timestamp(req.GetStartTime())
@@ -191,8 +290,13 @@ func (cs *CalendarServer) ListEvents(ctx context.Context, req *api.ListEventsReq
 		apiListEventsErrorCounter.Inc()
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	et, err := optionalTimestamp(req.GetEndTime())
+	if err != nil {
+		apiListEventsErrorCounter.Inc()
+		return nil, err
+	}
 	log.Printf("Getting events list: Owner: `%s`, start date: %s ...", owner, st)
-	events, err := cs.EventService.ListEvents(ctx, owner, &st)
+	events, err := cs.EventService.ListEvents(ctx, owner, &st, et)
 	if err != nil {
 		apiListEventsErrorCounter.Inc()
 		log.Printf("Error during event list preparing for user: `%s` since:  %s - %s", owner, req.GetStartTime(), err)
@@ -201,7 +305,12 @@ func (cs *CalendarServer) ListEvents(ctx context.Context, req *api.ListEventsReq
 	log.Printf("Events list received for user: `%s` since:  %s", owner, st)
 	protoEvents := make([]*api.Event, 0, len(events))
 	for _, e := range events {
-		protoEvent, err := EventToProto(e)
+		var protoEvent *api.Event
+		if role.Includes(models.RoleRead) {
+			protoEvent, err = EventToProto(e)
+		} else {
+			protoEvent, err = busyEventToProto(e)
+		}
 		if err != nil {
 			apiListEventsErrorCounter.Inc()
 			return nil, err
@@ -214,7 +323,99 @@ func (cs *CalendarServer) ListEvents(ctx context.Context, req *api.ListEventsReq
 	return resp, nil
 }
 
+func (cs *CalendarServer) ExportEvents(ctx context.Context, req *api.ExportEventsRequest) (*api.ExportEventsResponse, error) {
+	apiExportEventsCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiExportEventsErrorCounter.Inc()
+		return nil, err
+	}
+	st, err := ptypes.Timestamp(req.GetStartTime())
+	if err != nil {
+		apiExportEventsErrorCounter.Inc()
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	et, err := optionalTimestamp(req.GetEndTime())
+	if err != nil {
+		apiExportEventsErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Exporting events: Owner: `%s`, start date: %s ...", owner, st)
+	events, err := cs.EventService.ExportEvents(ctx, owner, &st, et)
+	if err != nil {
+		apiExportEventsErrorCounter.Inc()
+		log.Printf("Error during events export for user: `%s` since:  %s - %s", owner, st, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, events); err != nil {
+		apiExportEventsErrorCounter.Inc()
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	log.Printf("%d events exported for user: `%s` since:  %s", len(events), owner, st)
+	return &api.ExportEventsResponse{
+		Calendar: buf.Bytes(),
+	}, nil
+}
+
+func (cs *CalendarServer) ImportEvents(ctx context.Context, req *api.ImportEventsRequest) (*api.ImportEventsResponse, error) {
+	apiImportEventsCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiImportEventsErrorCounter.Inc()
+		return nil, err
+	}
+	events, rejected, err := ical.Decode(bytes.NewReader(req.GetCalendar()))
+	if err != nil {
+		apiImportEventsErrorCounter.Inc()
+		log.Printf("calendar is incorrect: %s", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.Printf("Importing %d events: Owner: `%s` ...", len(events), owner)
+	results, err := cs.EventService.ImportEvents(ctx, owner, events)
+	if err != nil {
+		apiImportEventsErrorCounter.Inc()
+		log.Printf("Error during events import for user: `%s` - %s", owner, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &api.ImportEventsResponse{
+		Results: make([]*api.ImportResult, 0, len(rejected)+len(results)),
+	}
+	for _, r := range rejected {
+		resp.Results = append(resp.Results, &api.ImportResult{
+			Uid:    r.Uid,
+			Title:  r.Summary,
+			Status: api.ImportStatus_REJECTED,
+			Reason: r.Error(),
+		})
+	}
+	for _, r := range results {
+		protoResult := &api.ImportResult{
+			Uid:    r.Uid,
+			Title:  r.Title,
+			Status: api.ImportStatus(r.Status),
+			Reason: r.Reason,
+		}
+		if r.Status != models.ImportRejected {
+			protoResult.EventId = r.EventId.String()
+		}
+		if r.OriginalStartTime != nil {
+			if protoResult.OriginalStartTime, err = ptypes.TimestampProto(*r.OriginalStartTime); err != nil {
+				apiImportEventsErrorCounter.Inc()
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+		resp.Results = append(resp.Results, protoResult)
+	}
+	log.Printf("Events imported for user: `%s`", owner)
+	return resp, nil
+}
+
+// getOwner returns owner of authenticated request, or owner metadata if authentication is disabled
 func getOwner(ctx context.Context) (string, error) {
+	if owner, ok := auth.OwnerFromContext(ctx); ok {
+		return owner, nil
+	}
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
 		if o := md.Get("owner"); len(o) > 0 {
 			return o[0], nil
@@ -231,6 +432,10 @@ func (cs *CalendarServer) UpdateEvent(ctx context.Context, req *api.UpdateEventR
 		apiUpdateEventErrorCounter.Inc()
 		return nil, err
 	}
+	if owner, _, err = cs.calendarOwner(ctx, owner, req.GetCalendarOwner(), models.RoleWrite); err != nil {
+		apiUpdateEventErrorCounter.Inc()
+		return nil, err
+	}
 	st, err := ptypes.Timestamp(req.GetStartTime())
 	if err != nil {
 		apiUpdateEventErrorCounter.Inc()
@@ -243,7 +448,30 @@ func (cs *CalendarServer) UpdateEvent(ctx context.Context, req *api.UpdateEventR
 		log.Printf("end time is incorrect: %s", err)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	event, err := cs.EventService.UpdateEvent(ctx, owner, req.GetTitle(), req.GetText(), req.GetId(), &st, &et)
+	ot, err := optionalTimestamp(req.GetOccurrenceStartTime())
+	if err != nil {
+		apiUpdateEventErrorCounter.Inc()
+		log.Printf("occurrence start time is incorrect: %s", err)
+		return nil, err
+	}
+	reminders, err := RemindersFromProto(req.GetReminders())
+	if err != nil {
+		apiUpdateEventErrorCounter.Inc()
+		log.Printf("reminders are incorrect: %s", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	event, err := cs.EventService.UpdateEvent(ctx, req.GetId(), models.Scope(req.GetScope()), ot, &models.Event{
+		Owner:        owner,
+		Title:        req.GetTitle(),
+		Text:         req.GetText(),
+		StartTime:    &st,
+		EndTime:      &et,
+		Recurrence:   req.GetRecurrence(),
+		Transparency: protoTransparencies[req.GetTransparency()],
+		AllDay:       req.GetAllDay(),
+		TimeZone:     req.GetTimeZone(),
+		Reminders:    reminders,
+	})
 	if err != nil {
 		apiUpdateEventErrorCounter.Inc()
 		log.Printf("Error during event creation: `%s` -  %s", req.GetTitle(), err)
@@ -270,8 +498,27 @@ func (cs *CalendarServer) UpdateEvent(ctx context.Context, req *api.UpdateEventR
 	return resp, nil
 }
 
+// NewServer returns gRPC server with registered services
+func (cs *CalendarServer) NewServer() *grpc.Server {
+	interceptors := []grpc.UnaryServerInterceptor{grpc_prometheus.UnaryServerInterceptor}
+	if cs.CertOwner {
+		interceptors = append(interceptors, CertOwnerInterceptor)
+	}
+	if cs.Authenticator != nil {
+		interceptors = append(interceptors, AuthInterceptor(cs.Authenticator))
+	}
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(chainUnaryInterceptors(interceptors...))}
+	if cs.Tls != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(cs.Tls)))
+	}
+	s := grpc.NewServer(opts...)
+	api.RegisterCalendarServiceServer(s, cs)
+	api.RegisterCalendarServiceV2Server(s, &CalendarServerV2{CalendarServer: cs})
+	return s
+}
+
 func (cs *CalendarServer) Serve(addr string) error {
-	s := grpc.NewServer(grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor))
+	s := cs.NewServer()
 	go func() {
 		stop := make(chan os.Signal, 1)
 		signal.Notify(stop, os.Interrupt, syscall.SIGINT)
@@ -284,7 +531,6 @@ func (cs *CalendarServer) Serve(addr string) error {
 	if err != nil {
 		return err
 	}
-	api.RegisterCalendarServiceServer(s, cs)
 	grpc_prometheus.EnableHandlingTimeHistogram()
 	grpc_prometheus.Register(s)
 	return s.Serve(l)
@@ -0,0 +1,222 @@
+package grpc
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/satori/go.uuid"
+	"log"
+	"time"
+)
+
+// CalendarServerV2 implements CalendarServiceV2Server, domain errors are returned as statuses
+// with error details. Methods without domain errors are the same as in CalendarServer
+type CalendarServerV2 struct {
+	*CalendarServer
+}
+
+// requiredTimestamp converts timestamp of request field, which should be set
+func requiredTimestamp(field string, ts *timestamp.Timestamp) (time.Time, error) {
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return t, invalidArgument(field, err)
+	}
+	return t, nil
+}
+
+// requestEvent converts fields of create or update request to event
+func requestEvent(owner, title, text, recurrence string, start, end *timestamp.Timestamp, transparency api.Transparency, allDay bool, timeZone string,
+	durations []*duration.Duration) (*models.Event, error) {
+	st, err := requiredTimestamp("start_time", start)
+	if err != nil {
+		return nil, err
+	}
+	et, err := requiredTimestamp("end_time", end)
+	if err != nil {
+		return nil, err
+	}
+	reminders, err := RemindersFromProto(durations)
+	if err != nil {
+		return nil, invalidArgument("reminders", err)
+	}
+	return &models.Event{
+		Owner:        owner,
+		Title:        title,
+		Text:         text,
+		StartTime:    &st,
+		EndTime:      &et,
+		Recurrence:   recurrence,
+		Transparency: protoTransparencies[transparency],
+		AllDay:       allDay,
+		TimeZone:     timeZone,
+		Reminders:    reminders,
+	}, nil
+}
+
+func checkId(id string) error {
+	if _, err := uuid.FromString(id); err != nil {
+		return invalidArgument("id", err)
+	}
+	return nil
+}
+
+// eventErrorStatus converts error of event changes, overlapping event is looked up by find to be described
+func eventErrorStatus(err error, id string, find func() (*models.Event, error)) error {
+	if err != errors.ErrOverlaping {
+		return errorStatus(err, eventResourceType, id)
+	}
+	overlapping, ferr := find()
+	if ferr != nil || overlapping == nil {
+		return errorStatus(err, eventResourceType, id)
+	}
+	return overlapStatus(overlapping)
+}
+
+func (cs *CalendarServerV2) CreateEvent(ctx context.Context, req *api.CreateEventRequest) (*api.Event, error) {
+	apiCreateEventCounter.Inc()
+	log.Printf("Creating new event: `%s`...", req.GetTitle())
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiCreateEventErrorCounter.Inc()
+		return nil, err
+	}
+	event, err := requestEvent(owner, req.GetTitle(), req.GetText(), req.GetRecurrence(), req.GetStartTime(), req.GetEndTime(), req.GetTransparency(), req.GetAllDay(), req.GetTimeZone(),
+		req.GetReminders())
+	if err != nil {
+		apiCreateEventErrorCounter.Inc()
+		return nil, err
+	}
+	created, err := cs.EventService.CreateEvent(ctx, event)
+	if err != nil {
+		apiCreateEventErrorCounter.Inc()
+		log.Printf("Error during event creation: `%s` -  %s", req.GetTitle(), err)
+		return nil, eventErrorStatus(err, "", func() (*models.Event, error) {
+			return cs.EventService.FindOverlap(ctx, event)
+		})
+	}
+	log.Printf("Event created: `%s` -  %s", req.GetTitle(), created.Id)
+	return EventToProto(created)
+}
+
+func (cs *CalendarServerV2) UpdateEvent(ctx context.Context, req *api.UpdateEventRequest) (*api.Event, error) {
+	apiUpdateEventCounter.Inc()
+	log.Printf("Updating event: `%s`...", req.GetId())
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiUpdateEventErrorCounter.Inc()
+		return nil, err
+	}
+	if owner, _, err = cs.calendarOwner(ctx, owner, req.GetCalendarOwner(), models.RoleWrite); err != nil {
+		apiUpdateEventErrorCounter.Inc()
+		return nil, err
+	}
+	if err := checkId(req.GetId()); err != nil {
+		apiUpdateEventErrorCounter.Inc()
+		return nil, err
+	}
+	event, err := requestEvent(owner, req.GetTitle(), req.GetText(), req.GetRecurrence(), req.GetStartTime(), req.GetEndTime(), req.GetTransparency(), req.GetAllDay(), req.GetTimeZone(),
+		req.GetReminders())
+	if err != nil {
+		apiUpdateEventErrorCounter.Inc()
+		return nil, err
+	}
+	ot, err := optionalTimestamp(req.GetOccurrenceStartTime())
+	if err != nil {
+		apiUpdateEventErrorCounter.Inc()
+		return nil, err
+	}
+	updated, err := cs.EventService.UpdateEvent(ctx, req.GetId(), models.Scope(req.GetScope()), ot, event)
+	if err != nil {
+		apiUpdateEventErrorCounter.Inc()
+		log.Printf("Error during event update: `%s` -  %s", req.GetId(), err)
+		return nil, eventErrorStatus(err, req.GetId(), func() (*models.Event, error) {
+			return cs.EventService.FindUpdateOverlap(ctx, req.GetId(), models.Scope(req.GetScope()), ot, event)
+		})
+	}
+	return EventToProto(updated)
+}
+
+func (cs *CalendarServerV2) GetEvent(ctx context.Context, req *api.GetEventRequest) (*api.Event, error) {
+	apiGetEventCounter.Inc()
+	log.Printf("Getting event: `%s`...", req.GetId())
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiGetEventErrorCounter.Inc()
+		return nil, err
+	}
+	if owner, _, err = cs.calendarOwner(ctx, owner, req.GetCalendarOwner(), models.RoleRead); err != nil {
+		apiGetEventErrorCounter.Inc()
+		return nil, err
+	}
+	if err := checkId(req.GetId()); err != nil {
+		apiGetEventErrorCounter.Inc()
+		return nil, err
+	}
+	event, err := cs.EventService.GetEvent(ctx, req.GetId(), owner)
+	if err != nil {
+		apiGetEventErrorCounter.Inc()
+		log.Printf("Error during getting event: `%s` -  %s", req.GetId(), err)
+		return nil, errorStatus(err, eventResourceType, req.GetId())
+	}
+	return EventToProto(event)
+}
+
+// DeleteEvent deletes old events if id isn't set, like in CalendarServer
+func (cs *CalendarServerV2) DeleteEvent(ctx context.Context, req *api.DeleteEventRequest) (*empty.Empty, error) {
+	apiDeleteEventCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiDeleteEventErrorCounter.Inc()
+		return nil, err
+	}
+	if owner, _, err = cs.calendarOwner(ctx, owner, req.GetCalendarOwner(), models.RoleWrite); err != nil {
+		apiDeleteEventErrorCounter.Inc()
+		return nil, err
+	}
+	if req.GetId() == "" {
+		log.Println("Cleaning up old events..")
+		date := time.Now().AddDate(-1, 0, 0)
+		if err := cs.EventService.DeleteEventsOlderDate(ctx, &date, owner); err != nil {
+			apiDeleteEventErrorCounter.Inc()
+			log.Printf("Error during event cleaning up: %s", err)
+			return nil, errorStatus(err, eventResourceType, "")
+		}
+		return &empty.Empty{}, nil
+	}
+	log.Printf("Deleting event: `%s`...", req.GetId())
+	if err := checkId(req.GetId()); err != nil {
+		apiDeleteEventErrorCounter.Inc()
+		return nil, err
+	}
+	ot, err := optionalTimestamp(req.GetOccurrenceStartTime())
+	if err != nil {
+		apiDeleteEventErrorCounter.Inc()
+		return nil, err
+	}
+	if err := cs.EventService.DeleteEvent(ctx, req.GetId(), owner, models.Scope(req.GetScope()), ot); err != nil {
+		apiDeleteEventErrorCounter.Inc()
+		log.Printf("Error during event deletion: `%s` -  %s", req.GetId(), err)
+		return nil, errorStatus(err, eventResourceType, req.GetId())
+	}
+	return &empty.Empty{}, nil
+}
+
+func (cs *CalendarServerV2) RevokeFeedToken(ctx context.Context, req *api.RevokeFeedTokenRequest) (*empty.Empty, error) {
+	apiRevokeFeedTokenCounter.Inc()
+	owner, err := getOwner(ctx)
+	if err != nil {
+		apiRevokeFeedTokenErrorCounter.Inc()
+		return nil, err
+	}
+	log.Printf("Revoking feed token: Owner: `%s`...", owner)
+	if err := cs.EventService.RevokeFeedToken(ctx, req.GetToken(), owner); err != nil {
+		apiRevokeFeedTokenErrorCounter.Inc()
+		return nil, errorStatus(err, feedTokenResourceType, "")
+	}
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/services"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/Brialius/calendar/internal/memdb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/satori/go.uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+func TestCalendarServerV2_errors(t *testing.T) {
+	storage, _ := memdb.NewMemEventStorage()
+	cs := &CalendarServerV2{CalendarServer: &CalendarServer{EventService: &services.EventService{EventStorage: storage}}}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("owner", "user"))
+	start := time.Date(2019, 11, 4, 10, 0, 0, 0, time.UTC)
+	newRequest := func(start time.Time, d time.Duration) *api.CreateEventRequest {
+		st, _ := ptypes.TimestampProto(start)
+		et, _ := ptypes.TimestampProto(start.Add(d))
+		return &api.CreateEventRequest{Title: "meeting", StartTime: st, EndTime: et}
+	}
+	created, err := cs.CreateEvent(ctx, newRequest(start, time.Hour))
+	if err != nil {
+		t.Fatalf("can't create event: %s", err)
+	}
+
+	_, err = cs.CreateEvent(ctx, newRequest(start.Add(30*time.Minute), time.Hour))
+	st := status.Convert(err)
+	if st.Code() != codes.FailedPrecondition || len(st.Details()) != 1 {
+		t.Fatalf("overlapping event: unexpected status %v", st)
+	}
+	if f, ok := st.Details()[0].(*errdetails.PreconditionFailure); !ok || f.Violations[0].Subject != created.Id {
+		t.Errorf("overlapping event `%s` is not found in details %v", created.Id, st.Details())
+	}
+
+	_, err = cs.CreateEvent(ctx, newRequest(start.AddDate(0, 0, 1), -time.Hour))
+	st = status.Convert(err)
+	if st.Code() != codes.InvalidArgument || len(st.Details()) != 1 {
+		t.Fatalf("incorrect end time: unexpected status %v", st)
+	}
+	if br, ok := st.Details()[0].(*errdetails.BadRequest); !ok || br.FieldViolations[0].Field != "end_time" {
+		t.Errorf("end_time is not found in details %v", st.Details())
+	}
+
+	id := uuid.NewV4().String()
+	_, err = cs.GetEvent(ctx, &api.GetEventRequest{Id: id})
+	st = status.Convert(err)
+	if st.Code() != codes.NotFound || len(st.Details()) != 1 {
+		t.Fatalf("absent event: unexpected status %v", st)
+	}
+	if ri, ok := st.Details()[0].(*errdetails.ResourceInfo); !ok || ri.ResourceName != id {
+		t.Errorf("event `%s` is not found in details %v", id, st.Details())
+	}
+
+	if _, err := cs.DeleteEvent(ctx, &api.DeleteEventRequest{Id: created.Id}); err != nil {
+		t.Errorf("can't delete event: %s", err)
+	}
+	if _, err := cs.DeleteEvent(ctx, &api.DeleteEventRequest{Id: "incorrect"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("incorrect id: expected %s, got %v", codes.InvalidArgument, err)
+	}
+}
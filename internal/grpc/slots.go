@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"time"
+)
+
+// hoursLayout is format of working hours
+const hoursLayout = "15:04"
+
+// FindSlots returns the earliest slots, where nobody of attendees has an event.
+// Owner of request is an attendee if attendees aren't set
+func (cs *CalendarServer) FindSlots(ctx context.Context, req *api.FindSlotsRequest) (*api.FindSlotsResponse, error) {
+	apiFindSlotsCounter.Inc()
+	user, err := getOwner(ctx)
+	if err != nil {
+		apiFindSlotsErrorCounter.Inc()
+		return nil, err
+	}
+	attendees := req.GetAttendees()
+	if len(attendees) == 0 {
+		attendees = []string{user}
+	}
+	st, err := requiredTimestamp("start_time", req.GetStartTime())
+	if err != nil {
+		apiFindSlotsErrorCounter.Inc()
+		return nil, err
+	}
+	et, err := requiredTimestamp("end_time", req.GetEndTime())
+	if err != nil {
+		apiFindSlotsErrorCounter.Inc()
+		return nil, err
+	}
+	d, err := ptypes.Duration(req.GetDuration())
+	if err != nil {
+		apiFindSlotsErrorCounter.Inc()
+		return nil, invalidArgument("duration", err)
+	}
+	hours, err := workingHours(req.GetWorkingHours())
+	if err != nil {
+		apiFindSlotsErrorCounter.Inc()
+		return nil, err
+	}
+	for _, a := range attendees {
+		if _, _, err := cs.calendarOwner(ctx, user, a, models.RoleFreeBusy); err != nil {
+			apiFindSlotsErrorCounter.Inc()
+			return nil, err
+		}
+	}
+	log.Printf("Finding slots of %s for %v: User: `%s`, period: %s - %s ...", d, attendees, user, st, et)
+	slots, err := cs.EventService.FindSlots(ctx, attendees, st, et, d, hours, int(req.GetCount()))
+	if err != nil {
+		apiFindSlotsErrorCounter.Inc()
+		log.Printf("Error during slots search for: %v - %s", attendees, err)
+		return nil, errorStatus(err, "", "")
+	}
+	resp := &api.FindSlotsResponse{
+		Slots: make([]*api.Slot, 0, len(slots)),
+	}
+	for _, s := range slots {
+		protoSlot, err := SlotToProto(s)
+		if err != nil {
+			apiFindSlotsErrorCounter.Inc()
+			return nil, err
+		}
+		resp.Slots = append(resp.Slots, protoSlot)
+	}
+	return resp, nil
+}
+
+func SlotToProto(s models.Interval) (*api.Slot, error) {
+	protoSlot := &api.Slot{}
+	var err error
+	if protoSlot.StartTime, err = ptypes.TimestampProto(s.Start); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if protoSlot.EndTime, err = ptypes.TimestampProto(s.End); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return protoSlot, nil
+}
+
+// workingHours converts working hours of request, nil is returned if they aren't set
+func workingHours(wh *api.WorkingHours) (*models.WorkingHours, error) {
+	if wh == nil || (wh.GetStart() == "" && wh.GetEnd() == "") {
+		return nil, nil
+	}
+	start, err := time.Parse(hoursLayout, wh.GetStart())
+	if err != nil {
+		return nil, invalidArgument("working_hours.start", err)
+	}
+	end, err := time.Parse(hoursLayout, wh.GetEnd())
+	if err != nil {
+		return nil, invalidArgument("working_hours.end", err)
+	}
+	loc, err := time.LoadLocation(wh.GetTimeZone())
+	if err != nil {
+		return nil, invalidArgument("working_hours.time_zone", fmt.Errorf("unknown time zone `%s`", wh.GetTimeZone()))
+	}
+	midnight := time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &models.WorkingHours{
+		Start:    start.Sub(midnight),
+		End:      end.Sub(midnight),
+		Location: loc,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"fmt"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+)
+
+const (
+	eventResourceType     = "event"
+	feedTokenResourceType = "feed_token"
+	grantResourceType     = "grant"
+	// overlapViolation is type of PreconditionFailure violation for overlapping events
+	overlapViolation = "OVERLAP"
+)
+
+// fieldOfError is request field which causes domain error
+var fieldOfError = map[errors.EventError]string{
+	errors.ErrIncorrectEndDate:      "end_time",
+	errors.ErrIncorrectRecurrence:   "recurrence",
+	errors.ErrIncorrectOccurrence:   "occurrence_start_time",
+	errors.ErrIncorrectRole:         "role",
+	errors.ErrIncorrectGrantee:      "grantee",
+	errors.ErrIncorrectDuration:     "duration",
+	errors.ErrIncorrectHours:        "working_hours",
+	errors.ErrIncorrectAttendee:     "attendees",
+	errors.ErrIncorrectStatus:       "status",
+	errors.ErrIncorrectTransparency: "transparency",
+	errors.ErrIncorrectTimeZone:     "time_zone",
+	errors.ErrIncorrectReminder:     "reminders",
+}
+
+// errorStatus converts domain error to status with error details, resource describes addressed
+// resource for NOT_FOUND. Other errors are internal
+func errorStatus(err error, resourceType, resourceName string) error {
+	berr, ok := err.(errors.EventError)
+	if !ok {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if berr == errors.ErrNotFound {
+		return withDetails(codes.NotFound, berr, &errdetails.ResourceInfo{
+			ResourceType: resourceType,
+			ResourceName: resourceName,
+			Description:  string(berr),
+		})
+	}
+	if berr == errors.ErrAccessDenied {
+		return status.Error(codes.PermissionDenied, string(berr))
+	}
+	if field, ok := fieldOfError[berr]; ok {
+		return invalidArgument(field, berr)
+	}
+	return status.Error(codes.FailedPrecondition, string(berr))
+}
+
+// overlapStatus describes event which is overlapped by created or updated one
+func overlapStatus(overlapping *models.Event) error {
+	return withDetails(codes.FailedPrecondition, errors.ErrOverlaping, &errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        overlapViolation,
+			Subject:     overlapping.Id.String(),
+			Description: fmt.Sprintf("event overlaps with `%s` at %s", overlapping.Title, overlapping.StartTime),
+		}},
+	})
+}
+
+func invalidArgument(field string, err error) error {
+	return withDetails(codes.InvalidArgument, err, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: err.Error(),
+		}},
+	})
+}
+
+func withDetails(c codes.Code, err error, details ...proto.Message) error {
+	st, derr := status.New(c, err.Error()).WithDetails(details...)
+	if derr != nil {
+		log.Printf("can't add details to status: %s", derr)
+		return status.Error(c, err.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// CertOwnerInterceptor takes owner from common name of verified client certificate,
+// token authentication isn't required for such requests
+func CertOwnerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if owner := certOwner(ctx); owner != "" {
+		ctx = auth.NewContext(ctx, owner)
+	}
+	return handler(ctx, req)
+}
+
+func certOwner(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return info.State.VerifiedChains[0][0].Subject.CommonName
+}
@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/Brialius/calendar/internal/config"
+	"github.com/Brialius/calendar/internal/domain/services"
+	"github.com/Brialius/calendar/internal/grpc/api"
+	"github.com/Brialius/calendar/internal/memdb"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert issues certificate signed by parent, self-signed if parent is nil, and writes PEM files to dir
+func writeCert(t *testing.T, dir, name string, tmpl *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl.SerialNumber = big.NewInt(time.Now().UnixNano())
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = ioutil.WriteFile(filepath.Join(dir, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	_ = ioutil.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	cert, _ := x509.ParseCertificate(der)
+	return cert, key
+}
+
+func TestCalendarServer_mutualTls(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeCert(t, dir, "ca", &x509.Certificate{
+		Subject: pkix.Name{CommonName: "calendar CA"}, IsCA: true, BasicConstraintsValid: true,
+		KeyUsage: x509.KeyUsageCertSign,
+	}, nil, nil)
+	writeCert(t, dir, "server", &x509.Certificate{
+		Subject: pkix.Name{CommonName: "calendar"}, DNSNames: []string{"calendar.test"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	writeCert(t, dir, "client", &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "alice"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	file := func(name string) string { return filepath.Join(dir, name) }
+
+	serverTls, err := (&config.ServerTlsConfig{CertFile: file("server.crt"), KeyFile: file("server.key"),
+		ClientCaFile: file("ca.crt"), CertOwner: true}).Tls()
+	if err != nil {
+		t.Fatalf("can't configure server TLS: %s", err)
+	}
+	storage, _ := memdb.NewMemEventStorage()
+	cs := &CalendarServer{EventService: &services.EventService{EventStorage: storage}, Tls: serverTls, CertOwner: true}
+	s := cs.NewServer()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() { _ = s.Serve(l) }()
+	defer s.Stop()
+
+	dial := func(c *config.ClientTlsConfig) (api.CalendarServiceV2Client, func()) {
+		t.Helper()
+		clientTls, err := c.Tls()
+		if err != nil {
+			t.Fatalf("can't configure client TLS: %s", err)
+		}
+		conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(clientTls)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return api.NewCalendarServiceV2Client(conn), func() { _ = conn.Close() }
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	st, _ := ptypes.TimestampProto(time.Date(2019, 11, 4, 10, 0, 0, 0, time.UTC))
+	et, _ := ptypes.TimestampProto(time.Date(2019, 11, 4, 11, 0, 0, 0, time.UTC))
+	req := &api.CreateEventRequest{Title: "meeting", StartTime: st, EndTime: et}
+
+	client, closeConn := dial(&config.ClientTlsConfig{Enabled: true, CaFile: file("ca.crt"),
+		CertFile: file("client.crt"), KeyFile: file("client.key"), ServerName: "calendar.test"})
+	defer closeConn()
+	created, err := client.CreateEvent(ctx, req)
+	if err != nil {
+		t.Fatalf("can't create event with client certificate: %s", err)
+	}
+	if _, err := storage.GetEventByIdOwner(ctx, created.Id, "alice"); err != nil {
+		t.Errorf("event of certificate owner is not found: %s", err)
+	}
+
+	anonymous, closeAnonymous := dial(&config.ClientTlsConfig{Enabled: true, CaFile: file("ca.crt"), ServerName: "calendar.test"})
+	defer closeAnonymous()
+	if _, err := anonymous.CreateEvent(ctx, req); err == nil {
+		t.Error("request without client certificate shouldn't be accepted")
+	}
+}
@@ -0,0 +1,317 @@
+package ical
+
+import (
+	"bufio"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/pkg/errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	localLayout = "20060102T150405"
+	dateLayout  = "20060102"
+)
+
+var durationRe = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// property is parsed content line
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// DecodeError describes VEVENT which can't be converted to event
+type DecodeError struct {
+	Uid     string
+	Summary string
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Decode reads VEVENTs of VCALENDAR object. Overrides with RECURRENCE-ID are returned with
+// OriginalStartTime and UID of their series, EXDATEs become cancelled overrides.
+// Times without zone are treated as UTC. Malformed VEVENTs are returned as DecodeErrors,
+// error is returned only if calendar itself can't be parsed
+func Decode(r io.Reader) ([]*models.Event, []*DecodeError, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var events []*models.Event
+	var rejected []*DecodeError
+	var components []string
+	var props []*property
+	calendars := 0
+	for n, line := range lines {
+		if line == "" {
+			continue
+		}
+		p, err := parseLine(line)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "line %d", n+1)
+		}
+		switch p.name {
+		case "BEGIN":
+			name := strings.ToUpper(p.value)
+			if len(components) == 0 {
+				if name != "VCALENDAR" {
+					return nil, nil, errors.Errorf("line %d: VCALENDAR is expected, got %s", n+1, name)
+				}
+				calendars++
+			}
+			components = append(components, name)
+			if name == "VEVENT" {
+				props = nil
+			}
+		case "END":
+			name := strings.ToUpper(p.value)
+			if len(components) == 0 || components[len(components)-1] != name {
+				return nil, nil, errors.Errorf("line %d: unexpected END:%s", n+1, name)
+			}
+			components = components[:len(components)-1]
+			if name == "VEVENT" && len(components) == 1 {
+				evs, err := toEvents(props)
+				if err != nil {
+					rejected = append(rejected, err)
+					continue
+				}
+				events = append(events, evs...)
+			}
+		default:
+			// properties of nested components like VALARM are ignored
+			if len(components) == 2 && components[1] == "VEVENT" {
+				props = append(props, p)
+			}
+		}
+	}
+	if len(components) != 0 {
+		return nil, nil, errors.Errorf("%s is not finished", components[len(components)-1])
+	}
+	if calendars == 0 {
+		return nil, nil, errors.New("VCALENDAR is not found")
+	}
+	return events, rejected, nil
+}
+
+// unfold joins continuation lines, which start with space or tab
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64*1024), 1024*1024)
+	for s.Scan() {
+		line := strings.TrimSuffix(s.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, s.Err()
+}
+
+// parseLine splits content line to name, parameters and value, parameter values can be quoted
+func parseLine(line string) (*property, error) {
+	p := &property{params: make(map[string]string)}
+	quoted := false
+	start := 0
+	var param string
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ';' || c == ':':
+			if p.name == "" {
+				p.name = strings.ToUpper(line[start:i])
+			} else {
+				p.params[strings.ToUpper(param)] = strings.Trim(line[start:i], `"`)
+			}
+			if c == ':' {
+				p.value = line[i+1:]
+				return p, nil
+			}
+			start = i + 1
+		case c == '=' && p.name != "":
+			param = line[start:i]
+			start = i + 1
+		}
+	}
+	return nil, errors.Errorf("can't parse content line `%s`", line)
+}
+
+func toEvents(props []*property) ([]*models.Event, *DecodeError) {
+	e := &models.Event{}
+	var start, end, recurrenceId *property
+	var duration string
+	var exdates []*property
+	var cancelled bool
+	for _, p := range props {
+		switch p.name {
+		case "UID":
+			e.Uid = p.value
+		case "SUMMARY":
+			e.Title = unescape(p.value)
+		case "DESCRIPTION":
+			e.Text = unescape(p.value)
+		case "DTSTART":
+			start = p
+		case "DTEND":
+			end = p
+		case "DURATION":
+			duration = p.value
+		case "RRULE":
+			e.Recurrence = p.value
+		case "RECURRENCE-ID":
+			recurrenceId = p
+		case "EXDATE":
+			exdates = append(exdates, p)
+		case "STATUS":
+			cancelled = strings.EqualFold(p.value, "CANCELLED")
+			if strings.EqualFold(p.value, "TENTATIVE") && e.Transparency == "" {
+				e.Transparency = models.TransparencyTentative
+			}
+		case "TRANSP":
+			if strings.EqualFold(p.value, "TRANSPARENT") {
+				e.Transparency = models.TransparencyFree
+			}
+		}
+	}
+	reject := func(err error) ([]*models.Event, *DecodeError) {
+		return nil, &DecodeError{Uid: e.Uid, Summary: e.Title, Err: err}
+	}
+	if start == nil {
+		return reject(errors.New("DTSTART is not set"))
+	}
+	st, isDate, err := parseTime(start, start.value)
+	if err != nil {
+		return reject(err)
+	}
+	var et time.Time
+	switch {
+	case end != nil:
+		if et, _, err = parseTime(end, end.value); err != nil {
+			return reject(err)
+		}
+	case duration != "":
+		d, err := parseDuration(duration)
+		if err != nil {
+			return reject(err)
+		}
+		et = st.Add(d)
+	case isDate:
+		// all day event lasts one day
+		et = st.AddDate(0, 0, 1)
+	default:
+		et = st
+	}
+	e.StartTime, e.EndTime, e.AllDay = &st, &et, isDate
+	if !isDate {
+		e.TimeZone = strings.TrimPrefix(start.params["TZID"], "/")
+	}
+	if recurrenceId != nil {
+		rid, _, err := parseTime(recurrenceId, recurrenceId.value)
+		if err != nil {
+			return reject(err)
+		}
+		e.OriginalStartTime = &rid
+		e.Recurrence = ""
+		e.Cancelled = cancelled
+		return []*models.Event{e}, nil
+	}
+	if cancelled {
+		return reject(errors.New("event is cancelled"))
+	}
+	events := []*models.Event{e}
+	for _, p := range exdates {
+		for _, v := range strings.Split(p.value, ",") {
+			t, _, err := parseTime(p, v)
+			if err != nil {
+				return reject(err)
+			}
+			te := t.Add(et.Sub(st))
+			events = append(events, &models.Event{
+				Uid:               e.Uid,
+				Title:             e.Title,
+				StartTime:         &t,
+				EndTime:           &te,
+				OriginalStartTime: &t,
+				Cancelled:         true,
+				AllDay:            isDate,
+			})
+		}
+	}
+	return events, nil
+}
+
+// parseTime parses DATE or DATE-TIME value, TZID parameter should be IANA time zone name
+func parseTime(p *property, value string) (time.Time, bool, error) {
+	if strings.EqualFold(p.params["VALUE"], "DATE") || len(value) == len(dateLayout) {
+		t, err := time.Parse(dateLayout, value)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcLayout, value)
+		return t, false, err
+	}
+	loc := time.UTC
+	if tzid := p.params["TZID"]; tzid != "" {
+		var err error
+		if loc, err = time.LoadLocation(strings.TrimPrefix(tzid, "/")); err != nil {
+			return time.Time{}, false, errors.Errorf("unknown time zone `%s`", tzid)
+		}
+	}
+	t, err := time.ParseInLocation(localLayout, value, loc)
+	return t, false, err
+}
+
+// parseDuration parses RFC 5545 DURATION value, e.g. PT1H30M
+func parseDuration(value string) (time.Duration, error) {
+	parts := durationRe.FindStringSubmatch(value)
+	if parts == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, errors.Errorf("can't parse duration `%s`", value)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if parts[i+2] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(parts[i+2])
+		if err != nil {
+			return 0, err
+		}
+		d += time.Duration(n) * unit
+	}
+	if parts[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+// unescape reverts TEXT value escaping
+func unescape(value string) string {
+	var b strings.Builder
+	escaped := false
+	for _, c := range value {
+		switch {
+		case escaped && (c == 'n' || c == 'N'):
+			b.WriteRune('\n')
+		case escaped:
+			b.WriteRune(c)
+		case c == '\\':
+			escaped = true
+			continue
+		default:
+			b.WriteRune(c)
+		}
+		escaped = false
+	}
+	return b.String()
+}
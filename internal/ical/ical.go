@@ -0,0 +1,163 @@
+package ical
+
+import (
+	"bufio"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"io"
+	"strings"
+	"time"
+)
+
+// ContentType is MIME type of encoded calendar
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	prodId = "-//Brialius//calendar//EN"
+	// utcLayout is RFC 5545 DATE-TIME in UTC form
+	utcLayout = "20060102T150405Z"
+	// maxLineLength is limit of content line length in octets, longer lines are folded
+	maxLineLength = 75
+)
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// Encode writes events as VCALENDAR object. Overrides of recurring events are written as VEVENTs
+// with UID of their series and RECURRENCE-ID, cancelled ones become EXDATE of the series
+func Encode(w io.Writer, events []*models.Event) error {
+	cw := &contentWriter{w: bufio.NewWriter(w)}
+	uids := make(map[uuid.UUID]string)
+	exdates := make(map[uuid.UUID][]time.Time)
+	for _, e := range events {
+		uids[e.Id] = uid(e)
+		if e.IsOverride() && e.Cancelled && e.OriginalStartTime != nil {
+			exdates[*e.SeriesId] = append(exdates[*e.SeriesId], *e.OriginalStartTime)
+		}
+	}
+	stamp := formatTime(time.Now())
+
+	cw.line("BEGIN", "VCALENDAR")
+	cw.line("VERSION", "2.0")
+	cw.line("PRODID", prodId)
+	cw.line("CALSCALE", "GREGORIAN")
+	for _, e := range events {
+		if e.Cancelled {
+			continue
+		}
+		cw.line("BEGIN", "VEVENT")
+		if e.IsOverride() {
+			seriesUid, ok := uids[*e.SeriesId]
+			if !ok {
+				seriesUid = e.SeriesId.String()
+			}
+			cw.line("UID", seriesUid)
+			if e.OriginalStartTime != nil {
+				cw.time("RECURRENCE-ID", e, *e.OriginalStartTime)
+			}
+		} else {
+			cw.line("UID", uids[e.Id])
+		}
+		cw.line("DTSTAMP", stamp)
+		cw.time("DTSTART", e, *e.StartTime)
+		cw.time("DTEND", e, *e.EndTime)
+		cw.line("SUMMARY", textEscaper.Replace(e.Title))
+		if e.Text != "" {
+			cw.line("DESCRIPTION", textEscaper.Replace(e.Text))
+		}
+		switch e.Transparency {
+		case models.TransparencyFree:
+			cw.line("TRANSP", "TRANSPARENT")
+		case models.TransparencyTentative:
+			cw.line("STATUS", "TENTATIVE")
+		}
+		if e.IsRecurring() {
+			cw.line("RRULE", strings.TrimPrefix(e.Recurrence, "RRULE:"))
+			if dates := exdates[e.Id]; len(dates) > 0 {
+				cw.time("EXDATE", e, dates...)
+			}
+		}
+		cw.line("END", "VEVENT")
+	}
+	cw.line("END", "VCALENDAR")
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+// uid returns UID of event, events stored before UIDs were introduced are identified by Id
+func uid(e *models.Event) string {
+	if e.Uid != "" {
+		return e.Uid
+	}
+	return e.Id.String()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+// contentWriter writes folded content lines, the first error is kept and stops writing
+type contentWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// time writes DATE-TIME property in time zone of the event, or DATE one for all-day events.
+// Times of events without known time zone are written in UTC
+func (cw *contentWriter) time(name string, e *models.Event, times ...time.Time) {
+	var loc *time.Location
+	if e.TimeZone != "" && !e.AllDay {
+		loc, _ = time.LoadLocation(e.TimeZone)
+	}
+	values := make([]string, 0, len(times))
+	for _, t := range times {
+		switch {
+		case e.AllDay:
+			values = append(values, t.UTC().Format(dateLayout))
+		case loc != nil:
+			values = append(values, t.In(loc).Format(localLayout))
+		default:
+			values = append(values, formatTime(t))
+		}
+	}
+	switch {
+	case e.AllDay:
+		name += ";VALUE=DATE"
+	case loc != nil:
+		name += ";TZID=" + e.TimeZone
+	}
+	cw.line(name, strings.Join(values, ","))
+}
+
+func (cw *contentWriter) line(name, value string) {
+	if cw.err != nil {
+		return
+	}
+	_, cw.err = cw.w.WriteString(fold(name + ":" + value))
+}
+
+// fold splits line longer than maxLineLength octets, continuation lines start with a space.
+// Lines are never split inside of UTF-8 sequence
+func fold(line string) string {
+	var b strings.Builder
+	limit := maxLineLength
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !isRuneStart(line[i]) {
+			i--
+		}
+		b.WriteString(line[:i])
+		b.WriteString("\r\n ")
+		line = line[i:]
+		// leading space takes one octet of continuation line
+		limit = maxLineLength - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,190 @@
+package ical
+
+import (
+	"bytes"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	start := time.Date(2019, 11, 4, 13, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	end := start.Add(15 * time.Minute)
+	series := &models.Event{
+		Id:           uuid.NewV4(),
+		Title:        "standup, daily",
+		Text:         "line 1\nline 2",
+		StartTime:    &start,
+		EndTime:      &end,
+		Recurrence:   "FREQ=WEEKLY;COUNT=10",
+		Transparency: models.TransparencyFree,
+	}
+	second, third := start.AddDate(0, 0, 7), start.AddDate(0, 0, 14)
+	moved, movedEnd := second.Add(time.Hour), second.Add(2*time.Hour)
+	override := &models.Event{
+		Id:                uuid.NewV4(),
+		Title:             strings.Repeat("long title ", 10),
+		StartTime:         &moved,
+		EndTime:           &movedEnd,
+		SeriesId:          &series.Id,
+		OriginalStartTime: &second,
+	}
+	cancelled := &models.Event{
+		Id:                uuid.NewV4(),
+		StartTime:         &third,
+		EndTime:           &third,
+		SeriesId:          &series.Id,
+		OriginalStartTime: &third,
+		Cancelled:         true,
+	}
+
+	holiday, holidayEnd := time.Date(2019, 11, 4, 0, 0, 0, 0, time.UTC), time.Date(2019, 11, 6, 0, 0, 0, 0, time.UTC)
+	allDay := &models.Event{
+		Id:        uuid.NewV4(),
+		Title:     "holiday",
+		StartTime: &holiday,
+		EndTime:   &holidayEnd,
+		AllDay:    true,
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, []*models.Event{series, override, cancelled, allDay}); err != nil {
+		t.Fatalf("can't encode events: %s", err)
+	}
+	res := buf.String()
+	for _, line := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:" + series.Id.String() + "\r\n",
+		"DTSTART:20191104T100000Z\r\n",
+		"SUMMARY:standup\\, daily\r\n",
+		"DESCRIPTION:line 1\\nline 2\r\n",
+		"RRULE:FREQ=WEEKLY;COUNT=10\r\n",
+		"TRANSP:TRANSPARENT\r\n",
+		"EXDATE:20191118T100000Z\r\n",
+		"RECURRENCE-ID:20191111T100000Z\r\n",
+		"DTSTART;VALUE=DATE:20191104\r\n",
+		"DTEND;VALUE=DATE:20191106\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(res, line) {
+			t.Errorf("%q is not found in:\n%s", line, res)
+		}
+	}
+	if c := strings.Count(res, "BEGIN:VEVENT"); c != 3 {
+		t.Errorf("expected 3 VEVENTs, got %d", c)
+	}
+	for _, line := range strings.Split(res, "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("line is not folded: %q", line)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/Moscow",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"DTSTART;TZID=Europe/Moscow:20191104T130000",
+		"DURATION:PT15M",
+		"SUMMARY:standup\\, daily",
+		"DESCRIPTION:long ",
+		" text\\nline 2",
+		"RRULE:FREQ=WEEKLY;COUNT=10",
+		"EXDATE:20191118T100000Z,20191125T100000Z",
+		"BEGIN:VALARM",
+		"DESCRIPTION:alarm",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:standup@example.com",
+		"RECURRENCE-ID:20191111T100000Z",
+		"DTSTART:20191111T110000Z",
+		"DTEND:20191111T111500Z",
+		"SUMMARY:moved",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:holiday@example.com",
+		"DTSTART;VALUE=DATE:20191104",
+		"SUMMARY:holiday",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:broken@example.com",
+		"SUMMARY:no start",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	events, rejected, err := Decode(strings.NewReader(calendar))
+	if err != nil {
+		t.Fatalf("can't decode calendar: %s", err)
+	}
+	if len(rejected) != 1 || rejected[0].Uid != "broken@example.com" {
+		t.Errorf("expected 1 rejected event, got %v", rejected)
+	}
+	if len(events) != 5 {
+		t.Fatalf("expected 5 events, got %d", len(events))
+	}
+	series := events[0]
+	start := time.Date(2019, 11, 4, 10, 0, 0, 0, time.UTC)
+	if series.Title != "standup, daily" || series.Text != "long text\nline 2" || series.Recurrence != "FREQ=WEEKLY;COUNT=10" ||
+		!series.StartTime.Equal(start) || !series.EndTime.Equal(start.Add(15*time.Minute)) {
+		t.Errorf("series is decoded incorrectly: %s", series)
+	}
+	for _, e := range events[1:3] {
+		if !e.Cancelled || e.Uid != series.Uid || e.OriginalStartTime == nil {
+			t.Errorf("EXDATE is decoded incorrectly: %s", e)
+		}
+	}
+	if override := events[3]; override.OriginalStartTime == nil || !override.OriginalStartTime.Equal(start.AddDate(0, 0, 7)) ||
+		override.Cancelled || override.IsRecurring() {
+		t.Errorf("override is decoded incorrectly: %s", override)
+	}
+	if holiday := events[4]; !holiday.AllDay || holiday.EndTime.Sub(*holiday.StartTime) != 24*time.Hour || series.AllDay {
+		t.Errorf("all day event should last 1 day: %s", holiday)
+	}
+	if events[4].Transparency != models.TransparencyFree || !series.IsBusy() {
+		t.Errorf("transparent event should be free, got %q", events[4].Transparency)
+	}
+
+	if _, _, err := Decode(strings.NewReader("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n")); err == nil {
+		t.Errorf("malformed calendar shouldn't be decoded")
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	start := time.Date(2019, 11, 4, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	event := &models.Event{
+		Id:        uuid.NewV4(),
+		Uid:       "event@example.com",
+		Title:     "title; with, special\\chars",
+		Text:      "multi\nline",
+		StartTime: &start,
+		EndTime:   &end,
+		TimeZone:  "Europe/Moscow",
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, []*models.Event{event}); err != nil {
+		t.Fatalf("can't encode events: %s", err)
+	}
+	if !strings.Contains(buf.String(), "DTSTART;TZID=Europe/Moscow:20191104T130000\r\n") {
+		t.Errorf("start time should be written in time zone of the event:\n%s", buf.String())
+	}
+	events, rejected, err := Decode(&buf)
+	if err != nil || len(rejected) != 0 || len(events) != 1 {
+		t.Fatalf("can't decode encoded events: %v %v", err, rejected)
+	}
+	got := events[0]
+	if got.Uid != event.Uid || got.Title != event.Title || got.Text != event.Text || got.TimeZone != event.TimeZone ||
+		!got.StartTime.Equal(start) || !got.EndTime.Equal(end) {
+		t.Errorf("decoded event is different: %s != %s", got, event)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"github.com/Brialius/calendar/internal/domain/errors"
 	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/jackc/pgx"
 	_ "github.com/jackc/pgx/stdlib"
 	"github.com/jmoiron/sqlx"
 	"time"
@@ -26,20 +27,14 @@ func NewPgEventStorage(dsn string) (*PgEventStorage, error) {
 	return &PgEventStorage{db: db}, nil
 }
 
-func (pges *PgEventStorage) SaveEvent(ctx context.Context, event *models.Event) error {
-	query := `
-		INSERT INTO events(id, owner, title, text, start_time, end_time)
-		VALUES (:id, :owner, :title, :text, :start_time, :end_time)
-	`
-	_, err := pges.db.NamedExecContext(ctx, query, map[string]interface{}{
-		"id":         event.Id.String(),
-		"owner":      event.Owner,
-		"title":      event.Title,
-		"text":       event.Text,
-		"start_time": event.StartTime,
-		"end_time":   event.EndTime,
+func (pges *PgEventStorage) SaveEvent(ctx context.Context, event *models.Event, messages []*models.OutboxMessage) error {
+	err := pges.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := insertEvent(ctx, tx, event); err != nil {
+			return err
+		}
+		return saveOutboxMessages(ctx, tx, messages)
 	})
-	return err
+	return eventError(err)
 }
 
 func (pges *PgEventStorage) GetEventByIdOwner(ctx context.Context, id, owner string) (*models.Event, error) {
@@ -57,9 +52,24 @@ func (pges *PgEventStorage) GetEventByIdOwner(ctx context.Context, id, owner str
 	return event, nil
 }
 
+func (pges *PgEventStorage) GetEventByUidOwner(ctx context.Context, uid, owner string) (*models.Event, error) {
+	query := `
+		SELECT * FROM events WHERE uid=$1 AND owner=$2
+`
+	event := &models.Event{}
+	err := pges.db.GetContext(ctx, event, query, uid, owner)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
 func (pges *PgEventStorage) GetEventsByOwnerStartDate(ctx context.Context, owner string, startTime *time.Time) ([]*models.Event, error) {
 	query := `
-		SELECT * FROM events WHERE owner=$1 AND start_time>=$2
+		SELECT * FROM events WHERE owner=$1 AND (start_time>=$2 OR recurrence<>'' OR original_start_time>=$2)
 `
 	var events []*models.Event
 	err := pges.db.SelectContext(ctx, &events, query, owner, startTime)
@@ -71,10 +81,12 @@ func (pges *PgEventStorage) GetEventsByOwnerStartDate(ctx context.Context, owner
 
 func (pges *PgEventStorage) GetEventsForNotification(ctx context.Context, startTime time.Time, period time.Duration) ([]*models.Event, error) {
 	query := `
-		SELECT * FROM events WHERE start_time<=$1 AND notified = false
+		SELECT e.* FROM events e
+		WHERE e.start_time<=$2 AND (e.recurrence='' AND e.end_time>$1 OR e.recurrence<>'' AND (e.series_end IS NULL OR e.series_end>$1))
+		   OR e.original_start_time<=$2 AND EXISTS(SELECT 1 FROM events s WHERE s.id=e.series_id AND e.original_start_time+(s.end_time-s.start_time)>$1)
 `
 	var events []*models.Event
-	err := pges.db.SelectContext(ctx, &events, query, startTime.Add(period))
+	err := pges.db.SelectContext(ctx, &events, query, startTime, startTime.Add(period))
 	if err != nil {
 		return nil, err
 	}
@@ -86,8 +98,8 @@ func (pges *PgEventStorage) GetEventsCountByOwnerStartDateEndDate(ctx context.Co
 SELECT count(*)
 FROM events
 WHERE owner = $1
-  AND (start_time BETWEEN $2 AND $3
-    OR end_time BETWEEN $2 AND $3)
+  AND start_time < $3
+  AND end_time > $2
 `
 	var eventsCount int
 	err := pges.db.GetContext(ctx, &eventsCount, query, owner, startTime, endTime)
@@ -97,33 +109,310 @@ WHERE owner = $1
 	return eventsCount, nil
 }
 
-func (pges *PgEventStorage) DeleteEventByIdOwner(ctx context.Context, id, owner string) error {
+func (pges *PgEventStorage) GetEventsByOwnerStartDateEndDate(ctx context.Context, owner string, startTime, endTime *time.Time) ([]*models.Event, error) {
 	query := `
-		DELETE FROM events WHERE id=$1 AND owner=$2
+		SELECT * FROM events WHERE owner=$1 AND (start_time<=$3 AND (end_time>=$2 OR recurrence<>'') OR original_start_time<=$3)
+`
+	var events []*models.Event
+	err := pges.db.SelectContext(ctx, &events, query, owner, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (pges *PgEventStorage) DeleteEventByIdOwner(ctx context.Context, id, owner string, messages []*models.OutboxMessage) error {
+	query := `
+		DELETE FROM events WHERE (id=$1 OR series_id=$1) AND owner=$2
 	`
-	res, err := pges.db.ExecContext(ctx, query, id, owner)
-	if res != nil {
+	return pges.inTx(ctx, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, query, id, owner)
+		if err != nil {
+			return err
+		}
 		if c, _ := res.RowsAffected(); c == 0 {
 			return errors.ErrNotFound
 		}
-	}
-	return err
+		return saveOutboxMessages(ctx, tx, messages)
+	})
 }
 
 func (pges *PgEventStorage) DeleteEventsOlderDateByIdOwner(ctx context.Context, date *time.Time, owner string) (int64, error) {
 	query := `
-		DELETE FROM events WHERE end_time<=$1 AND owner=$2
+		DELETE FROM events WHERE end_time<=$1 AND owner=$2 AND recurrence='' AND series_id IS NULL
 	`
 	res, err := pges.db.ExecContext(ctx, query, date, owner)
 	c, _ := res.RowsAffected()
 	return c, err
 }
 
-func (pges *PgEventStorage) UpdateEventByIdOwner(ctx context.Context, id string, event *models.Event) error {
+func (pges *PgEventStorage) UpdateEventByIdOwner(ctx context.Context, id string, event *models.Event, messages []*models.OutboxMessage) error {
+	err := pges.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := updateEvent(ctx, tx, id, event); err != nil {
+			return err
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+	return eventError(err)
+}
+
+// UpdateSeries updates series and deletes overrides of its occurrences started since, if it's set
+func (pges *PgEventStorage) UpdateSeries(ctx context.Context, series *models.Event, since *time.Time, messages []*models.OutboxMessage) error {
+	err := pges.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := updateEvent(ctx, tx, series.Id.String(), series); err != nil {
+			return err
+		}
+		if since != nil {
+			if _, err := deleteOverrides(ctx, tx, series.Id.String(), series.Owner, since); err != nil {
+				return err
+			}
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+	return eventError(err)
+}
+
+// SplitSeries updates truncated series, deletes overrides of its occurrences started since till
+// and saves event, which continues the series
+func (pges *PgEventStorage) SplitSeries(ctx context.Context, series *models.Event, till time.Time, event *models.Event, messages []*models.OutboxMessage) error {
+	err := pges.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := updateEvent(ctx, tx, series.Id.String(), series); err != nil {
+			return err
+		}
+		if _, err := deleteOverrides(ctx, tx, series.Id.String(), series.Owner, &till); err != nil {
+			return err
+		}
+		if err := insertEvent(ctx, tx, event); err != nil {
+			return err
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+	return eventError(err)
+}
+
+func (pges *PgEventStorage) GetEventsBySeriesIdOwner(ctx context.Context, seriesId, owner string) ([]*models.Event, error) {
+	query := `
+		SELECT * FROM events WHERE series_id=$1 AND owner=$2 AND id<>$1 ORDER BY original_start_time
+`
+	var events []*models.Event
+	err := pges.db.SelectContext(ctx, &events, query, seriesId, owner)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (pges *PgEventStorage) GetEventBySeriesIdOwnerStartDate(ctx context.Context, seriesId, owner string, startTime *time.Time) (*models.Event, error) {
+	query := `
+		SELECT * FROM events WHERE series_id=$1 AND owner=$2 AND original_start_time=$3
+`
+	event := &models.Event{}
+	err := pges.db.GetContext(ctx, event, query, seriesId, owner, startTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+func (pges *PgEventStorage) DeleteEventsBySeriesIdOwnerStartDate(ctx context.Context, seriesId, owner string, startTime *time.Time) (int64, error) {
+	var c int64
+	err := pges.inTx(ctx, func(tx *sqlx.Tx) (err error) {
+		c, err = deleteOverrides(ctx, tx, seriesId, owner, startTime)
+		return err
+	})
+	return c, err
+}
+
+// GetNotifiedReminders returns offsets of notified reminders of event or occurrence started at startTime
+func (pges *PgEventStorage) GetNotifiedReminders(ctx context.Context, id string, startTime time.Time) (models.Reminders, error) {
+	query := `
+		SELECT reminder FROM reminder_notifications WHERE event_id=$1 AND start_time=$2
+`
+	var seconds []int64
+	err := pges.db.SelectContext(ctx, &seconds, query, id, startTime)
+	if err != nil {
+		return nil, err
+	}
+	reminders := make(models.Reminders, 0, len(seconds))
+	for _, s := range seconds {
+		reminders = append(reminders, time.Duration(s)*time.Second)
+	}
+	return reminders, nil
+}
+
+func (pges *PgEventStorage) MarkReminderNotified(ctx context.Context, id string, startTime time.Time, reminder time.Duration, messages []*models.OutboxMessage) error {
+	query := `
+		INSERT INTO reminder_notifications(event_id, start_time, reminder) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING
+`
+	return pges.inTx(ctx, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, query, id, startTime, int64(reminder/time.Second))
+		if err != nil {
+			return err
+		}
+		// reminder is already notified, e.g. by another replica
+		if c, _ := res.RowsAffected(); c == 0 {
+			return nil
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+}
+
+// GetPendingOutboxMessages returns the oldest messages, which aren't marked sent
+func (pges *PgEventStorage) GetPendingOutboxMessages(ctx context.Context, limit int) ([]*models.OutboxMessage, error) {
+	query := `
+		SELECT * FROM outbox WHERE sent_at IS NULL ORDER BY created_at LIMIT $1
+`
+	var messages []*models.OutboxMessage
+	err := pges.db.SelectContext(ctx, &messages, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (pges *PgEventStorage) MarkOutboxMessageSent(ctx context.Context, id string, sentAt time.Time) error {
+	query := `
+		UPDATE outbox SET sent_at=$2 WHERE id=$1
+`
+	_, err := pges.db.ExecContext(ctx, query, id, sentAt)
+	return err
+}
+
+func (pges *PgEventStorage) DeleteOutboxMessagesSentBefore(ctx context.Context, date time.Time) (int64, error) {
+	query := `
+		DELETE FROM outbox WHERE sent_at<$1
+`
+	res, err := pges.db.ExecContext(ctx, query, date)
+	if err != nil {
+		return 0, err
+	}
+	c, _ := res.RowsAffected()
+	return c, nil
+}
+
+func (pges *PgEventStorage) IsMessageProcessed(ctx context.Context, id string) (bool, error) {
+	query := `
+		SELECT count(*) FROM processed_messages WHERE message_id=$1
+`
+	var c int
+	if err := pges.db.GetContext(ctx, &c, query, id); err != nil {
+		return false, err
+	}
+	return c > 0, nil
+}
+
+func (pges *PgEventStorage) MarkMessageProcessed(ctx context.Context, id string, processedAt time.Time) error {
+	query := `
+		INSERT INTO processed_messages(message_id, processed_at) VALUES ($1, $2) ON CONFLICT DO NOTHING
+`
+	_, err := pges.db.ExecContext(ctx, query, id, processedAt)
+	return err
+}
+
+func (pges *PgEventStorage) DeleteMessagesProcessedBefore(ctx context.Context, date time.Time) (int64, error) {
+	query := `
+		DELETE FROM processed_messages WHERE processed_at<$1
+`
+	res, err := pges.db.ExecContext(ctx, query, date)
+	if err != nil {
+		return 0, err
+	}
+	c, _ := res.RowsAffected()
+	return c, nil
+}
+
+func (pges *PgEventStorage) SaveFeedToken(ctx context.Context, token *models.FeedToken) error {
 	query := `
-		UPDATE events SET title=$3, text=$4, start_time=$5, end_time=$6 WHERE id=$1 AND owner=$2
+		INSERT INTO feed_tokens(token, owner, created_at) VALUES ($1, $2, $3)
 `
-	res, err := pges.db.ExecContext(ctx, query, id, event.Owner, event.Title, event.Text, event.StartTime, event.EndTime)
+	_, err := pges.db.ExecContext(ctx, query, token.Token, token.Owner, token.CreatedAt)
+	return err
+}
+
+func (pges *PgEventStorage) GetFeedToken(ctx context.Context, token string) (*models.FeedToken, error) {
+	query := `
+		SELECT * FROM feed_tokens WHERE token=$1
+`
+	feedToken := &models.FeedToken{}
+	err := pges.db.GetContext(ctx, feedToken, query, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return feedToken, nil
+}
+
+func (pges *PgEventStorage) GetFeedTokensByOwner(ctx context.Context, owner string) ([]*models.FeedToken, error) {
+	query := `
+		SELECT * FROM feed_tokens WHERE owner=$1 ORDER BY created_at
+`
+	var tokens []*models.FeedToken
+	err := pges.db.SelectContext(ctx, &tokens, query, owner)
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (pges *PgEventStorage) DeleteFeedTokenByTokenOwner(ctx context.Context, token, owner string) error {
+	query := `
+		DELETE FROM feed_tokens WHERE token=$1 AND owner=$2
+	`
+	res, err := pges.db.ExecContext(ctx, query, token, owner)
+	if res != nil {
+		if c, _ := res.RowsAffected(); c == 0 {
+			return errors.ErrNotFound
+		}
+	}
+	return err
+}
+
+func (pges *PgEventStorage) SaveGrant(ctx context.Context, grant *models.Grant) error {
+	query := `
+		INSERT INTO grants(owner, grantee, role, created_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (owner, grantee) DO UPDATE SET role=excluded.role, created_at=excluded.created_at
+`
+	_, err := pges.db.ExecContext(ctx, query, grant.Owner, grant.Grantee, grant.Role, grant.CreatedAt)
+	return err
+}
+
+func (pges *PgEventStorage) GetGrant(ctx context.Context, owner, grantee string) (*models.Grant, error) {
+	query := `
+		SELECT * FROM grants WHERE owner=$1 AND grantee=$2
+`
+	grant := &models.Grant{}
+	err := pges.db.GetContext(ctx, grant, query, owner, grantee)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (pges *PgEventStorage) GetGrantsByOwner(ctx context.Context, owner string) ([]*models.Grant, error) {
+	query := `
+		SELECT * FROM grants WHERE owner=$1 ORDER BY grantee
+`
+	var grants []*models.Grant
+	err := pges.db.SelectContext(ctx, &grants, query, owner)
+	if err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+func (pges *PgEventStorage) DeleteGrantByOwnerGrantee(ctx context.Context, owner, grantee string) error {
+	query := `
+		DELETE FROM grants WHERE owner=$1 AND grantee=$2
+	`
+	res, err := pges.db.ExecContext(ctx, query, owner, grantee)
 	if res != nil {
 		if c, _ := res.RowsAffected(); c == 0 {
 			return errors.ErrNotFound
@@ -132,14 +421,182 @@ func (pges *PgEventStorage) UpdateEventByIdOwner(ctx context.Context, id string,
 	return err
 }
 
-func (pges *PgEventStorage) MarkEventNotified(ctx context.Context, id string) error {
+func (pges *PgEventStorage) SaveAttendee(ctx context.Context, eventId string, attendee *models.Attendee) error {
 	query := `
-		UPDATE events SET notified=true WHERE id=$1
+		INSERT INTO attendees(event_id, name, status) VALUES ($1, $2, $3)
+		ON CONFLICT (event_id, name) DO NOTHING
 `
-	_, err := pges.db.ExecContext(ctx, query, id)
+	_, err := pges.db.ExecContext(ctx, query, eventId, attendee.Name, attendee.Status)
 	return err
 }
 
+func (pges *PgEventStorage) GetAttendeesByEventId(ctx context.Context, eventId string) ([]*models.Attendee, error) {
+	query := `
+		SELECT name, status FROM attendees WHERE event_id=$1 ORDER BY name
+`
+	var attendees []*models.Attendee
+	err := pges.db.SelectContext(ctx, &attendees, query, eventId)
+	if err != nil {
+		return nil, err
+	}
+	return attendees, nil
+}
+
+// UpdateAttendeeStatus sets status of attendee, id of override or series can be used for recurring events
+func (pges *PgEventStorage) UpdateAttendeeStatus(ctx context.Context, eventId, name string, status models.AttendeeStatus) error {
+	query := `
+		UPDATE attendees SET status=$3
+		WHERE name=$2 AND event_id=(SELECT COALESCE(series_id, id) FROM events WHERE id=$1)
+	`
+	res, err := pges.db.ExecContext(ctx, query, eventId, name, status)
+	if res != nil {
+		if c, _ := res.RowsAffected(); c == 0 {
+			return errors.ErrNotFound
+		}
+	}
+	return err
+}
+
+// GetEventsByAttendeeStartDate returns events, which attendee is invited to and hasn't declined
+func (pges *PgEventStorage) GetEventsByAttendeeStartDate(ctx context.Context, attendee string, startTime *time.Time) ([]*models.Event, error) {
+	query := `
+		SELECT e.* FROM events e JOIN attendees a ON a.event_id=COALESCE(e.series_id, e.id)
+		WHERE a.name=$1 AND a.status<>'declined'
+		  AND (e.start_time>=$2 OR e.recurrence<>'' OR e.original_start_time>=$2)
+`
+	var events []*models.Event
+	err := pges.db.SelectContext(ctx, &events, query, attendee, startTime)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// AcquireLease inserts lease, or takes it over if it's expired at now or held by the same holder
+func (pges *PgEventStorage) AcquireLease(ctx context.Context, lease *models.Lease, now time.Time) (bool, error) {
+	query := `
+		INSERT INTO leases(name, holder, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET holder=excluded.holder, expires_at=excluded.expires_at
+		WHERE leases.holder=excluded.holder OR leases.expires_at<$4
+`
+	res, err := pges.db.ExecContext(ctx, query, lease.Name, lease.Holder, lease.ExpiresAt, now)
+	if err != nil {
+		return false, err
+	}
+	c, _ := res.RowsAffected()
+	return c > 0, nil
+}
+
+func (pges *PgEventStorage) ReleaseLease(ctx context.Context, name, holder string) error {
+	query := `
+		DELETE FROM leases WHERE name=$1 AND holder=$2
+`
+	_, err := pges.db.ExecContext(ctx, query, name, holder)
+	return err
+}
+
+// inTx runs f in transaction, which is committed if f succeeds
+func (pges *PgEventStorage) inTx(ctx context.Context, f func(tx *sqlx.Tx) error) error {
+	tx, err := pges.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertEvent inserts event in transaction
+func insertEvent(ctx context.Context, tx *sqlx.Tx, event *models.Event) error {
+	query := `
+		INSERT INTO events(id, owner, title, text, start_time, end_time, recurrence, series_id, original_start_time, cancelled, uid, transparency, all_day, time_zone, reminders, series_end)
+		VALUES (:id, :owner, :title, :text, :start_time, :end_time, :recurrence, :series_id, :original_start_time, :cancelled, :uid, :transparency, :all_day, :time_zone, :reminders, :series_end)
+	`
+	_, err := tx.NamedExecContext(ctx, query, map[string]interface{}{
+		"id":                  event.Id.String(),
+		"owner":               event.Owner,
+		"title":               event.Title,
+		"text":                event.Text,
+		"start_time":          event.StartTime,
+		"end_time":            event.EndTime,
+		"recurrence":          event.Recurrence,
+		"series_id":           event.SeriesId,
+		"original_start_time": event.OriginalStartTime,
+		"cancelled":           event.Cancelled,
+		"uid":                 event.Uid,
+		"transparency":        event.Transparency,
+		"all_day":             event.AllDay,
+		"time_zone":           event.TimeZone,
+		"reminders":           event.Reminders,
+		"series_end":          event.SeriesEnd,
+	})
+	return err
+}
+
+// updateEvent updates owner's event in transaction, notified reminders of the event are forgotten
+// if it's moved, so it's reminded again
+func updateEvent(ctx context.Context, tx *sqlx.Tx, id string, event *models.Event) error {
+	reset := `
+		DELETE FROM reminder_notifications
+		WHERE event_id=$1 AND EXISTS(SELECT 1 FROM events WHERE id=$1 AND owner=$2 AND start_time<>$3)
+`
+	if _, err := tx.ExecContext(ctx, reset, id, event.Owner, event.StartTime); err != nil {
+		return err
+	}
+	query := `
+		UPDATE events SET title=$3, text=$4, start_time=$5, end_time=$6, recurrence=$7, cancelled=$8, transparency=$9, all_day=$10, time_zone=$11, reminders=$12, series_end=$13
+		WHERE id=$1 AND owner=$2
+`
+	res, err := tx.ExecContext(ctx, query, id, event.Owner, event.Title, event.Text, event.StartTime, event.EndTime, event.Recurrence, event.Cancelled,
+		event.Transparency, event.AllDay, event.TimeZone, event.Reminders, event.SeriesEnd)
+	if err != nil {
+		return err
+	}
+	if c, _ := res.RowsAffected(); c == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// deleteOverrides deletes overrides of series occurrences started since startTime in transaction
+func deleteOverrides(ctx context.Context, tx *sqlx.Tx, seriesId, owner string, startTime *time.Time) (int64, error) {
+	query := `
+		DELETE FROM events WHERE series_id=$1 AND owner=$2 AND original_start_time>=$3
+	`
+	res, err := tx.ExecContext(ctx, query, seriesId, owner, startTime)
+	if err != nil {
+		return 0, err
+	}
+	c, _ := res.RowsAffected()
+	return c, nil
+}
+
+// saveOutboxMessages inserts messages in transaction of the change, which caused them
+func saveOutboxMessages(ctx context.Context, tx *sqlx.Tx, messages []*models.OutboxMessage) error {
+	query := `
+		INSERT INTO outbox(id, payload, created_at) VALUES ($1, $2, $3)
+`
+	for _, m := range messages {
+		if _, err := tx.ExecContext(ctx, query, m.Id, m.Payload, m.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (pges *PgEventStorage) Close(ctx context.Context) {
 	_ = pges.db.Close()
 }
+
+// exclusionViolation is SQLSTATE of overlapping events rejected by events_owner_time_excl constraint
+const exclusionViolation = "23P01"
+
+// eventError converts violation of overlap constraint to domain error
+func eventError(err error) error {
+	if pgErr, ok := err.(pgx.PgError); ok && pgErr.Code == exclusionViolation {
+		return errors.ErrOverlaping
+	}
+	return err
+}
@@ -4,13 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/pkg/errors"
 	"github.com/streadway/amqp"
 	"log"
+	"sync"
 )
 
 type RabbitMq struct {
 	ch   *amqp.Channel
 	conn *amqp.Connection
+	// mu serializes publishing, so every message waits for its own confirmation
+	mu        sync.Mutex
+	confirms  chan amqp.Confirmation
+	published uint64
 }
 
 func NewRabbitMqQueue(url string) (*RabbitMq, error) {
@@ -23,8 +29,13 @@ func NewRabbitMqQueue(url string) (*RabbitMq, error) {
 	if err != nil {
 		return nil, err
 	}
+	// broker confirms published messages, when it has taken responsibility for them
+	if err := ch.Confirm(false); err != nil {
+		return nil, err
+	}
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation, 1))
 
-	return &RabbitMq{ch: ch, conn: conn}, nil
+	return &RabbitMq{ch: ch, conn: conn, confirms: confirms}, nil
 }
 
 func (r *RabbitMq) DeclareQueue(ctx context.Context, qName string, durable bool) error {
@@ -71,24 +82,53 @@ func (r *RabbitMq) SetQos(ctx context.Context, prefetchCount, prefetchSize int,
 	)
 }
 
-func (r *RabbitMq) SendTaskToQueue(ctx context.Context, exchange, routingKey string, event *models.Event) error {
-	jsonBody, err := json.Marshal(event)
+// SendTaskToQueue publishes persistent message and waits for publisher confirmation of broker,
+// id of outbox message is sent as MessageId
+func (r *RabbitMq) SendTaskToQueue(ctx context.Context, exchange, routingKey string, notification *models.Notification) error {
+	jsonBody, err := json.Marshal(notification)
 	if err != nil {
-		log.Printf("can't marshal to JSON  `%v`: %s", event, err)
+		log.Printf("can't marshal to JSON  `%v`: %s", notification, err)
 		return err
 	}
-	return r.ch.Publish(
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err = r.ch.Publish(
 		exchange,
 		routingKey,
 		false,
 		false,
 		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        jsonBody,
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    notification.MessageId.String(),
+			Body:         jsonBody,
 		})
+	if err != nil {
+		return err
+	}
+	// delivery tags of confirm mode channel are numbers of published messages
+	r.published++
+	for {
+		select {
+		case c, ok := <-r.confirms:
+			if !ok {
+				return errors.New("channel is closed before publishing is confirmed")
+			}
+			if c.DeliveryTag < r.published {
+				// confirmation of message, which wasn't waited for
+				continue
+			}
+			if !c.Ack {
+				return errors.Errorf("publishing of message `%s` is rejected by broker", notification.MessageId)
+			}
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
 }
 
-func (r *RabbitMq) ConsumeTasksFromQueue(ctx context.Context, qName, consumer string, autoAck bool, task func(ctx context.Context, event *models.Event) error) error {
+func (r *RabbitMq) ConsumeTasksFromQueue(ctx context.Context, qName, consumer string, autoAck bool, task func(ctx context.Context, notification *models.Notification) error) error {
 	msgs, err := r.ch.Consume(
 		qName,
 		consumer,
@@ -105,13 +145,13 @@ func (r *RabbitMq) ConsumeTasksFromQueue(ctx context.Context, qName, consumer st
 	forever := make(chan bool)
 	go func() {
 		for d := range msgs {
-			e := &models.Event{}
+			n := &models.Notification{}
 			log.Printf("Received a message: %s", d.Body)
-			err = json.Unmarshal(d.Body, e)
+			err = json.Unmarshal(d.Body, n)
 			if err != nil {
-				log.Printf("can't unmarshal from JSON  `%v`: %s", e, err)
+				log.Printf("can't unmarshal from JSON  `%v`: %s", n, err)
 			}
-			if task(ctx, e) == nil {
+			if task(ctx, n) == nil {
 				_ = d.Ack(false)
 			}
 		}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/Brialius/calendar/internal/domain/models"
 	"io"
+	"time"
 )
 
 type SendToStream struct {
@@ -15,11 +16,41 @@ func NewSendToStream(out io.Writer) (*SendToStream, error) {
 	return &SendToStream{out: out}, nil
 }
 
-func (s *SendToStream) SendEvent(ctx context.Context, event *models.Event) error {
+func (s *SendToStream) SendNotification(ctx context.Context, notification *models.Notification) error {
 	senderEventCounter.Inc()
-	_, err := fmt.Fprintf(s.out, "Send notification to `%s`: %s\n", event.Owner, event.Id)
+	_, err := fmt.Fprintf(s.out, "Send notification to `%s`: %s\n", notification.Recipient, render(notification))
 	if err != nil {
 		senderEventErrorCounter.Inc()
 	}
 	return err
 }
+
+// render describes notification of every type, notifications without type are reminders
+func render(n *models.Notification) string {
+	switch n.Type {
+	case models.NotificationCreated:
+		return fmt.Sprintf("event `%s` (%s) is created for %s", n.Title, n.Id, period(n.Event))
+	case models.NotificationUpdated:
+		if n.Before == nil {
+			return fmt.Sprintf("event `%s` (%s) is updated, it's %s", n.Title, n.Id, period(n.Event))
+		}
+		return fmt.Sprintf("event `%s` (%s) is updated, it's moved from %s to %s", n.Title, n.Id, period(n.Before), period(n.Event))
+	case models.NotificationCancelled:
+		return fmt.Sprintf("event `%s` (%s) for %s is cancelled", n.Title, n.Id, period(n.Event))
+	}
+	if n.Reminder == 0 {
+		return fmt.Sprintf("event `%s` (%s) starts now, it's %s", n.Title, n.Id, period(n.Event))
+	}
+	return fmt.Sprintf("event `%s` (%s) starts in %s, it's %s", n.Title, n.Id, n.Reminder, period(n.Event))
+}
+
+// period formats times of event, all-day events are shown as dates
+func period(e *models.Event) string {
+	if e.StartTime == nil || e.EndTime == nil {
+		return "unknown time"
+	}
+	if e.AllDay {
+		return fmt.Sprintf("%s - %s", e.StartTime.Format("2006-01-02"), e.EndTime.AddDate(0, 0, -1).Format("2006-01-02"))
+	}
+	return fmt.Sprintf("%s - %s", e.StartTime.Format(time.RFC3339), e.EndTime.Format(time.RFC3339))
+}
@@ -0,0 +1,39 @@
+package mainsender
+
+import (
+	"bytes"
+	"context"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSendToStream_SendNotification(t *testing.T) {
+	start := time.Date(2030, 1, 10, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	moved, movedEnd := start.Add(2*time.Hour), end.Add(2*time.Hour)
+	event := &models.Event{Title: "meeting", StartTime: &start, EndTime: &end}
+	updated := &models.Event{Title: "meeting", StartTime: &moved, EndTime: &movedEnd}
+
+	for _, tc := range []struct {
+		notification *models.Notification
+		expected     string
+	}{
+		{&models.Notification{Event: event, Recipient: "user", Reminder: 15 * time.Minute}, "starts in 15m0s"},
+		{&models.Notification{Event: event, Recipient: "user", Type: models.NotificationReminder}, "starts now"},
+		{&models.Notification{Event: event, Recipient: "user", Type: models.NotificationCreated}, "is created for 2030-01-10T10:00:00Z"},
+		{&models.Notification{Event: updated, Recipient: "user", Type: models.NotificationUpdated, Before: event},
+			"moved from 2030-01-10T10:00:00Z - 2030-01-10T11:00:00Z to 2030-01-10T12:00:00Z"},
+		{&models.Notification{Event: event, Recipient: "user", Type: models.NotificationCancelled, Before: event}, "is cancelled"},
+	} {
+		out := &bytes.Buffer{}
+		s, _ := NewSendToStream(out)
+		if err := s.SendNotification(context.Background(), tc.notification); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), tc.expected) {
+			t.Errorf("expected `%s` in %q", tc.expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,669 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemEventStorage keeps events in process memory, it's safe for concurrent use
+type MemEventStorage struct {
+	mu     sync.RWMutex
+	events map[uuid.UUID]*models.Event
+	// notifiedReminders holds reminders of events and occurrences indexed by id and start time
+	notifiedReminders map[uuid.UUID]map[int64]models.Reminders
+	feedTokens        map[string]*models.FeedToken
+	// grants are indexed by owner and grantee
+	grants map[[2]string]*models.Grant
+	// attendees hold statuses of invited users by id of event or series
+	attendees map[uuid.UUID]map[string]models.AttendeeStatus
+	outbox    map[uuid.UUID]*models.OutboxMessage
+	leases    map[string]*models.Lease
+	// processedMessages hold processing times of messages sent by sender
+	processedMessages map[uuid.UUID]time.Time
+}
+
+func NewMemEventStorage() (*MemEventStorage, error) {
+	return &MemEventStorage{
+		events:            make(map[uuid.UUID]*models.Event),
+		notifiedReminders: make(map[uuid.UUID]map[int64]models.Reminders),
+		feedTokens:        make(map[string]*models.FeedToken),
+		grants:            make(map[[2]string]*models.Grant),
+		attendees:         make(map[uuid.UUID]map[string]models.AttendeeStatus),
+		outbox:            make(map[uuid.UUID]*models.OutboxMessage),
+		leases:            make(map[string]*models.Lease),
+		processedMessages: make(map[uuid.UUID]time.Time),
+	}, nil
+}
+
+func (mes *MemEventStorage) SaveEvent(ctx context.Context, event *models.Event, messages []*models.OutboxMessage) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	if err := mes.saveEvent(event); err != nil {
+		return err
+	}
+	mes.saveOutboxMessages(messages)
+	return nil
+}
+
+func (mes *MemEventStorage) GetEventByIdOwner(ctx context.Context, id, owner string) (*models.Event, error) {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	e, ok := mes.getEvent(id)
+	if !ok || e.Owner != owner {
+		return nil, errors.ErrNotFound
+	}
+	return copyEvent(e), nil
+}
+
+func (mes *MemEventStorage) GetEventByUidOwner(ctx context.Context, uid, owner string) (*models.Event, error) {
+	events := mes.filter(func(e *models.Event) bool {
+		return e.Owner == owner && e.Uid == uid
+	})
+	if len(events) == 0 {
+		return nil, errors.ErrNotFound
+	}
+	return events[0], nil
+}
+
+func (mes *MemEventStorage) GetEventsByOwnerStartDate(ctx context.Context, owner string, startTime *time.Time) ([]*models.Event, error) {
+	return mes.filter(func(e *models.Event) bool {
+		return e.Owner == owner && (!e.StartTime.Before(*startTime) || e.IsRecurring() ||
+			(e.OriginalStartTime != nil && !e.OriginalStartTime.Before(*startTime)))
+	}), nil
+}
+
+func (mes *MemEventStorage) GetEventsForNotification(ctx context.Context, startTime time.Time, period time.Duration) ([]*models.Event, error) {
+	till := startTime.Add(period)
+	return mes.filter(func(e *models.Event) bool {
+		if e.IsOverride() && e.OriginalStartTime != nil && !e.OriginalStartTime.After(till) {
+			// occurrence replaced by override lasts as long as its series
+			if series, ok := mes.events[*e.SeriesId]; ok && e.OriginalStartTime.Add(series.EndTime.Sub(*series.StartTime)).After(startTime) {
+				return true
+			}
+		}
+		if e.StartTime.After(till) {
+			return false
+		}
+		if e.IsRecurring() {
+			return e.SeriesEnd == nil || e.SeriesEnd.After(startTime)
+		}
+		return e.EndTime.After(startTime)
+	}), nil
+}
+
+func (mes *MemEventStorage) GetEventsCountByOwnerStartDateEndDate(ctx context.Context, owner string, startTime, endTime *time.Time) (int, error) {
+	events := mes.filter(func(e *models.Event) bool {
+		return e.Owner == owner && e.StartTime.Before(*endTime) && e.EndTime.After(*startTime)
+	})
+	return len(events), nil
+}
+
+func (mes *MemEventStorage) GetEventsByOwnerStartDateEndDate(ctx context.Context, owner string, startTime, endTime *time.Time) ([]*models.Event, error) {
+	return mes.filter(func(e *models.Event) bool {
+		return e.Owner == owner && ((!e.StartTime.After(*endTime) && (!e.EndTime.Before(*startTime) || e.IsRecurring())) ||
+			(e.OriginalStartTime != nil && !e.OriginalStartTime.After(*endTime)))
+	}), nil
+}
+
+func (mes *MemEventStorage) DeleteEventByIdOwner(ctx context.Context, id, owner string, messages []*models.OutboxMessage) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	e, ok := mes.getEvent(id)
+	if !ok || e.Owner != owner {
+		return errors.ErrNotFound
+	}
+	mes.deleteEvent(e.Id)
+	for oid, o := range mes.events {
+		if o.SeriesId != nil && *o.SeriesId == e.Id {
+			mes.deleteEvent(oid)
+		}
+	}
+	mes.saveOutboxMessages(messages)
+	return nil
+}
+
+func (mes *MemEventStorage) GetEventsBySeriesIdOwner(ctx context.Context, seriesId, owner string) ([]*models.Event, error) {
+	return mes.filter(func(e *models.Event) bool {
+		return e.Owner == owner && e.IsOverride() && e.SeriesId.String() == seriesId
+	}), nil
+}
+
+func (mes *MemEventStorage) GetEventBySeriesIdOwnerStartDate(ctx context.Context, seriesId, owner string, startTime *time.Time) (*models.Event, error) {
+	events := mes.filter(func(e *models.Event) bool {
+		return e.Owner == owner && e.SeriesId != nil && e.SeriesId.String() == seriesId &&
+			e.OriginalStartTime != nil && e.OriginalStartTime.Equal(*startTime)
+	})
+	if len(events) == 0 {
+		return nil, errors.ErrNotFound
+	}
+	return events[0], nil
+}
+
+func (mes *MemEventStorage) DeleteEventsBySeriesIdOwnerStartDate(ctx context.Context, seriesId, owner string, startTime *time.Time) (int64, error) {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	return mes.deleteOverrides(seriesId, owner, startTime), nil
+}
+
+func (mes *MemEventStorage) DeleteEventsOlderDateByIdOwner(ctx context.Context, date *time.Time, owner string) (int64, error) {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	var c int64
+	for id, e := range mes.events {
+		if e.Owner == owner && e.EndTime != nil && !e.EndTime.After(*date) && !e.IsRecurring() && e.SeriesId == nil {
+			mes.deleteEvent(id)
+			c++
+		}
+	}
+	return c, nil
+}
+
+func (mes *MemEventStorage) UpdateEventByIdOwner(ctx context.Context, id string, event *models.Event, messages []*models.OutboxMessage) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	if err := mes.updateEvent(id, event); err != nil {
+		return err
+	}
+	mes.saveOutboxMessages(messages)
+	return nil
+}
+
+// UpdateSeries updates series and deletes overrides of its occurrences started since, if it's set
+func (mes *MemEventStorage) UpdateSeries(ctx context.Context, series *models.Event, since *time.Time, messages []*models.OutboxMessage) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	if err := mes.updateEvent(series.Id.String(), series); err != nil {
+		return err
+	}
+	if since != nil {
+		mes.deleteOverrides(series.Id.String(), series.Owner, since)
+	}
+	mes.saveOutboxMessages(messages)
+	return nil
+}
+
+// SplitSeries updates truncated series, deletes overrides of its occurrences started since till
+// and saves event, which continues the series
+func (mes *MemEventStorage) SplitSeries(ctx context.Context, series *models.Event, till time.Time, event *models.Event, messages []*models.OutboxMessage) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	err := mes.inTx(func() error {
+		if err := mes.updateEvent(series.Id.String(), series); err != nil {
+			return err
+		}
+		mes.deleteOverrides(series.Id.String(), series.Owner, &till)
+		return mes.saveEvent(event)
+	})
+	if err != nil {
+		return err
+	}
+	mes.saveOutboxMessages(messages)
+	return nil
+}
+
+func (mes *MemEventStorage) GetNotifiedReminders(ctx context.Context, id string, startTime time.Time) (models.Reminders, error) {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	e, ok := mes.getEvent(id)
+	if !ok {
+		return nil, nil
+	}
+	return append(models.Reminders(nil), mes.notifiedReminders[e.Id][startTime.UnixNano()]...), nil
+}
+
+func (mes *MemEventStorage) MarkReminderNotified(ctx context.Context, id string, startTime time.Time, reminder time.Duration, messages []*models.OutboxMessage) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	e, ok := mes.getEvent(id)
+	if !ok {
+		return errors.ErrNotFound
+	}
+	if mes.notifiedReminders[e.Id] == nil {
+		mes.notifiedReminders[e.Id] = make(map[int64]models.Reminders)
+	}
+	notified := mes.notifiedReminders[e.Id][startTime.UnixNano()]
+	for _, r := range notified {
+		if r == reminder {
+			return nil
+		}
+	}
+	mes.notifiedReminders[e.Id][startTime.UnixNano()] = append(notified, reminder)
+	mes.saveOutboxMessages(messages)
+	return nil
+}
+
+// saveOutboxMessages keeps copies of messages, lock should be held by caller
+func (mes *MemEventStorage) saveOutboxMessages(messages []*models.OutboxMessage) {
+	for _, m := range messages {
+		mes.outbox[m.Id] = copyOutboxMessage(m)
+	}
+}
+
+// GetPendingOutboxMessages returns the oldest messages, which aren't marked sent
+func (mes *MemEventStorage) GetPendingOutboxMessages(ctx context.Context, limit int) ([]*models.OutboxMessage, error) {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	var res []*models.OutboxMessage
+	for _, m := range mes.outbox {
+		if m.SentAt == nil {
+			res = append(res, copyOutboxMessage(m))
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].CreatedAt.Before(*res[j].CreatedAt)
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (mes *MemEventStorage) MarkOutboxMessageSent(ctx context.Context, id string, sentAt time.Time) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	messageId, err := uuid.FromString(id)
+	if err != nil {
+		return err
+	}
+	if m, ok := mes.outbox[messageId]; ok {
+		m.SentAt = &sentAt
+	}
+	return nil
+}
+
+func (mes *MemEventStorage) DeleteOutboxMessagesSentBefore(ctx context.Context, date time.Time) (int64, error) {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	var deleted int64
+	for id, m := range mes.outbox {
+		if m.SentAt != nil && m.SentAt.Before(date) {
+			delete(mes.outbox, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// AcquireLease takes free or expired at now lease, or renews lease of the same holder
+func (mes *MemEventStorage) AcquireLease(ctx context.Context, lease *models.Lease, now time.Time) (bool, error) {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	if l, ok := mes.leases[lease.Name]; ok && l.Holder != lease.Holder && !l.ExpiresAt.Before(now) {
+		return false, nil
+	}
+	l := *lease
+	l.ExpiresAt = copyTime(lease.ExpiresAt)
+	mes.leases[lease.Name] = &l
+	return true, nil
+}
+
+func (mes *MemEventStorage) ReleaseLease(ctx context.Context, name, holder string) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	if l, ok := mes.leases[name]; ok && l.Holder == holder {
+		delete(mes.leases, name)
+	}
+	return nil
+}
+
+func copyOutboxMessage(message *models.OutboxMessage) *models.OutboxMessage {
+	m := *message
+	m.CreatedAt = copyTime(message.CreatedAt)
+	m.SentAt = copyTime(message.SentAt)
+	return &m
+}
+
+func (mes *MemEventStorage) IsMessageProcessed(ctx context.Context, id string) (bool, error) {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	messageId, err := uuid.FromString(id)
+	if err != nil {
+		return false, err
+	}
+	_, ok := mes.processedMessages[messageId]
+	return ok, nil
+}
+
+func (mes *MemEventStorage) MarkMessageProcessed(ctx context.Context, id string, processedAt time.Time) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	messageId, err := uuid.FromString(id)
+	if err != nil {
+		return err
+	}
+	if _, ok := mes.processedMessages[messageId]; !ok {
+		mes.processedMessages[messageId] = processedAt
+	}
+	return nil
+}
+
+func (mes *MemEventStorage) DeleteMessagesProcessedBefore(ctx context.Context, date time.Time) (int64, error) {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	var deleted int64
+	for id, processedAt := range mes.processedMessages {
+		if processedAt.Before(date) {
+			delete(mes.processedMessages, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (mes *MemEventStorage) SaveFeedToken(ctx context.Context, token *models.FeedToken) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	if _, ok := mes.feedTokens[token.Token]; ok {
+		return fmt.Errorf("feed token already exists")
+	}
+	t := *token
+	t.CreatedAt = copyTime(token.CreatedAt)
+	mes.feedTokens[token.Token] = &t
+	return nil
+}
+
+func (mes *MemEventStorage) GetFeedToken(ctx context.Context, token string) (*models.FeedToken, error) {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	t, ok := mes.feedTokens[token]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	c := *t
+	return &c, nil
+}
+
+func (mes *MemEventStorage) GetFeedTokensByOwner(ctx context.Context, owner string) ([]*models.FeedToken, error) {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	var tokens []*models.FeedToken
+	for _, t := range mes.feedTokens {
+		if t.Owner == owner {
+			c := *t
+			tokens = append(tokens, &c)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].CreatedAt.Before(*tokens[j].CreatedAt)
+	})
+	return tokens, nil
+}
+
+func (mes *MemEventStorage) DeleteFeedTokenByTokenOwner(ctx context.Context, token, owner string) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	t, ok := mes.feedTokens[token]
+	if !ok || t.Owner != owner {
+		return errors.ErrNotFound
+	}
+	delete(mes.feedTokens, token)
+	return nil
+}
+
+func (mes *MemEventStorage) Close(ctx context.Context) {}
+
+// getEvent should be called with mu held
+// saveEvent stores copy of new event, it should be called with mu held
+func (mes *MemEventStorage) saveEvent(event *models.Event) error {
+	if _, ok := mes.events[event.Id]; ok {
+		return fmt.Errorf("event `%s` already exists", event.Id)
+	}
+	for _, e := range mes.events {
+		if event.SeriesId != nil && e.SeriesId != nil && *e.SeriesId == *event.SeriesId &&
+			event.OriginalStartTime != nil && e.OriginalStartTime != nil && e.OriginalStartTime.Equal(*event.OriginalStartTime) {
+			return fmt.Errorf("occurrence `%s` of event `%s` is already overridden", event.OriginalStartTime, event.SeriesId)
+		}
+		if e.Owner == event.Owner && e.Uid == event.Uid {
+			return fmt.Errorf("event with uid `%s` already exists", event.Uid)
+		}
+	}
+	if mes.overlaps(event) {
+		return errors.ErrOverlaping
+	}
+	mes.events[event.Id] = copyEvent(event)
+	return nil
+}
+
+// updateEvent replaces stored owner's event with updated copy, notified reminders of the event are forgotten
+// if it's moved, so it's reminded again. It should be called with mu held
+func (mes *MemEventStorage) updateEvent(id string, event *models.Event) error {
+	e, ok := mes.getEvent(id)
+	if !ok || e.Owner != event.Owner {
+		return errors.ErrNotFound
+	}
+	updated := copyEvent(e)
+	updated.Title = event.Title
+	updated.Text = event.Text
+	updated.StartTime = copyTime(event.StartTime)
+	updated.EndTime = copyTime(event.EndTime)
+	updated.Recurrence = event.Recurrence
+	updated.Cancelled = event.Cancelled
+	updated.Transparency = event.Transparency
+	updated.AllDay = event.AllDay
+	updated.TimeZone = event.TimeZone
+	updated.Reminders = event.Reminders
+	updated.SeriesEnd = copyTime(event.SeriesEnd)
+	if mes.overlaps(updated) {
+		return errors.ErrOverlaping
+	}
+	if !updated.StartTime.Equal(*e.StartTime) {
+		delete(mes.notifiedReminders, e.Id)
+	}
+	mes.events[e.Id] = updated
+	return nil
+}
+
+// deleteOverrides deletes overrides of series occurrences started since startTime, it should be called with mu held
+func (mes *MemEventStorage) deleteOverrides(seriesId, owner string, startTime *time.Time) int64 {
+	var c int64
+	for id, e := range mes.events {
+		if e.Owner == owner && e.SeriesId != nil && e.SeriesId.String() == seriesId &&
+			e.OriginalStartTime != nil && !e.OriginalStartTime.Before(*startTime) {
+			mes.deleteEvent(id)
+			c++
+		}
+	}
+	return c
+}
+
+// deleteEvent deletes event together with its notified reminders and attendees, it should be called with mu held
+func (mes *MemEventStorage) deleteEvent(id uuid.UUID) {
+	delete(mes.events, id)
+	delete(mes.notifiedReminders, id)
+	delete(mes.attendees, id)
+}
+
+// inTx runs f, events, their notified reminders and attendees are restored if f fails,
+// it should be called with mu held. f only replaces or deletes entries of the maps, so their copies are enough
+func (mes *MemEventStorage) inTx(f func() error) error {
+	events := make(map[uuid.UUID]*models.Event, len(mes.events))
+	for id, e := range mes.events {
+		events[id] = e
+	}
+	notifiedReminders := make(map[uuid.UUID]map[int64]models.Reminders, len(mes.notifiedReminders))
+	for id, r := range mes.notifiedReminders {
+		notifiedReminders[id] = r
+	}
+	attendees := make(map[uuid.UUID]map[string]models.AttendeeStatus, len(mes.attendees))
+	for id, a := range mes.attendees {
+		attendees[id] = a
+	}
+	if err := f(); err != nil {
+		mes.events, mes.notifiedReminders, mes.attendees = events, notifiedReminders, attendees
+		return err
+	}
+	return nil
+}
+
+func (mes *MemEventStorage) getEvent(id string) (*models.Event, bool) {
+	uuidId, err := uuid.FromString(id)
+	if err != nil {
+		return nil, false
+	}
+	e, ok := mes.events[uuidId]
+	return e, ok
+}
+
+// filter returns copies of matched events ordered by start time
+func (mes *MemEventStorage) filter(match func(e *models.Event) bool) []*models.Event {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	var events []*models.Event
+	for _, e := range mes.events {
+		if match(e) {
+			events = append(events, copyEvent(e))
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].StartTime.Before(*events[j].StartTime)
+	})
+	return events
+}
+
+// overlaps checks stored single events and overrides of the owner like overlap constraint of sql storages,
+// it should be called with mu held
+func (mes *MemEventStorage) overlaps(event *models.Event) bool {
+	if event.IsRecurring() || event.Cancelled || !event.IsBlocking() {
+		return false
+	}
+	for _, e := range mes.events {
+		if e.Id != event.Id && e.Owner == event.Owner && !e.IsRecurring() && !e.Cancelled && e.IsBlocking() &&
+			e.StartTime.Before(*event.EndTime) && e.EndTime.After(*event.StartTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyEvent(event *models.Event) *models.Event {
+	e := *event
+	e.StartTime = copyTime(event.StartTime)
+	e.EndTime = copyTime(event.EndTime)
+	e.OriginalStartTime = copyTime(event.OriginalStartTime)
+	e.SeriesEnd = copyTime(event.SeriesEnd)
+	e.Reminders = append(models.Reminders(nil), event.Reminders...)
+	if event.SeriesId != nil {
+		seriesId := *event.SeriesId
+		e.SeriesId = &seriesId
+	}
+	return &e
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
+
+func (mes *MemEventStorage) SaveGrant(ctx context.Context, grant *models.Grant) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	g := *grant
+	g.CreatedAt = copyTime(grant.CreatedAt)
+	mes.grants[[2]string{grant.Owner, grant.Grantee}] = &g
+	return nil
+}
+
+func (mes *MemEventStorage) GetGrant(ctx context.Context, owner, grantee string) (*models.Grant, error) {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	g, ok := mes.grants[[2]string{owner, grantee}]
+	if !ok {
+		return nil, errors.ErrNotFound
+	}
+	c := *g
+	return &c, nil
+}
+
+func (mes *MemEventStorage) GetGrantsByOwner(ctx context.Context, owner string) ([]*models.Grant, error) {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	var grants []*models.Grant
+	for _, g := range mes.grants {
+		if g.Owner == owner {
+			c := *g
+			grants = append(grants, &c)
+		}
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		return grants[i].Grantee < grants[j].Grantee
+	})
+	return grants, nil
+}
+
+func (mes *MemEventStorage) DeleteGrantByOwnerGrantee(ctx context.Context, owner, grantee string) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	key := [2]string{owner, grantee}
+	if _, ok := mes.grants[key]; !ok {
+		return errors.ErrNotFound
+	}
+	delete(mes.grants, key)
+	return nil
+}
+
+func (mes *MemEventStorage) SaveAttendee(ctx context.Context, eventId string, attendee *models.Attendee) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	id, err := uuid.FromString(eventId)
+	if err != nil {
+		return err
+	}
+	if _, ok := mes.attendees[id]; !ok {
+		mes.attendees[id] = make(map[string]models.AttendeeStatus)
+	}
+	if _, ok := mes.attendees[id][attendee.Name]; !ok {
+		mes.attendees[id][attendee.Name] = attendee.Status
+	}
+	return nil
+}
+
+func (mes *MemEventStorage) GetAttendeesByEventId(ctx context.Context, eventId string) ([]*models.Attendee, error) {
+	mes.mu.RLock()
+	defer mes.mu.RUnlock()
+	id, err := uuid.FromString(eventId)
+	if err != nil {
+		return nil, err
+	}
+	var attendees []*models.Attendee
+	for name, status := range mes.attendees[id] {
+		attendees = append(attendees, &models.Attendee{Name: name, Status: status})
+	}
+	sort.Slice(attendees, func(i, j int) bool {
+		return attendees[i].Name < attendees[j].Name
+	})
+	return attendees, nil
+}
+
+// UpdateAttendeeStatus sets status of attendee, id of override or series can be used for recurring events
+func (mes *MemEventStorage) UpdateAttendeeStatus(ctx context.Context, eventId, name string, status models.AttendeeStatus) error {
+	mes.mu.Lock()
+	defer mes.mu.Unlock()
+	e, ok := mes.getEvent(eventId)
+	if !ok {
+		return errors.ErrNotFound
+	}
+	attendees := mes.attendees[e.SeriesOrEventId()]
+	if _, ok := attendees[name]; !ok {
+		return errors.ErrNotFound
+	}
+	attendees[name] = status
+	return nil
+}
+
+// GetEventsByAttendeeStartDate returns events, which attendee is invited to and hasn't declined
+func (mes *MemEventStorage) GetEventsByAttendeeStartDate(ctx context.Context, attendee string, startTime *time.Time) ([]*models.Event, error) {
+	return mes.filter(func(e *models.Event) bool {
+		status, ok := mes.attendees[e.SeriesOrEventId()][attendee]
+		return ok && status != models.StatusDeclined && (!e.StartTime.Before(*startTime) || e.IsRecurring() ||
+			(e.OriginalStartTime != nil && !e.OriginalStartTime.Before(*startTime)))
+	}), nil
+}
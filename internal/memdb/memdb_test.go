@@ -0,0 +1,56 @@
+package memdb
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"testing"
+	"time"
+)
+
+func TestMemEventStorage_DeleteEvents(t *testing.T) {
+	ctx := context.Background()
+	storage, _ := NewMemEventStorage()
+	start := time.Date(2019, 10, 7, 10, 0, 0, 0, time.UTC)
+	newEvent := func(recurrence string, st time.Time, seriesId *uuid.UUID) *models.Event {
+		id := uuid.NewV4()
+		et := st.Add(time.Hour)
+		e := &models.Event{Id: id, Uid: id.String(), Owner: "user", StartTime: &st, EndTime: &et,
+			Recurrence: recurrence, SeriesId: seriesId}
+		if seriesId != nil {
+			e.OriginalStartTime = &st
+		}
+		if err := storage.SaveEvent(ctx, e, nil); err != nil {
+			t.Fatalf("can't save event: %s", err)
+		}
+		if err := storage.SaveAttendee(ctx, id.String(), &models.Attendee{Name: "bob", Status: models.StatusAccepted}); err != nil {
+			t.Fatalf("can't save attendee: %s", err)
+		}
+		if err := storage.MarkReminderNotified(ctx, id.String(), st, 0, nil); err != nil {
+			t.Fatalf("can't mark reminder notified: %s", err)
+		}
+		return e
+	}
+	checkDeleted := func(e *models.Event) {
+		t.Helper()
+		if attendees, err := storage.GetAttendeesByEventId(ctx, e.Id.String()); err != nil || len(attendees) != 0 {
+			t.Errorf("attendees of deleted event should be deleted, got %v (%v)", attendees, err)
+		}
+		if notified, err := storage.GetNotifiedReminders(ctx, e.Id.String(), *e.StartTime); err != nil || len(notified) != 0 {
+			t.Errorf("notified reminders of deleted event should be deleted, got %v (%v)", notified, err)
+		}
+	}
+
+	series := newEvent("FREQ=DAILY;COUNT=5", start, nil)
+	first := newEvent("", start.AddDate(0, 0, 1), &series.Id)
+	second := newEvent("", start.AddDate(0, 0, 2), &series.Id)
+	if c, err := storage.DeleteEventsBySeriesIdOwnerStartDate(ctx, series.Id.String(), "user", second.StartTime); err != nil || c != 1 {
+		t.Fatalf("expected 1 deleted override, got %d (%v)", c, err)
+	}
+	checkDeleted(second)
+	if err := storage.DeleteEventByIdOwner(ctx, series.Id.String(), "user", nil); err != nil {
+		t.Fatalf("can't delete series: %s", err)
+	}
+	checkDeleted(series)
+	checkDeleted(first)
+}
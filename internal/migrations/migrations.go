@@ -0,0 +1,410 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	schema "github.com/Brialius/calendar/sql"
+	_ "github.com/jackc/pgx/stdlib"
+	"github.com/pkg/errors"
+	"hash/crc32"
+	"io/fs"
+	"log"
+	_ "modernc.org/sqlite"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// advisoryLockId identifies migration lock in PostgreSQL, so replicas started together wait for each other
+var advisoryLockId = int64(crc32.ChecksumIEEE([]byte("calendar_schema_migrations")))
+
+var fileNameRe = regexp.MustCompile(`^([0-9]+)_(.*)\.(up|down)\.sql$`)
+
+type migration struct {
+	version int64
+	name    string
+	up      string
+	down    string
+}
+
+// Status describes migration state of database
+type Status struct {
+	Version   int64
+	Dirty     bool
+	Available []int64
+}
+
+// Migrator applies embedded sql/ migrations, applied version is tracked in schema_migrations table
+// compatible with migrate/migrate tool, so both can be used on the same database
+type Migrator struct {
+	db         *sql.DB
+	dialect    string
+	migrations []*migration
+	ownDb      bool
+}
+
+// NewMigrator opens own connection to the storage, it should be closed with Close
+func NewMigrator(storageType, dsn string) (*Migrator, error) {
+	driver, err := driverName(storageType)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	m, err := NewDbMigrator(db, storageType)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	m.ownDb = true
+	return m, nil
+}
+
+// NewDbMigrator uses already opened database, Close doesn't close it
+func NewDbMigrator(db *sql.DB, storageType string) (*Migrator, error) {
+	files, err := migrationFiles(storageType)
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := loadMigrations(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: storageType, migrations: migrations}, nil
+}
+
+func driverName(storageType string) (string, error) {
+	switch storageType {
+	case "pg":
+		return "pgx", nil
+	case "sqlite":
+		return "sqlite", nil
+	}
+	return "", errors.Errorf("migrations are not supported for storage `%s`", storageType)
+}
+
+func migrationFiles(storageType string) (fs.FS, error) {
+	switch storageType {
+	case "pg":
+		return schema.Postgres, nil
+	case "sqlite":
+		return fs.Sub(schema.Sqlite, "sqlite")
+	}
+	return nil, errors.Errorf("migrations are not supported for storage `%s`", storageType)
+}
+
+func loadMigrations(files fs.FS) ([]*migration, error) {
+	entries, err := fs.ReadDir(files, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*migration)
+	for _, e := range entries {
+		parts := fileNameRe.FindStringSubmatch(e.Name())
+		if parts == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't parse migration version from `%s`", e.Name())
+		}
+		body, err := fs.ReadFile(files, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version, name: parts[2]}
+			byVersion[version] = m
+		}
+		if parts[3] == "up" {
+			m.up = string(body)
+		} else {
+			m.down = string(body)
+		}
+	}
+	migrations := make([]*migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+func (m *Migrator) Close() {
+	if m.ownDb {
+		_ = m.db.Close()
+	}
+}
+
+// Latest returns version of the last embedded migration
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].version
+}
+
+// Up applies all pending migrations
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the last applied migration
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		version, err := m.checkVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if version == 0 {
+			log.Println("Nothing to revert")
+			return nil
+		}
+		return m.migrate(ctx, conn, m.previous(version))
+	})
+}
+
+// To migrates database up or down to the given version, 0 reverts all migrations
+func (m *Migrator) To(ctx context.Context, target int64) error {
+	if target != 0 && m.find(target) == nil {
+		return errors.Errorf("migration %d doesn't exist", target)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		if _, err := m.checkVersion(ctx, conn); err != nil {
+			return err
+		}
+		return m.migrate(ctx, conn, target)
+	})
+}
+
+func (m *Migrator) Status(ctx context.Context) (*Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return nil, err
+	}
+	version, dirty, err := m.version(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	st := &Status{Version: version, Dirty: dirty}
+	for _, mg := range m.migrations {
+		st.Available = append(st.Available, mg.version)
+	}
+	return st, nil
+}
+
+// checkVersion returns current version, dirty database requires manual fix
+func (m *Migrator) checkVersion(ctx context.Context, conn *sql.Conn) (int64, error) {
+	version, dirty, err := m.version(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, errors.Errorf("database is dirty at version %d, fix it manually", version)
+	}
+	return version, nil
+}
+
+// migrate applies migrations one by one, each step in own transaction
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, target int64) error {
+	for {
+		done, err := m.step(ctx, conn, target)
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+func (m *Migrator) step(ctx context.Context, conn *sql.Conn, target int64) (bool, error) {
+	if err := m.begin(ctx, conn); err != nil {
+		return false, err
+	}
+	rollback := func(err error) (bool, error) {
+		_, _ = conn.ExecContext(ctx, "ROLLBACK")
+		return false, err
+	}
+	// version is re-read inside transaction, sqlite has no advisory locks
+	version, _, err := m.version(ctx, conn)
+	if err != nil {
+		return rollback(err)
+	}
+	var query string
+	var next int64
+	var name string
+	switch {
+	case version < target:
+		mg := m.next(version)
+		query, next, name = mg.up, mg.version, fmt.Sprintf("%d_%s.up", mg.version, mg.name)
+	case version > target:
+		mg := m.find(version)
+		if mg == nil {
+			return rollback(errors.Errorf("migration %d doesn't exist", version))
+		}
+		query, next, name = mg.down, m.previous(version), fmt.Sprintf("%d_%s.down", mg.version, mg.name)
+	default:
+		_, err := conn.ExecContext(ctx, "COMMIT")
+		return true, err
+	}
+	log.Printf("Applying migration %s...", name)
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return rollback(errors.Wrapf(err, "can't apply migration %s", name))
+	}
+	if err := m.setVersion(ctx, conn, next); err != nil {
+		return rollback(err)
+	}
+	if m.dialect == "sqlite" {
+		if err := checkForeignKeys(ctx, conn); err != nil {
+			return rollback(errors.Wrapf(err, "can't apply migration %s", name))
+		}
+	}
+	_, err = conn.ExecContext(ctx, "COMMIT")
+	return false, err
+}
+
+func (m *Migrator) next(version int64) *migration {
+	for _, mg := range m.migrations {
+		if mg.version > version {
+			return mg
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) previous(version int64) int64 {
+	var prev int64
+	for _, mg := range m.migrations {
+		if mg.version >= version {
+			break
+		}
+		prev = mg.version
+	}
+	return prev
+}
+
+func (m *Migrator) find(version int64) *migration {
+	for _, mg := range m.migrations {
+		if mg.version == version {
+			return mg
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, f func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if m.dialect == "pg" {
+		// session level lock, it's released on unlock or when connection is closed
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockId); err != nil {
+			return errors.Wrap(err, "can't acquire migration lock")
+		}
+		defer func() {
+			_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockId)
+		}()
+	}
+	if m.dialect == "sqlite" {
+		restore, err := disableForeignKeys(ctx, conn)
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return f(conn)
+}
+
+// disableForeignKeys switches off foreign keys of sqlite connection, so tables can be rebuilt without
+// cascade deletes of referencing rows. They can't be switched inside transaction, so they're disabled
+// for all steps and checked before commit of each one. Returned function restores previous state
+func disableForeignKeys(ctx context.Context, conn *sql.Conn) (func(), error) {
+	var enabled bool
+	if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&enabled); err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return func() {}, nil
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys=OFF"); err != nil {
+		return nil, err
+	}
+	return func() {
+		_, _ = conn.ExecContext(context.Background(), "PRAGMA foreign_keys=ON")
+	}, nil
+}
+
+// checkForeignKeys fails if sqlite migration has left rows referencing missing ones
+func checkForeignKeys(ctx context.Context, conn *sql.Conn) error {
+	rows, err := conn.QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if rows.Next() {
+		var table string
+		var rowId sql.NullInt64
+		var parent string
+		var fkId int64
+		if err := rows.Scan(&table, &rowId, &parent, &fkId); err != nil {
+			return err
+		}
+		return errors.Errorf("row %d of `%s` references missing row of `%s`", rowId.Int64, table, parent)
+	}
+	return rows.Err()
+}
+
+func (m *Migrator) begin(ctx context.Context, conn *sql.Conn) error {
+	query := "BEGIN"
+	if m.dialect == "sqlite" {
+		// take write lock at once, so concurrent processes can't read the same version
+		query = "BEGIN IMMEDIATE"
+	}
+	_, err := conn.ExecContext(ctx, query)
+	return err
+}
+
+func (m *Migrator) ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (version bigint not null primary key, dirty boolean not null)
+`)
+	return err
+}
+
+func (m *Migrator) version(ctx context.Context, conn *sql.Conn) (int64, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+func (m *Migrator) setVersion(ctx context.Context, conn *sql.Conn, version int64) error {
+	if _, err := conn.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	_, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations(version, dirty) VALUES ($1, false)`, version)
+	return err
+}
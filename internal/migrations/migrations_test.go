@@ -0,0 +1,372 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewMigrator("sqlite", filepath.Join(t.TempDir(), "calendar.db"))
+	if err != nil {
+		t.Fatalf("can't create migrator: %s", err)
+	}
+	defer m.Close()
+
+	checkVersion := func(expected int64) {
+		t.Helper()
+		st, err := m.Status(ctx)
+		if err != nil {
+			t.Fatalf("can't get status: %s", err)
+		}
+		if st.Version != expected || st.Dirty {
+			t.Errorf("expected version %d, got %d (dirty: %t)", expected, st.Version, st.Dirty)
+		}
+	}
+
+	checkVersion(0)
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("can't apply migrations: %s", err)
+	}
+	checkVersion(m.Latest())
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("second up should be no-op: %s", err)
+	}
+	if err := m.Down(ctx); err != nil {
+		t.Fatalf("can't revert migration: %s", err)
+	}
+	checkVersion(m.previous(m.Latest()))
+	if err := m.To(ctx, 0); err != nil {
+		t.Fatalf("can't revert all migrations: %s", err)
+	}
+	checkVersion(0)
+	if err := m.To(ctx, 100500); err == nil {
+		t.Error("migration to unknown version should fail")
+	}
+}
+
+var (
+	createTableRe = regexp.MustCompile(`(?is)create table (\w+) \((.*?)\n\);`)
+	columnFkRe    = regexp.MustCompile(`(?i)(\w+) UUID[^,]*?references (\w+) \((\w+)\) on delete (\w+)`)
+	addColumnFkRe = regexp.MustCompile(`(?is)alter table (\w+)\s+add (\w+) UUID references (\w+) \((\w+)\) on delete (\w+)`)
+	dropTableRe   = regexp.MustCompile(`(?i)drop table (?:if exists )?(\w+)`)
+
+	commentRe       = regexp.MustCompile(`--[^\n]*`)
+	createColumnsRe = regexp.MustCompile(`(?is)^create table (\w+) \((.*)\)$`)
+	alterTableRe    = regexp.MustCompile(`(?is)^alter table (\w+)\s+(.*)$`)
+	clauseSepRe     = regexp.MustCompile(`,\s*\n`)
+	addColumnRe     = regexp.MustCompile(`(?is)^add (\w+) (.*)$`)
+	alterColumnRe   = regexp.MustCompile(`(?is)^alter column (\w+) (set not null|drop not null|set default (.*)|drop default|type .*)$`)
+	dropColumnRe    = regexp.MustCompile(`(?i)^drop column (\w+)$`)
+	notNullRe       = regexp.MustCompile(`(?i)\bnot null\b|\bprimary key\b`)
+	defaultRe       = regexp.MustCompile(`(?i)\bdefault ('[^']*'|[^\s,]+)`)
+)
+
+// column describes nullability and default value of table column
+type column struct {
+	notNull bool
+	dflt    string
+}
+
+func (c column) String() string {
+	return fmt.Sprintf("not null: %t, default: `%s`", c.notNull, c.dflt)
+}
+
+// newColumn parses definition of column, which follows its name
+func newColumn(definition string) column {
+	c := column{notNull: notNullRe.MatchString(definition)}
+	if d := defaultRe.FindStringSubmatch(definition); d != nil {
+		c.dflt = strings.ToLower(d[1])
+	}
+	return c
+}
+
+// postgresColumns replays statements of PostgreSQL migrations, which change columns of tables,
+// and returns the final columns indexed by `table.column`
+func postgresColumns(t *testing.T, migrations []*migration) map[string]column {
+	tables := make(map[string]map[string]column)
+	for _, mg := range migrations {
+		for _, stmt := range strings.Split(commentRe.ReplaceAllString(mg.up, ""), ";") {
+			stmt = strings.TrimSpace(stmt)
+			if c := createColumnsRe.FindStringSubmatch(stmt); c != nil {
+				tables[c[1]] = make(map[string]column)
+				for _, line := range strings.Split(c[2], "\n") {
+					line = strings.TrimSuffix(strings.TrimSpace(line), ",")
+					if line == "" || strings.HasPrefix(strings.ToLower(line), "primary key") {
+						continue
+					}
+					parts := strings.SplitN(line, " ", 2)
+					tables[c[1]][parts[0]] = newColumn(parts[1])
+				}
+				continue
+			}
+			if d := dropTableRe.FindStringSubmatch(stmt); d != nil {
+				delete(tables, d[1])
+				continue
+			}
+			a := alterTableRe.FindStringSubmatch(stmt)
+			if a == nil {
+				continue
+			}
+			columns := tables[a[1]]
+			for _, clause := range clauseSepRe.Split(a[2], -1) {
+				clause = strings.TrimSpace(clause)
+				switch lower := strings.ToLower(clause); {
+				case strings.HasPrefix(lower, "add constraint"), strings.HasPrefix(lower, "drop constraint"):
+				case addColumnRe.MatchString(clause):
+					c := addColumnRe.FindStringSubmatch(clause)
+					columns[c[1]] = newColumn(c[2])
+				case dropColumnRe.MatchString(clause):
+					delete(columns, dropColumnRe.FindStringSubmatch(clause)[1])
+				case alterColumnRe.MatchString(clause):
+					c := alterColumnRe.FindStringSubmatch(clause)
+					col := columns[c[1]]
+					switch action := strings.ToLower(c[2]); {
+					case action == "set not null":
+						col.notNull = true
+					case action == "drop not null":
+						col.notNull = false
+					case action == "drop default":
+						col.dflt = ""
+					case strings.HasPrefix(action, "set default"):
+						col.dflt = strings.ToLower(c[3])
+					}
+					columns[c[1]] = col
+				default:
+					t.Fatalf("unknown clause of %d_%s: %s", mg.version, mg.name, clause)
+				}
+			}
+		}
+	}
+	res := make(map[string]column)
+	for table, columns := range tables {
+		for name, c := range columns {
+			res[table+"."+name] = c
+		}
+	}
+	return res
+}
+
+// sqliteTables returns tables of migrated database
+func sqliteTables(t *testing.T, db *sql.DB) []string {
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name <> 'schema_migrations'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			t.Fatal(err)
+		}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// sqliteColumns returns columns of all tables of migrated database indexed by `table.column`
+func sqliteColumns(t *testing.T, db *sql.DB) map[string]column {
+	res := make(map[string]column)
+	for _, table := range sqliteTables(t, db) {
+		rows, err := db.Query(fmt.Sprintf("SELECT name, \"notnull\", dflt_value, pk FROM pragma_table_info('%s')", table))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for rows.Next() {
+			var name string
+			var notNull bool
+			var pk int
+			var dflt sql.NullString
+			if err := rows.Scan(&name, &notNull, &dflt, &pk); err != nil {
+				t.Fatal(err)
+			}
+			res[table+"."+name] = column{notNull: notNull || pk > 0, dflt: strings.ToLower(dflt.String)}
+		}
+		_ = rows.Close()
+	}
+	return res
+}
+
+// postgresForeignKeys collects foreign keys of tables created by PostgreSQL migrations,
+// which are not dropped by later ones
+func postgresForeignKeys(t *testing.T, migrations []*migration) map[string]bool {
+	fks := make(map[string]map[string]bool)
+	add := func(table, column, parent, parentColumn, onDelete string) {
+		if fks[table] == nil {
+			fks[table] = make(map[string]bool)
+		}
+		fks[table][fmt.Sprintf("%s.%s -> %s.%s on delete %s", table, column, parent, parentColumn, strings.ToLower(onDelete))] = true
+	}
+	for _, mg := range migrations {
+		for _, c := range createTableRe.FindAllStringSubmatch(mg.up, -1) {
+			for _, fk := range columnFkRe.FindAllStringSubmatch(c[2], -1) {
+				add(c[1], fk[1], fk[2], fk[3], fk[4])
+			}
+		}
+		for _, fk := range addColumnFkRe.FindAllStringSubmatch(mg.up, -1) {
+			add(fk[1], fk[2], fk[3], fk[4], fk[5])
+		}
+		for _, d := range dropTableRe.FindAllStringSubmatch(mg.up, -1) {
+			delete(fks, d[1])
+		}
+	}
+	res := make(map[string]bool)
+	for _, table := range fks {
+		for fk := range table {
+			res[fk] = true
+		}
+	}
+	return res
+}
+
+// sqliteForeignKeys returns foreign keys of all tables of migrated database
+func sqliteForeignKeys(t *testing.T, db *sql.DB) map[string]bool {
+	res := make(map[string]bool)
+	for _, table := range sqliteTables(t, db) {
+		rows, err := db.Query(fmt.Sprintf("SELECT \"table\", \"from\", \"to\", on_delete FROM pragma_foreign_key_list('%s')", table))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for rows.Next() {
+			var parent, column, parentColumn, onDelete string
+			if err := rows.Scan(&parent, &column, &parentColumn, &onDelete); err != nil {
+				t.Fatal(err)
+			}
+			res[fmt.Sprintf("%s.%s -> %s.%s on delete %s", table, column, parent, parentColumn, strings.ToLower(onDelete))] = true
+		}
+		_ = rows.Close()
+	}
+	return res
+}
+
+// TestMigrator_Dialects checks that sqlite migrations don't drift from PostgreSQL ones
+func TestMigrator_Dialects(t *testing.T) {
+	pg, err := NewDbMigrator(nil, "pg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewMigrator("sqlite", filepath.Join(t.TempDir(), "calendar.db"))
+	if err != nil {
+		t.Fatalf("can't create migrator: %s", err)
+	}
+	defer m.Close()
+
+	if len(pg.migrations) != len(m.migrations) {
+		t.Fatalf("expected %d sqlite migrations, got %d", len(pg.migrations), len(m.migrations))
+	}
+	for i, mg := range pg.migrations {
+		if s := m.migrations[i]; s.version != mg.version || s.name != mg.name || s.up == "" || s.down == "" {
+			t.Errorf("expected sqlite migration %d_%s with up and down, got %d_%s", mg.version, mg.name, s.version, s.name)
+		}
+	}
+
+	if err := m.Up(context.Background()); err != nil {
+		t.Fatalf("can't apply migrations: %s", err)
+	}
+	expected, got := postgresForeignKeys(t, pg.migrations), sqliteForeignKeys(t, m.db)
+	for fk := range expected {
+		if !got[fk] {
+			t.Errorf("sqlite foreign key `%s` is missing", fk)
+		}
+	}
+	for fk := range got {
+		if !expected[fk] {
+			t.Errorf("sqlite foreign key `%s` doesn't exist in PostgreSQL", fk)
+		}
+	}
+	if len(expected) == 0 {
+		t.Errorf("foreign keys of PostgreSQL migrations aren't found")
+	}
+
+	expectedColumns, gotColumns := postgresColumns(t, pg.migrations), sqliteColumns(t, m.db)
+	for name, c := range expectedColumns {
+		if got, ok := gotColumns[name]; !ok {
+			t.Errorf("sqlite column `%s` is missing", name)
+		} else if got != c {
+			t.Errorf("sqlite column `%s` should be %s, got %s", name, c, got)
+		}
+	}
+	for name := range gotColumns {
+		if _, ok := expectedColumns[name]; !ok {
+			t.Errorf("sqlite column `%s` doesn't exist in PostgreSQL", name)
+		}
+	}
+	if len(expectedColumns) == 0 {
+		t.Errorf("columns of PostgreSQL migrations aren't found")
+	}
+}
+
+func TestMigrator_SqliteForeignKeys(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewMigrator("sqlite", filepath.Join(t.TempDir(), "calendar.db")+"?_pragma=foreign_keys(1)")
+	if err != nil {
+		t.Fatalf("can't create migrator: %s", err)
+	}
+	defer m.Close()
+	if err := m.To(ctx, 18); err != nil {
+		t.Fatalf("can't apply migrations: %s", err)
+	}
+	// rows left by events deleted before foreign keys were enforced
+	if _, err := m.db.Exec(`
+		INSERT INTO events(id, uid, owner, title, start_time, end_time, series_id)
+		VALUES ('e2d6e4b8-4f36-4a57-a8a4-cb1e4b2c2b80', 'override', 'user', 'override', '2019-10-07 10:00:00', '2019-10-07 11:00:00',
+		        'a7a9b3f6-3c0e-4e5e-9d44-3f0f0e6f3c51');
+		INSERT INTO events(id, uid, owner, title, start_time, end_time)
+		VALUES ('0b7c7b7e-8f46-4d0e-8a44-8c1f9b0d6a11', 'single', 'user', 'single', '2019-10-07 12:00:00', '2019-10-07 13:00:00');
+		INSERT INTO attendees(event_id, name, status) VALUES ('0b7c7b7e-8f46-4d0e-8a44-8c1f9b0d6a11', 'bob', 'accepted');
+		PRAGMA foreign_keys=OFF;
+		INSERT INTO attendees(event_id, name, status) VALUES ('a7a9b3f6-3c0e-4e5e-9d44-3f0f0e6f3c51', 'bob', 'accepted');
+		PRAGMA foreign_keys=ON;
+	`); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("can't apply migrations: %s", err)
+	}
+	var events, attendees int
+	if err := m.db.QueryRow(`SELECT (SELECT count(*) FROM events), (SELECT count(*) FROM attendees)`).Scan(&events, &attendees); err != nil {
+		t.Fatal(err)
+	}
+	if events != 1 || attendees != 1 {
+		t.Errorf("expected only rows of existing events to be kept, got %d events and %d attendees", events, attendees)
+	}
+}
+
+func TestMigrator_SqliteOverlap(t *testing.T) {
+	ctx := context.Background()
+	m, err := NewMigrator("sqlite", filepath.Join(t.TempDir(), "calendar.db"))
+	if err != nil {
+		t.Fatalf("can't create migrator: %s", err)
+	}
+	defer m.Close()
+	if err := m.To(ctx, 9); err != nil {
+		t.Fatalf("can't apply migrations: %s", err)
+	}
+	if _, err := m.db.Exec(`
+		INSERT INTO events(id, uid, owner, title, start_time, end_time)
+		VALUES ('e2d6e4b8-4f36-4a57-a8a4-cb1e4b2c2b80', 'first', 'user', 'first', '2019-10-07 10:00:00', '2019-10-07 11:00:00');
+		INSERT INTO events(id, uid, owner, title, start_time, end_time)
+		VALUES ('0b7c7b7e-8f46-4d0e-8a44-8c1f9b0d6a11', 'second', 'user', 'second', '2019-10-07 10:30:00', '2019-10-07 11:30:00');
+	`); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(ctx); err == nil || !strings.Contains(err.Error(), "overlapping events") {
+		t.Fatalf("migration should be aborted by overlapping events, got %v", err)
+	}
+	if st, err := m.Status(ctx); err != nil || st.Version != 9 || st.Dirty {
+		t.Errorf("expected clean version 9, got %v (%v)", st, err)
+	}
+
+	if _, err := m.db.Exec(`UPDATE events SET cancelled = true WHERE uid = 'second'`); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("can't apply migrations: %s", err)
+	}
+}
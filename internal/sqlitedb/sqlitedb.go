@@ -0,0 +1,653 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/migrations"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+	"strings"
+	"time"
+)
+
+type SqliteEventStorage struct {
+	db *sqlx.DB
+}
+
+// NewSqliteEventStorage opens (or creates) database file and applies schema migrations,
+// dsn is a file name, optionally with `file:` prefix and query parameters
+func NewSqliteEventStorage(dsn string) (*SqliteEventStorage, error) {
+	db, err := sqlx.Open("sqlite", withForeignKeys(withTimeFormat(dsn)))
+	if err != nil {
+		return nil, err
+	}
+	// sqlite allows only one writer, so serialize access instead of getting SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+	// there is no external migration tool for embedded database, so schema is always kept up to date
+	m, err := migrations.NewDbMigrator(db.DB, "sqlite")
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := m.Up(context.Background()); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return &SqliteEventStorage{db: db}, nil
+}
+
+// withTimeFormat makes driver store timestamps in sortable format, so they can be compared in queries
+func withTimeFormat(dsn string) string {
+	if strings.Contains(dsn, "_time_format=") {
+		return dsn
+	}
+	return withParam(dsn, "_time_format=sqlite")
+}
+
+// withForeignKeys enables foreign keys on every connection, sqlite doesn't enforce them by default
+func withForeignKeys(dsn string) string {
+	if strings.Contains(dsn, "foreign_keys") {
+		return dsn
+	}
+	return withParam(dsn, "_pragma=foreign_keys(1)")
+}
+
+func withParam(dsn, param string) string {
+	if strings.Contains(dsn, "?") {
+		return dsn + "&" + param
+	}
+	return dsn + "?" + param
+}
+
+func (ses *SqliteEventStorage) SaveEvent(ctx context.Context, event *models.Event, messages []*models.OutboxMessage) error {
+	err := ses.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := insertEvent(ctx, tx, event); err != nil {
+			return err
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+	return eventError(err)
+}
+
+func (ses *SqliteEventStorage) GetEventByIdOwner(ctx context.Context, id, owner string) (*models.Event, error) {
+	query := `
+		SELECT * FROM events WHERE id=$1 AND owner=$2
+`
+	event := &models.Event{}
+	err := ses.db.GetContext(ctx, event, query, id, owner)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+func (ses *SqliteEventStorage) GetEventByUidOwner(ctx context.Context, uid, owner string) (*models.Event, error) {
+	query := `
+		SELECT * FROM events WHERE uid=$1 AND owner=$2
+`
+	event := &models.Event{}
+	err := ses.db.GetContext(ctx, event, query, uid, owner)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+func (ses *SqliteEventStorage) GetEventsByOwnerStartDate(ctx context.Context, owner string, startTime *time.Time) ([]*models.Event, error) {
+	query := `
+		SELECT * FROM events WHERE owner=$1 AND (start_time>=$2 OR recurrence<>'' OR original_start_time>=$2)
+`
+	var events []*models.Event
+	err := ses.db.SelectContext(ctx, &events, query, owner, utc(startTime))
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (ses *SqliteEventStorage) GetEventsForNotification(ctx context.Context, startTime time.Time, period time.Duration) ([]*models.Event, error) {
+	query := `
+		SELECT e.* FROM events e
+		WHERE e.start_time<=$2 AND (e.recurrence='' AND e.end_time>$1 OR e.recurrence<>'' AND (e.series_end IS NULL OR e.series_end>$1))
+		   OR e.original_start_time<=$2 AND EXISTS(SELECT 1 FROM events s WHERE s.id=e.series_id
+		       AND julianday(e.original_start_time)+julianday(s.end_time)-julianday(s.start_time)>julianday($1))
+`
+	var events []*models.Event
+	err := ses.db.SelectContext(ctx, &events, query, startTime.UTC(), startTime.Add(period).UTC())
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (ses *SqliteEventStorage) GetEventsCountByOwnerStartDateEndDate(ctx context.Context, owner string, startTime, endTime *time.Time) (int, error) {
+	query := `
+SELECT count(*)
+FROM events
+WHERE owner = $1
+  AND start_time < $3
+  AND end_time > $2
+`
+	var eventsCount int
+	err := ses.db.GetContext(ctx, &eventsCount, query, owner, utc(startTime), utc(endTime))
+	if err != nil {
+		return 0, err
+	}
+	return eventsCount, nil
+}
+
+func (ses *SqliteEventStorage) GetEventsByOwnerStartDateEndDate(ctx context.Context, owner string, startTime, endTime *time.Time) ([]*models.Event, error) {
+	query := `
+		SELECT * FROM events WHERE owner=$1 AND (start_time<=$3 AND (end_time>=$2 OR recurrence<>'') OR original_start_time<=$3)
+`
+	var events []*models.Event
+	err := ses.db.SelectContext(ctx, &events, query, owner, utc(startTime), utc(endTime))
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (ses *SqliteEventStorage) DeleteEventByIdOwner(ctx context.Context, id, owner string, messages []*models.OutboxMessage) error {
+	query := `
+		DELETE FROM events WHERE (id=$1 OR series_id=$1) AND owner=$2
+	`
+	return ses.inTx(ctx, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, query, id, owner)
+		if err != nil {
+			return err
+		}
+		if c, _ := res.RowsAffected(); c == 0 {
+			return errors.ErrNotFound
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+}
+
+func (ses *SqliteEventStorage) DeleteEventsOlderDateByIdOwner(ctx context.Context, date *time.Time, owner string) (int64, error) {
+	query := `
+		DELETE FROM events WHERE end_time<=$1 AND owner=$2 AND recurrence='' AND series_id IS NULL
+	`
+	res, err := ses.db.ExecContext(ctx, query, utc(date), owner)
+	if err != nil {
+		return 0, err
+	}
+	c, _ := res.RowsAffected()
+	return c, nil
+}
+
+func (ses *SqliteEventStorage) UpdateEventByIdOwner(ctx context.Context, id string, event *models.Event, messages []*models.OutboxMessage) error {
+	err := ses.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := updateEvent(ctx, tx, id, event); err != nil {
+			return err
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+	return eventError(err)
+}
+
+// UpdateSeries updates series and deletes overrides of its occurrences started since, if it's set
+func (ses *SqliteEventStorage) UpdateSeries(ctx context.Context, series *models.Event, since *time.Time, messages []*models.OutboxMessage) error {
+	err := ses.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := updateEvent(ctx, tx, series.Id.String(), series); err != nil {
+			return err
+		}
+		if since != nil {
+			if _, err := deleteOverrides(ctx, tx, series.Id.String(), series.Owner, since); err != nil {
+				return err
+			}
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+	return eventError(err)
+}
+
+// SplitSeries updates truncated series, deletes overrides of its occurrences started since till
+// and saves event, which continues the series
+func (ses *SqliteEventStorage) SplitSeries(ctx context.Context, series *models.Event, till time.Time, event *models.Event, messages []*models.OutboxMessage) error {
+	err := ses.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := updateEvent(ctx, tx, series.Id.String(), series); err != nil {
+			return err
+		}
+		if _, err := deleteOverrides(ctx, tx, series.Id.String(), series.Owner, &till); err != nil {
+			return err
+		}
+		if err := insertEvent(ctx, tx, event); err != nil {
+			return err
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+	return eventError(err)
+}
+
+func (ses *SqliteEventStorage) GetEventsBySeriesIdOwner(ctx context.Context, seriesId, owner string) ([]*models.Event, error) {
+	query := `
+		SELECT * FROM events WHERE series_id=$1 AND owner=$2 AND id<>$1 ORDER BY original_start_time
+`
+	var events []*models.Event
+	err := ses.db.SelectContext(ctx, &events, query, seriesId, owner)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (ses *SqliteEventStorage) GetEventBySeriesIdOwnerStartDate(ctx context.Context, seriesId, owner string, startTime *time.Time) (*models.Event, error) {
+	query := `
+		SELECT * FROM events WHERE series_id=$1 AND owner=$2 AND original_start_time=$3
+`
+	event := &models.Event{}
+	err := ses.db.GetContext(ctx, event, query, seriesId, owner, utc(startTime))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+func (ses *SqliteEventStorage) DeleteEventsBySeriesIdOwnerStartDate(ctx context.Context, seriesId, owner string, startTime *time.Time) (int64, error) {
+	var c int64
+	err := ses.inTx(ctx, func(tx *sqlx.Tx) (err error) {
+		c, err = deleteOverrides(ctx, tx, seriesId, owner, startTime)
+		return err
+	})
+	return c, err
+}
+
+// GetNotifiedReminders returns offsets of notified reminders of event or occurrence started at startTime
+func (ses *SqliteEventStorage) GetNotifiedReminders(ctx context.Context, id string, startTime time.Time) (models.Reminders, error) {
+	query := `
+		SELECT reminder FROM reminder_notifications WHERE event_id=$1 AND start_time=$2
+`
+	var seconds []int64
+	err := ses.db.SelectContext(ctx, &seconds, query, id, startTime.UTC())
+	if err != nil {
+		return nil, err
+	}
+	reminders := make(models.Reminders, 0, len(seconds))
+	for _, s := range seconds {
+		reminders = append(reminders, time.Duration(s)*time.Second)
+	}
+	return reminders, nil
+}
+
+func (ses *SqliteEventStorage) MarkReminderNotified(ctx context.Context, id string, startTime time.Time, reminder time.Duration, messages []*models.OutboxMessage) error {
+	query := `
+		INSERT INTO reminder_notifications(event_id, start_time, reminder) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING
+`
+	return ses.inTx(ctx, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx, query, id, startTime.UTC(), int64(reminder/time.Second))
+		if err != nil {
+			return err
+		}
+		// reminder is already notified, e.g. by another replica
+		if c, _ := res.RowsAffected(); c == 0 {
+			return nil
+		}
+		return saveOutboxMessages(ctx, tx, messages)
+	})
+}
+
+// GetPendingOutboxMessages returns the oldest messages, which aren't marked sent
+func (ses *SqliteEventStorage) GetPendingOutboxMessages(ctx context.Context, limit int) ([]*models.OutboxMessage, error) {
+	query := `
+		SELECT * FROM outbox WHERE sent_at IS NULL ORDER BY created_at LIMIT $1
+`
+	var messages []*models.OutboxMessage
+	err := ses.db.SelectContext(ctx, &messages, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (ses *SqliteEventStorage) MarkOutboxMessageSent(ctx context.Context, id string, sentAt time.Time) error {
+	query := `
+		UPDATE outbox SET sent_at=$2 WHERE id=$1
+`
+	_, err := ses.db.ExecContext(ctx, query, id, sentAt.UTC())
+	return err
+}
+
+func (ses *SqliteEventStorage) DeleteOutboxMessagesSentBefore(ctx context.Context, date time.Time) (int64, error) {
+	query := `
+		DELETE FROM outbox WHERE sent_at<$1
+`
+	res, err := ses.db.ExecContext(ctx, query, date.UTC())
+	if err != nil {
+		return 0, err
+	}
+	c, _ := res.RowsAffected()
+	return c, nil
+}
+
+func (ses *SqliteEventStorage) IsMessageProcessed(ctx context.Context, id string) (bool, error) {
+	query := `
+		SELECT count(*) FROM processed_messages WHERE message_id=$1
+`
+	var c int
+	if err := ses.db.GetContext(ctx, &c, query, id); err != nil {
+		return false, err
+	}
+	return c > 0, nil
+}
+
+func (ses *SqliteEventStorage) MarkMessageProcessed(ctx context.Context, id string, processedAt time.Time) error {
+	query := `
+		INSERT INTO processed_messages(message_id, processed_at) VALUES ($1, $2) ON CONFLICT DO NOTHING
+`
+	_, err := ses.db.ExecContext(ctx, query, id, processedAt.UTC())
+	return err
+}
+
+func (ses *SqliteEventStorage) DeleteMessagesProcessedBefore(ctx context.Context, date time.Time) (int64, error) {
+	query := `
+		DELETE FROM processed_messages WHERE processed_at<$1
+`
+	res, err := ses.db.ExecContext(ctx, query, date.UTC())
+	if err != nil {
+		return 0, err
+	}
+	c, _ := res.RowsAffected()
+	return c, nil
+}
+
+func (ses *SqliteEventStorage) SaveFeedToken(ctx context.Context, token *models.FeedToken) error {
+	query := `
+		INSERT INTO feed_tokens(token, owner, created_at) VALUES ($1, $2, $3)
+`
+	_, err := ses.db.ExecContext(ctx, query, token.Token, token.Owner, utc(token.CreatedAt))
+	return err
+}
+
+func (ses *SqliteEventStorage) GetFeedToken(ctx context.Context, token string) (*models.FeedToken, error) {
+	query := `
+		SELECT * FROM feed_tokens WHERE token=$1
+`
+	feedToken := &models.FeedToken{}
+	err := ses.db.GetContext(ctx, feedToken, query, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return feedToken, nil
+}
+
+func (ses *SqliteEventStorage) GetFeedTokensByOwner(ctx context.Context, owner string) ([]*models.FeedToken, error) {
+	query := `
+		SELECT * FROM feed_tokens WHERE owner=$1 ORDER BY created_at
+`
+	var tokens []*models.FeedToken
+	err := ses.db.SelectContext(ctx, &tokens, query, owner)
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (ses *SqliteEventStorage) DeleteFeedTokenByTokenOwner(ctx context.Context, token, owner string) error {
+	query := `
+		DELETE FROM feed_tokens WHERE token=$1 AND owner=$2
+	`
+	res, err := ses.db.ExecContext(ctx, query, token, owner)
+	if res != nil {
+		if c, _ := res.RowsAffected(); c == 0 {
+			return errors.ErrNotFound
+		}
+	}
+	return err
+}
+
+func (ses *SqliteEventStorage) SaveGrant(ctx context.Context, grant *models.Grant) error {
+	query := `
+		INSERT INTO grants(owner, grantee, role, created_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (owner, grantee) DO UPDATE SET role=excluded.role, created_at=excluded.created_at
+`
+	_, err := ses.db.ExecContext(ctx, query, grant.Owner, grant.Grantee, grant.Role, utc(grant.CreatedAt))
+	return err
+}
+
+func (ses *SqliteEventStorage) GetGrant(ctx context.Context, owner, grantee string) (*models.Grant, error) {
+	query := `
+		SELECT * FROM grants WHERE owner=$1 AND grantee=$2
+`
+	grant := &models.Grant{}
+	err := ses.db.GetContext(ctx, grant, query, owner, grantee)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errors.ErrNotFound
+		}
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (ses *SqliteEventStorage) GetGrantsByOwner(ctx context.Context, owner string) ([]*models.Grant, error) {
+	query := `
+		SELECT * FROM grants WHERE owner=$1 ORDER BY grantee
+`
+	var grants []*models.Grant
+	err := ses.db.SelectContext(ctx, &grants, query, owner)
+	if err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+func (ses *SqliteEventStorage) DeleteGrantByOwnerGrantee(ctx context.Context, owner, grantee string) error {
+	query := `
+		DELETE FROM grants WHERE owner=$1 AND grantee=$2
+	`
+	res, err := ses.db.ExecContext(ctx, query, owner, grantee)
+	if res != nil {
+		if c, _ := res.RowsAffected(); c == 0 {
+			return errors.ErrNotFound
+		}
+	}
+	return err
+}
+
+func (ses *SqliteEventStorage) SaveAttendee(ctx context.Context, eventId string, attendee *models.Attendee) error {
+	query := `
+		INSERT INTO attendees(event_id, name, status) VALUES ($1, $2, $3)
+		ON CONFLICT (event_id, name) DO NOTHING
+`
+	_, err := ses.db.ExecContext(ctx, query, eventId, attendee.Name, attendee.Status)
+	return err
+}
+
+func (ses *SqliteEventStorage) GetAttendeesByEventId(ctx context.Context, eventId string) ([]*models.Attendee, error) {
+	query := `
+		SELECT name, status FROM attendees WHERE event_id=$1 ORDER BY name
+`
+	var attendees []*models.Attendee
+	err := ses.db.SelectContext(ctx, &attendees, query, eventId)
+	if err != nil {
+		return nil, err
+	}
+	return attendees, nil
+}
+
+// UpdateAttendeeStatus sets status of attendee, id of override or series can be used for recurring events
+func (ses *SqliteEventStorage) UpdateAttendeeStatus(ctx context.Context, eventId, name string, status models.AttendeeStatus) error {
+	query := `
+		UPDATE attendees SET status=$3
+		WHERE name=$2 AND event_id=(SELECT COALESCE(series_id, id) FROM events WHERE id=$1)
+	`
+	res, err := ses.db.ExecContext(ctx, query, eventId, name, status)
+	if res != nil {
+		if c, _ := res.RowsAffected(); c == 0 {
+			return errors.ErrNotFound
+		}
+	}
+	return err
+}
+
+// GetEventsByAttendeeStartDate returns events, which attendee is invited to and hasn't declined
+func (ses *SqliteEventStorage) GetEventsByAttendeeStartDate(ctx context.Context, attendee string, startTime *time.Time) ([]*models.Event, error) {
+	query := `
+		SELECT e.* FROM events e JOIN attendees a ON a.event_id=COALESCE(e.series_id, e.id)
+		WHERE a.name=$1 AND a.status<>'declined'
+		  AND (e.start_time>=$2 OR e.recurrence<>'' OR e.original_start_time>=$2)
+`
+	var events []*models.Event
+	err := ses.db.SelectContext(ctx, &events, query, attendee, utc(startTime))
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// AcquireLease inserts lease, or takes it over if it's expired at now or held by the same holder
+func (ses *SqliteEventStorage) AcquireLease(ctx context.Context, lease *models.Lease, now time.Time) (bool, error) {
+	query := `
+		INSERT INTO leases(name, holder, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET holder=excluded.holder, expires_at=excluded.expires_at
+		WHERE leases.holder=excluded.holder OR leases.expires_at<$4
+`
+	res, err := ses.db.ExecContext(ctx, query, lease.Name, lease.Holder, utc(lease.ExpiresAt), now.UTC())
+	if err != nil {
+		return false, err
+	}
+	c, _ := res.RowsAffected()
+	return c > 0, nil
+}
+
+func (ses *SqliteEventStorage) ReleaseLease(ctx context.Context, name, holder string) error {
+	query := `
+		DELETE FROM leases WHERE name=$1 AND holder=$2
+`
+	_, err := ses.db.ExecContext(ctx, query, name, holder)
+	return err
+}
+
+// inTx runs f in transaction, which is committed if f succeeds
+func (ses *SqliteEventStorage) inTx(ctx context.Context, f func(tx *sqlx.Tx) error) error {
+	tx, err := ses.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertEvent inserts event in transaction
+func insertEvent(ctx context.Context, tx *sqlx.Tx, event *models.Event) error {
+	query := `
+		INSERT INTO events(id, owner, title, text, start_time, end_time, recurrence, series_id, original_start_time, cancelled, uid, transparency, all_day, time_zone, reminders, series_end)
+		VALUES (:id, :owner, :title, :text, :start_time, :end_time, :recurrence, :series_id, :original_start_time, :cancelled, :uid, :transparency, :all_day, :time_zone, :reminders, :series_end)
+	`
+	_, err := tx.NamedExecContext(ctx, query, map[string]interface{}{
+		"id":                  event.Id.String(),
+		"owner":               event.Owner,
+		"title":               event.Title,
+		"text":                event.Text,
+		"start_time":          utc(event.StartTime),
+		"end_time":            utc(event.EndTime),
+		"recurrence":          event.Recurrence,
+		"series_id":           event.SeriesId,
+		"original_start_time": utc(event.OriginalStartTime),
+		"cancelled":           event.Cancelled,
+		"uid":                 event.Uid,
+		"transparency":        event.Transparency,
+		"all_day":             event.AllDay,
+		"time_zone":           event.TimeZone,
+		"reminders":           event.Reminders,
+		"series_end":          utc(event.SeriesEnd),
+	})
+	return err
+}
+
+// updateEvent updates owner's event in transaction, notified reminders of the event are forgotten
+// if it's moved, so it's reminded again
+func updateEvent(ctx context.Context, tx *sqlx.Tx, id string, event *models.Event) error {
+	reset := `
+		DELETE FROM reminder_notifications
+		WHERE event_id=$1 AND EXISTS(SELECT 1 FROM events WHERE id=$1 AND owner=$2 AND start_time<>$3)
+`
+	if _, err := tx.ExecContext(ctx, reset, id, event.Owner, utc(event.StartTime)); err != nil {
+		return err
+	}
+	query := `
+		UPDATE events SET title=$3, text=$4, start_time=$5, end_time=$6, recurrence=$7, cancelled=$8, transparency=$9, all_day=$10, time_zone=$11, reminders=$12, series_end=$13
+		WHERE id=$1 AND owner=$2
+`
+	res, err := tx.ExecContext(ctx, query, id, event.Owner, event.Title, event.Text, utc(event.StartTime), utc(event.EndTime), event.Recurrence, event.Cancelled,
+		event.Transparency, event.AllDay, event.TimeZone, event.Reminders, utc(event.SeriesEnd))
+	if err != nil {
+		return err
+	}
+	if c, _ := res.RowsAffected(); c == 0 {
+		return errors.ErrNotFound
+	}
+	return nil
+}
+
+// deleteOverrides deletes overrides of series occurrences started since startTime in transaction
+func deleteOverrides(ctx context.Context, tx *sqlx.Tx, seriesId, owner string, startTime *time.Time) (int64, error) {
+	query := `
+		DELETE FROM events WHERE series_id=$1 AND owner=$2 AND original_start_time>=$3
+	`
+	res, err := tx.ExecContext(ctx, query, seriesId, owner, utc(startTime))
+	if err != nil {
+		return 0, err
+	}
+	c, _ := res.RowsAffected()
+	return c, nil
+}
+
+// saveOutboxMessages inserts messages in transaction of the change, which caused them
+func saveOutboxMessages(ctx context.Context, tx *sqlx.Tx, messages []*models.OutboxMessage) error {
+	query := `
+		INSERT INTO outbox(id, payload, created_at) VALUES ($1, $2, $3)
+`
+	for _, m := range messages {
+		if _, err := tx.ExecContext(ctx, query, m.Id, m.Payload, utc(m.CreatedAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ses *SqliteEventStorage) Close(ctx context.Context) {
+	_ = ses.db.Close()
+}
+
+// utc normalizes time zone, sqlite compares timestamps as strings
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// overlapMessage is raised by triggers, which reject overlapping events
+const overlapMessage = "events overlap"
+
+// eventError converts error raised by overlap triggers to domain error
+func eventError(err error) error {
+	if err != nil && strings.Contains(err.Error(), overlapMessage) {
+		return errors.ErrOverlaping
+	}
+	return err
+}
@@ -0,0 +1,376 @@
+package sqlitedb
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSqliteEventStorage(t *testing.T) {
+	ctx := context.Background()
+	dsn := filepath.Join(t.TempDir(), "calendar.db")
+	storage, err := NewSqliteEventStorage(dsn)
+	if err != nil {
+		t.Fatalf("can't open storage: %s", err)
+	}
+	start := time.Date(2019, 10, 10, 10, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	end := start.Add(time.Hour)
+	id := uuid.NewV4()
+	event := &models.Event{
+		Id:        id,
+		Uid:       id.String(),
+		Owner:     "user",
+		Title:     "title",
+		Text:      "text",
+		StartTime: &start,
+		EndTime:   &end,
+		Reminders: models.Reminders{24 * time.Hour, 0},
+	}
+	if err := storage.SaveEvent(ctx, event, nil); err != nil {
+		t.Fatalf("can't save event: %s", err)
+	}
+
+	got, err := storage.GetEventByIdOwner(ctx, event.Id.String(), "user")
+	if err != nil {
+		t.Fatalf("can't get event: %s", err)
+	}
+	if got.Title != event.Title || !got.StartTime.Equal(start) || !got.EndTime.Equal(end) ||
+		len(got.Reminders) != 2 || got.Reminders[0] != 24*time.Hour || got.Reminders[1] != 0 {
+		t.Errorf("saved and loaded events are different: %s != %s", got, event)
+	}
+
+	from, till := start.Add(30*time.Minute), start.Add(2*time.Hour)
+	if c, err := storage.GetEventsCountByOwnerStartDateEndDate(ctx, "user", &from, &till); err != nil || c != 1 {
+		t.Errorf("expected 1 overlapping event, got %d (%v)", c, err)
+	}
+	created := start.Add(-time.Hour)
+	message := &models.OutboxMessage{Id: uuid.NewV4(), Payload: `{"Recipient":"user"}`, CreatedAt: &created}
+	if err := storage.MarkReminderNotified(ctx, event.Id.String(), start, 24*time.Hour, []*models.OutboxMessage{message}); err != nil {
+		t.Fatalf("can't mark reminder notified: %s", err)
+	}
+	if events, err := storage.GetEventsForNotification(ctx, end, time.Hour); err != nil || len(events) != 0 {
+		t.Errorf("ended event shouldn't be returned, got %d (%v)", len(events), err)
+	}
+	// reminder isn't marked if outbox message can't be saved
+	if err := storage.MarkReminderNotified(ctx, event.Id.String(), start, 0, []*models.OutboxMessage{message}); err == nil {
+		t.Errorf("duplicate outbox message shouldn't be saved")
+	}
+	if notified, err := storage.GetNotifiedReminders(ctx, event.Id.String(), start); err != nil || len(notified) != 1 {
+		t.Errorf("reminder shouldn't be notified in rolled back transaction, got %v (%v)", notified, err)
+	}
+	nextMessage := &models.OutboxMessage{Id: uuid.NewV4(), Payload: `{"Recipient":"user"}`, CreatedAt: &start}
+	if err := storage.MarkReminderNotified(ctx, event.Id.String(), start, 0, []*models.OutboxMessage{nextMessage}); err != nil {
+		t.Fatalf("can't mark reminder notified: %s", err)
+	}
+	if notified, err := storage.GetNotifiedReminders(ctx, event.Id.String(), start.UTC()); err != nil || len(notified) != 2 {
+		t.Errorf("both reminders should be notified, got %v (%v)", notified, err)
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 2 || pending[0].Id != message.Id {
+		t.Fatalf("expected 2 pending outbox messages, got %v (%v)", pending, err)
+	}
+	if err := storage.MarkOutboxMessageSent(ctx, message.Id.String(), start); err != nil {
+		t.Fatalf("can't mark outbox message sent: %s", err)
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 1 || pending[0].Id != nextMessage.Id {
+		t.Errorf("expected 1 pending outbox message, got %v (%v)", pending, err)
+	}
+	if c, err := storage.DeleteOutboxMessagesSentBefore(ctx, end); err != nil || c != 1 {
+		t.Errorf("expected 1 deleted outbox message, got %d (%v)", c, err)
+	}
+	if processed, err := storage.IsMessageProcessed(ctx, message.Id.String()); err != nil || processed {
+		t.Errorf("message shouldn't be processed, got %t (%v)", processed, err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := storage.MarkMessageProcessed(ctx, message.Id.String(), start); err != nil {
+			t.Fatalf("can't mark message processed: %s", err)
+		}
+	}
+	if processed, err := storage.IsMessageProcessed(ctx, message.Id.String()); err != nil || !processed {
+		t.Errorf("message should be processed, got %t (%v)", processed, err)
+	}
+	if c, err := storage.DeleteMessagesProcessedBefore(ctx, end); err != nil || c != 1 {
+		t.Errorf("expected 1 deleted processed message, got %d (%v)", c, err)
+	}
+	if err := storage.MarkReminderNotified(ctx, event.Id.String(), start, 0, []*models.OutboxMessage{message}); err != nil {
+		t.Errorf("marking of notified reminder should be skipped: %s", err)
+	}
+	changeMessage := &models.OutboxMessage{Id: uuid.NewV4(), Payload: `{"Recipient":"user","Type":"updated"}`, CreatedAt: &end}
+	if err := storage.UpdateEventByIdOwner(ctx, event.Id.String(), event, []*models.OutboxMessage{changeMessage}); err != nil {
+		t.Fatalf("can't update event: %s", err)
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 2 || pending[1].Id != changeMessage.Id {
+		t.Errorf("expected 2 pending outbox messages, got %v (%v)", pending, err)
+	}
+	if notified, err := storage.GetNotifiedReminders(ctx, event.Id.String(), start); err != nil || len(notified) != 2 {
+		t.Errorf("reminders of event, which isn't moved, should be kept, got %v (%v)", notified, err)
+	}
+	moved := *event
+	movedStart, movedEnd := start.Add(time.Hour), end.Add(time.Hour)
+	moved.StartTime, moved.EndTime = &movedStart, &movedEnd
+	if err := storage.UpdateEventByIdOwner(ctx, event.Id.String(), &moved, nil); err != nil {
+		t.Fatalf("can't move event: %s", err)
+	}
+	if notified, err := storage.GetNotifiedReminders(ctx, event.Id.String(), start); err != nil || len(notified) != 0 {
+		t.Errorf("reminders of moved event should be reset, got %v (%v)", notified, err)
+	}
+	if err := storage.UpdateEventByIdOwner(ctx, event.Id.String(), event, nil); err != nil {
+		t.Fatalf("can't move event back: %s", err)
+	}
+
+	lease := &models.Lease{Name: "notificator", Holder: "first", ExpiresAt: &end}
+	if ok, err := storage.AcquireLease(ctx, lease, start); err != nil || !ok {
+		t.Fatalf("can't acquire lease: %t (%v)", ok, err)
+	}
+	other := &models.Lease{Name: "notificator", Holder: "second", ExpiresAt: &till}
+	if ok, err := storage.AcquireLease(ctx, other, start); err != nil || ok {
+		t.Errorf("lease shouldn't be taken over before it expires: %t (%v)", ok, err)
+	}
+	if ok, err := storage.AcquireLease(ctx, lease, start); err != nil || !ok {
+		t.Errorf("holder should renew lease: %t (%v)", ok, err)
+	}
+	if ok, err := storage.AcquireLease(ctx, other, till); err != nil || !ok {
+		t.Errorf("expired lease should be taken over: %t (%v)", ok, err)
+	}
+	if err := storage.ReleaseLease(ctx, "notificator", "first"); err != nil {
+		t.Errorf("can't release lease: %s", err)
+	}
+	if ok, err := storage.AcquireLease(ctx, lease, till); err != nil || ok {
+		t.Errorf("lease of another holder shouldn't be released: %t (%v)", ok, err)
+	}
+
+	override := *event
+	override.Id = uuid.NewV4()
+	override.Uid = override.Id.String()
+	override.SeriesId = &event.Id
+	override.OriginalStartTime = &start
+	override.Cancelled = true
+	if err := storage.SaveEvent(ctx, &override, nil); err != nil {
+		t.Fatalf("can't save override: %s", err)
+	}
+	got, err = storage.GetEventBySeriesIdOwnerStartDate(ctx, event.Id.String(), "user", &start)
+	if err != nil {
+		t.Fatalf("can't get override: %s", err)
+	}
+	if got.Id != override.Id || *got.SeriesId != event.Id || !got.OriginalStartTime.Equal(start) || !got.Cancelled {
+		t.Errorf("saved and loaded overrides are different: %s != %s", got, &override)
+	}
+	if overrides, err := storage.GetEventsBySeriesIdOwner(ctx, event.Id.String(), "user"); err != nil || len(overrides) != 1 {
+		t.Errorf("expected 1 override, got %d (%v)", len(overrides), err)
+	}
+	if got, err := storage.GetEventByUidOwner(ctx, event.Uid, "user"); err != nil || got.Id != event.Id {
+		t.Errorf("can't get event by uid: %v", err)
+	}
+	grant := &models.Grant{Owner: "user", Grantee: "assistant", Role: models.RoleRead, CreatedAt: &start}
+	if err := storage.SaveGrant(ctx, grant); err != nil {
+		t.Fatalf("can't save grant: %s", err)
+	}
+	grant.Role = models.RoleWrite
+	if err := storage.SaveGrant(ctx, grant); err != nil {
+		t.Fatalf("can't replace grant: %s", err)
+	}
+	if got, err := storage.GetGrant(ctx, "user", "assistant"); err != nil || got.Role != models.RoleWrite {
+		t.Errorf("expected replaced grant, got %v (%v)", got, err)
+	}
+	if grants, err := storage.GetGrantsByOwner(ctx, "user"); err != nil || len(grants) != 1 {
+		t.Errorf("expected 1 grant, got %d (%v)", len(grants), err)
+	}
+	attendee := &models.Attendee{Name: "guest", Status: models.StatusNeedsAction}
+	if err := storage.SaveAttendee(ctx, event.Id.String(), attendee); err != nil {
+		t.Fatalf("can't save attendee: %s", err)
+	}
+	// attendees of series are addressed by id of its override too
+	if err := storage.UpdateAttendeeStatus(ctx, override.Id.String(), "guest", models.StatusAccepted); err != nil {
+		t.Fatalf("can't update attendee status: %s", err)
+	}
+	if attendees, err := storage.GetAttendeesByEventId(ctx, event.Id.String()); err != nil || len(attendees) != 1 ||
+		attendees[0].Status != models.StatusAccepted {
+		t.Errorf("expected accepted attendee, got %v (%v)", attendees, err)
+	}
+	if events, err := storage.GetEventsByAttendeeStartDate(ctx, "guest", &start); err != nil || len(events) != 2 {
+		t.Errorf("expected series and override for attendee, got %d (%v)", len(events), err)
+	}
+	if c, err := storage.GetEventsCountByOwnerStartDateEndDate(ctx, "user", &end, &till); err != nil || c != 0 {
+		t.Errorf("expected no overlapping events after the end, got %d (%v)", c, err)
+	}
+	overlapping := *event
+	overlapping.Id = uuid.NewV4()
+	overlapping.Uid = overlapping.Id.String()
+	inner, innerEnd := start.Add(15*time.Minute), start.Add(30*time.Minute)
+	overlapping.StartTime, overlapping.EndTime = &inner, &innerEnd
+	lost := &models.OutboxMessage{Id: uuid.NewV4(), Payload: `{"Recipient":"user","Type":"created"}`, CreatedAt: &end}
+	if err := storage.SaveEvent(ctx, &overlapping, []*models.OutboxMessage{lost}); err != errors.ErrOverlaping {
+		t.Errorf("expected %q, got %v", errors.ErrOverlaping, err)
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 2 {
+		t.Errorf("outbox message of rejected event shouldn't be saved, got %d (%v)", len(pending), err)
+	}
+	next := overlapping
+	nextEnd := end.Add(time.Hour)
+	next.StartTime, next.EndTime = &end, &nextEnd
+	// event isn't saved if its outbox message can't be saved
+	if err := storage.SaveEvent(ctx, &next, []*models.OutboxMessage{changeMessage}); err == nil {
+		t.Errorf("duplicate outbox message shouldn't be saved")
+	}
+	if _, err := storage.GetEventByIdOwner(ctx, next.Id.String(), "user"); err != errors.ErrNotFound {
+		t.Errorf("event shouldn't be saved in rolled back transaction, got %v", err)
+	}
+	if err := storage.SaveEvent(ctx, &next, nil); err != nil {
+		t.Errorf("back-to-back events shouldn't overlap: %s", err)
+	}
+	if err := storage.UpdateEventByIdOwner(ctx, next.Id.String(), &overlapping, nil); err != errors.ErrOverlaping {
+		t.Errorf("expected %q, got %v", errors.ErrOverlaping, err)
+	}
+	overlapping.Id = uuid.NewV4()
+	overlapping.Uid = overlapping.Id.String()
+	overlapping.Transparency = models.TransparencyFree
+	if err := storage.SaveEvent(ctx, &overlapping, nil); err != nil {
+		t.Errorf("free event shouldn't overlap: %s", err)
+	}
+	overlapping.Id = uuid.NewV4()
+	overlapping.Uid = overlapping.Id.String()
+	overlapping.Transparency = models.TransparencyBusy
+	overlapping.AllDay = true
+	overlapping.TimeZone = "Europe/Moscow"
+	if err := storage.SaveEvent(ctx, &overlapping, nil); err != nil {
+		t.Errorf("all-day event shouldn't overlap: %s", err)
+	}
+	if saved, err := storage.GetEventByIdOwner(ctx, overlapping.Id.String(), "user"); err != nil || !saved.AllDay ||
+		saved.TimeZone != overlapping.TimeZone {
+		t.Errorf("expected all-day event in %s, got %v (%v)", overlapping.TimeZone, saved, err)
+	}
+	storage.Close(ctx)
+
+	// schema migrations shouldn't be applied twice
+	storage, err = NewSqliteEventStorage(dsn)
+	if err != nil {
+		t.Fatalf("can't reopen storage: %s", err)
+	}
+	defer storage.Close(ctx)
+	if err := storage.DeleteEventByIdOwner(ctx, event.Id.String(), "user", nil); err != nil {
+		t.Fatalf("can't delete event: %s", err)
+	}
+	if _, err := storage.GetEventByIdOwner(ctx, event.Id.String(), "user"); err != errors.ErrNotFound {
+		t.Errorf("expected %q, got %v", errors.ErrNotFound, err)
+	}
+	if _, err := storage.GetEventByIdOwner(ctx, override.Id.String(), "user"); err != errors.ErrNotFound {
+		t.Errorf("override should be deleted with series, got %v", err)
+	}
+}
+
+func TestSqliteEventStorage_EventsForNotification(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewSqliteEventStorage(filepath.Join(t.TempDir(), "calendar.db"))
+	if err != nil {
+		t.Fatalf("can't open storage: %s", err)
+	}
+	start := time.Date(2019, 10, 7, 10, 0, 0, 0, time.UTC)
+	seriesEnd := start.AddDate(0, 0, 4).Add(time.Hour)
+	newEvent := func(title, recurrence string, st time.Time, seriesId *uuid.UUID, originalStart *time.Time) *models.Event {
+		id := uuid.NewV4()
+		et := st.Add(time.Hour)
+		e := &models.Event{Id: id, Uid: id.String(), Owner: "user", Title: title, StartTime: &st, EndTime: &et,
+			Recurrence: recurrence, SeriesId: seriesId, OriginalStartTime: originalStart}
+		if recurrence != "" {
+			e.SeriesId = &e.Id
+		}
+		if err := storage.SaveEvent(ctx, e, nil); err != nil {
+			t.Fatalf("can't save event: %s", err)
+		}
+		return e
+	}
+	finished := newEvent("finished", "FREQ=DAILY;COUNT=5", start, nil, nil)
+	finished.SeriesEnd = &seriesEnd
+	if err := storage.UpdateEventByIdOwner(ctx, finished.Id.String(), finished, nil); err != nil {
+		t.Fatalf("can't update event: %s", err)
+	}
+	endless := newEvent("endless", "FREQ=WEEKLY", start.Add(2*time.Hour), nil, nil)
+	// the first occurrence is moved to the next month
+	moved := newEvent("moved", "", start.AddDate(0, 1, 0), &endless.Id, endless.StartTime)
+	// occurrence of the second week is moved to the next day
+	original := endless.StartTime.AddDate(0, 0, 7)
+	next := newEvent("next", "", original.AddDate(0, 0, 1), &endless.Id, &original)
+
+	events, err := storage.GetEventsForNotification(ctx, original.Add(-time.Hour), 2*time.Hour)
+	if err != nil {
+		t.Fatalf("can't get events for notification: %s", err)
+	}
+	got := make(map[uuid.UUID]bool)
+	for _, e := range events {
+		got[e.Id] = true
+	}
+	if len(events) != 2 || !got[endless.Id] || !got[next.Id] {
+		t.Errorf("expected endless series and override of occurrence within period, got %v", events)
+	}
+	if got[finished.Id] || got[moved.Id] {
+		t.Errorf("finished series and overrides of passed occurrences shouldn't be returned")
+	}
+}
+
+func TestSqliteEventStorage_SplitSeries(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewSqliteEventStorage(filepath.Join(t.TempDir(), "calendar.db"))
+	if err != nil {
+		t.Fatalf("can't open storage: %s", err)
+	}
+	start := time.Date(2019, 10, 7, 10, 0, 0, 0, time.UTC)
+	newEvent := func(title, recurrence string, st time.Time, seriesId *uuid.UUID) *models.Event {
+		id := uuid.NewV4()
+		et := st.Add(time.Hour)
+		e := &models.Event{Id: id, Uid: id.String(), Owner: "user", Title: title, StartTime: &st, EndTime: &et,
+			Recurrence: recurrence, SeriesId: seriesId}
+		if seriesId != nil {
+			e.OriginalStartTime = &st
+		}
+		if recurrence != "" {
+			e.SeriesId = &e.Id
+		}
+		return e
+	}
+	series := newEvent("series", "FREQ=DAILY;COUNT=5", start, nil)
+	override := newEvent("override", "", start.AddDate(0, 0, 3), &series.Id)
+	busy := newEvent("busy", "", start.AddDate(0, 0, 2).Add(3*time.Hour), nil)
+	for _, e := range []*models.Event{series, override, busy} {
+		if err := storage.SaveEvent(ctx, e, nil); err != nil {
+			t.Fatalf("can't save event: %s", err)
+		}
+	}
+
+	till := start.AddDate(0, 0, 2)
+	truncated := *series
+	truncated.Recurrence = "FREQ=DAILY;COUNT=2"
+	created := start.Add(-time.Hour)
+	message := &models.OutboxMessage{Id: uuid.NewV4(), Payload: `{"Recipient":"user"}`, CreatedAt: &created}
+	// nothing is changed if the new part of series can't be saved
+	overlapping := newEvent("moved", "", till.Add(3*time.Hour), nil)
+	err = storage.SplitSeries(ctx, &truncated, till, overlapping, []*models.OutboxMessage{message})
+	if err != errors.ErrOverlaping {
+		t.Fatalf("expected %q, got %v", errors.ErrOverlaping, err)
+	}
+	if got, err := storage.GetEventByIdOwner(ctx, series.Id.String(), "user"); err != nil || got.Recurrence != series.Recurrence {
+		t.Errorf("series shouldn't be truncated, got %v (%v)", got, err)
+	}
+	if _, err := storage.GetEventByIdOwner(ctx, override.Id.String(), "user"); err != nil {
+		t.Errorf("override shouldn't be deleted, got %v", err)
+	}
+	if pending, err := storage.GetPendingOutboxMessages(ctx, 10); err != nil || len(pending) != 0 {
+		t.Errorf("outbox message shouldn't be saved, got %d (%v)", len(pending), err)
+	}
+
+	following := newEvent("following", "FREQ=DAILY;COUNT=3", till, nil)
+	if err := storage.SplitSeries(ctx, &truncated, till, following, []*models.OutboxMessage{message}); err != nil {
+		t.Fatalf("can't split series: %s", err)
+	}
+	if got, err := storage.GetEventByIdOwner(ctx, series.Id.String(), "user"); err != nil || got.Recurrence != truncated.Recurrence {
+		t.Errorf("series should be truncated, got %v (%v)", got, err)
+	}
+	if _, err := storage.GetEventByIdOwner(ctx, override.Id.String(), "user"); err != errors.ErrNotFound {
+		t.Errorf("override of truncated occurrence should be deleted, got %v", err)
+	}
+	if _, err := storage.GetEventByIdOwner(ctx, following.Id.String(), "user"); err != nil {
+		t.Errorf("following series should be saved, got %v", err)
+	}
+}
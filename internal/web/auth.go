@@ -0,0 +1,45 @@
+package web
+
+import (
+	"github.com/Brialius/calendar/internal/auth"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	apiKeyHeader = "X-Api-Key"
+)
+
+// requestOwner returns owner of verified credentials, or owner sent by client if authentication is disabled.
+// Empty owner means that request isn't authenticated
+func (s *Server) requestOwner(r *http.Request, clientOwner string) string {
+	if s.Authenticator == nil {
+		return clientOwner
+	}
+	owner, err := s.authenticate(r)
+	if err != nil {
+		log.Printf("Authentication error: %s - %s", r.URL.Path, err)
+		return ""
+	}
+	return owner
+}
+
+// authenticate returns owner of bearer token or API key. Password of basic authentication
+// is verified as token or API key too, so CalDAV clients can use it
+func (s *Server) authenticate(r *http.Request) (string, error) {
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, bearerPrefix) {
+		return s.Authenticator.VerifyToken(strings.TrimPrefix(h, bearerPrefix))
+	}
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return s.Authenticator.VerifyApiKey(key)
+	}
+	if _, password, ok := r.BasicAuth(); ok {
+		if owner, err := s.Authenticator.VerifyApiKey(password); err == nil {
+			return owner, nil
+		}
+		return s.Authenticator.VerifyToken(password)
+	}
+	return "", auth.ErrUnauthenticated
+}
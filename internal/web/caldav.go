@@ -0,0 +1,523 @@
+package web
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/ical"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CalDAV resources are
+//
+//	/dav/principals/<owner>/                  principal of owner
+//	/dav/calendars/<owner>/                   calendar home
+//	/dav/calendars/<owner>/events/            calendar collection
+//	/dav/calendars/<owner>/events/<uid>.ics   event with overrides of its occurrences
+const (
+	davPrefix       = "/dav/"
+	principalsPath  = "principals"
+	calendarsPath   = "calendars"
+	calendarPath    = "events"
+	objectSuffix    = ".ics"
+	timeRangeLayout = "20060102T150405Z"
+	// maxBodySize limits size of request body
+	maxBodySize = 1 << 20
+)
+
+const davMethods = "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT"
+
+type davKind int
+
+const (
+	davRoot davKind = iota
+	davPrincipal
+	davHome
+	davCalendar
+	davObject
+)
+
+// davPath is parsed path of CalDAV resource
+type davPath struct {
+	kind  davKind
+	owner string
+	uid   string
+}
+
+func parseDavPath(p string) (*davPath, bool) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(p, davPrefix), "/"), "/")
+	switch {
+	case len(parts) == 1 && parts[0] == "":
+		return &davPath{kind: davRoot}, true
+	case len(parts) == 2 && parts[0] == principalsPath:
+		return &davPath{kind: davPrincipal, owner: parts[1]}, true
+	case len(parts) == 2 && parts[0] == calendarsPath:
+		return &davPath{kind: davHome, owner: parts[1]}, true
+	case len(parts) == 3 && parts[0] == calendarsPath && parts[2] == calendarPath:
+		return &davPath{kind: davCalendar, owner: parts[1]}, true
+	case len(parts) == 4 && parts[0] == calendarsPath && parts[2] == calendarPath &&
+		strings.HasSuffix(parts[3], objectSuffix) && len(parts[3]) > len(objectSuffix):
+		return &davPath{kind: davObject, owner: parts[1], uid: strings.TrimSuffix(parts[3], objectSuffix)}, true
+	}
+	return nil, false
+}
+
+func principalHref(owner string) string {
+	return davPrefix + principalsPath + "/" + url.PathEscape(owner) + "/"
+}
+
+func homeHref(owner string) string {
+	return davPrefix + calendarsPath + "/" + url.PathEscape(owner) + "/"
+}
+
+func calendarHref(owner string) string {
+	return homeHref(owner) + calendarPath + "/"
+}
+
+func objectHref(owner, uid string) string {
+	return calendarHref(owner) + url.PathEscape(uid) + objectSuffix
+}
+
+// serveDav serves minimal CalDAV (RFC 4791) calendar of each owner. Owner is taken from
+// verified credentials, or from basic authentication user name if authentication is disabled
+func (s *Server) serveDav(w http.ResponseWriter, r *http.Request) {
+	httpDavCounter.Inc()
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	user, _, _ := r.BasicAuth()
+	owner := s.requestOwner(r, user)
+	if owner == "" {
+		httpDavErrorCounter.Inc()
+		w.Header().Set("WWW-Authenticate", `Basic realm="calendar"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	p, ok := parseDavPath(r.URL.Path)
+	if !ok {
+		httpDavErrorCounter.Inc()
+		http.NotFound(w, r)
+		return
+	}
+	if p.owner != "" && p.owner != owner {
+		httpDavErrorCounter.Inc()
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	var status int
+	switch {
+	case r.Method == http.MethodOptions:
+		w.Header().Set("Allow", davMethods)
+		status = http.StatusOK
+	case r.Method == "PROPFIND":
+		status = s.davPropfind(w, r, owner, p)
+	case r.Method == "REPORT" && p.kind == davCalendar:
+		status = s.davReport(w, r, owner)
+	case (r.Method == http.MethodGet || r.Method == http.MethodHead) && p.kind == davObject:
+		status = s.davGet(w, r, owner, p.uid)
+	case r.Method == http.MethodPut && p.kind == davObject:
+		status = s.davPut(w, r, owner, p.uid)
+	case r.Method == http.MethodDelete && p.kind == davObject:
+		status = s.davDelete(w, r, owner, p.uid)
+	default:
+		w.Header().Set("Allow", davMethods)
+		status = http.StatusMethodNotAllowed
+		http.Error(w, http.StatusText(status), status)
+	}
+	if status >= http.StatusBadRequest {
+		httpDavErrorCounter.Inc()
+	}
+}
+
+// serveWellKnown redirects clients looking for CalDAV service
+func (s *Server) serveWellKnown(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, davPrefix, http.StatusMovedPermanently)
+}
+
+// davError writes error response, EventErrors are caused by request and aren't logged
+func davError(w http.ResponseWriter, err error) int {
+	status := http.StatusForbidden
+	switch err {
+	case errors.ErrNotFound:
+		status = http.StatusNotFound
+	case errors.ErrOverlaping:
+		status = http.StatusConflict
+	default:
+		if _, ok := err.(errors.EventError); !ok {
+			log.Printf("Error during CalDAV request: %s", err)
+			status = http.StatusInternalServerError
+			http.Error(w, http.StatusText(status), status)
+			return status
+		}
+	}
+	http.Error(w, err.Error(), status)
+	return status
+}
+
+func badRequest(w http.ResponseWriter, err error) int {
+	http.Error(w, err.Error(), http.StatusBadRequest)
+	return http.StatusBadRequest
+}
+
+func readBody(r io.Reader) ([]byte, error) {
+	return ioutil.ReadAll(io.LimitReader(r, maxBodySize))
+}
+
+func (s *Server) davPropfind(w http.ResponseWriter, r *http.Request, owner string, p *davPath) int {
+	req, err := parsePropfind(r.Body)
+	if err != nil {
+		return badRequest(w, err)
+	}
+	// infinite depth is served as 1
+	depth := r.Header.Get("Depth") != "0"
+	var resources []*davResource
+	switch p.kind {
+	case davRoot:
+		resources = append(resources, &davResource{href: davPrefix, props: []davProperty{
+			davProp("resourcetype", "<D:collection/>"),
+			davProp("current-user-principal", hrefValue(principalHref(owner))),
+		}})
+	case davPrincipal:
+		resources = append(resources, &davResource{href: principalHref(owner), props: []davProperty{
+			davProp("resourcetype", "<D:collection/><D:principal/>"),
+			davProp("displayname", escapeText(owner)),
+			davProp("current-user-principal", hrefValue(principalHref(owner))),
+			davProp("principal-URL", hrefValue(principalHref(owner))),
+			caldavProp("calendar-home-set", hrefValue(homeHref(owner))),
+		}})
+	case davHome:
+		resources = append(resources, &davResource{href: homeHref(owner), props: []davProperty{
+			davProp("resourcetype", "<D:collection/>"),
+			davProp("current-user-principal", hrefValue(principalHref(owner))),
+		}})
+		if depth {
+			resources = append(resources, calendarResource(owner))
+		}
+	case davCalendar:
+		resources = append(resources, calendarResource(owner))
+		if depth {
+			objects, err := s.EventService.ListEventObjects(r.Context(), owner, nil, nil)
+			if err != nil {
+				return davError(w, err)
+			}
+			for _, o := range objects {
+				res, err := objectResource(owner, o)
+				if err != nil {
+					return davError(w, err)
+				}
+				resources = append(resources, res)
+			}
+		}
+	case davObject:
+		o, err := s.EventService.GetEventObject(r.Context(), p.uid, owner)
+		if err != nil {
+			return davError(w, err)
+		}
+		res, err := objectResource(owner, o)
+		if err != nil {
+			return davError(w, err)
+		}
+		resources = append(resources, res)
+	}
+	writeMultistatus(w, req.selector(), resources)
+	return http.StatusMultiStatus
+}
+
+func calendarResource(owner string) *davResource {
+	return &davResource{href: calendarHref(owner), props: []davProperty{
+		davProp("resourcetype", "<D:collection/><C:calendar/>"),
+		davProp("displayname", escapeText(owner)),
+		davProp("current-user-principal", hrefValue(principalHref(owner))),
+		caldavProp("supported-calendar-component-set", `<C:comp name="VEVENT"/>`),
+		davProp("supported-report-set", "<D:supported-report><D:report><C:calendar-query/></D:report></D:supported-report>"+
+			"<D:supported-report><D:report><C:calendar-multiget/></D:report></D:supported-report>"),
+	}}
+}
+
+func objectResource(owner string, o *models.EventObject) (*davResource, error) {
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, o.Events()); err != nil {
+		return nil, err
+	}
+	return &davResource{href: objectHref(owner, o.Event.Uid), props: []davProperty{
+		davProp("resourcetype", ""),
+		davProp("getetag", escapeText(etag(o))),
+		davProp("getcontenttype", escapeText(ical.ContentType)),
+		caldavProp("calendar-data", escapeText(buf.String())),
+	}}, nil
+}
+
+// etag is hash of stored fields of the event and its overrides, it doesn't depend on encoding
+func etag(o *models.EventObject) string {
+	h := sha1.New()
+	for _, e := range o.Events() {
+		var originalStart int64
+		if e.OriginalStartTime != nil {
+			originalStart = e.OriginalStartTime.UnixNano()
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00%d\x00%d\x00%s\x00%d\x00%t\x00", e.Uid, e.Title, e.Text,
+			e.StartTime.UnixNano(), e.EndTime.UnixNano(), e.Recurrence, originalStart, e.Cancelled)
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
+
+// reportRequest is calendar-query or calendar-multiget
+type reportRequest struct {
+	XMLName xml.Name
+	Prop    *propRequest `xml:"DAV: prop"`
+	Filter  *compFilter  `xml:"urn:ietf:params:xml:ns:caldav filter>comp-filter"`
+	Hrefs   []string     `xml:"DAV: href"`
+}
+
+type compFilter struct {
+	Name      string       `xml:"name,attr"`
+	TimeRange *timeRange   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	Filters   []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type timeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+func (s *Server) davReport(w http.ResponseWriter, r *http.Request, owner string) int {
+	body, err := readBody(r.Body)
+	if err != nil {
+		return badRequest(w, err)
+	}
+	req := &reportRequest{}
+	if err := xml.Unmarshal(body, req); err != nil {
+		return badRequest(w, err)
+	}
+	var resources []*davResource
+	switch req.XMLName {
+	case xml.Name{Space: caldavNs, Local: "calendar-query"}:
+		resources, err = s.calendarQuery(r, owner, req.Filter)
+	case xml.Name{Space: caldavNs, Local: "calendar-multiget"}:
+		resources, err = s.calendarMultiget(r, owner, req.Hrefs)
+	default:
+		status := http.StatusForbidden
+		http.Error(w, "report isn't supported", status)
+		return status
+	}
+	if err != nil {
+		if rerr, ok := err.(*requestError); ok {
+			return badRequest(w, rerr)
+		}
+		return davError(w, err)
+	}
+	writeMultistatus(w, newPropSelector(req.Prop), resources)
+	return http.StatusMultiStatus
+}
+
+// requestError is caused by incorrect request content
+type requestError struct {
+	msg string
+}
+
+func (e *requestError) Error() string {
+	return e.msg
+}
+
+// calendarQuery returns events matched by VEVENT comp-filter, only time-range is supported
+func (s *Server) calendarQuery(r *http.Request, owner string, filter *compFilter) ([]*davResource, error) {
+	if filter == nil || filter.Name != "VCALENDAR" {
+		return nil, &requestError{msg: "VCALENDAR comp-filter is expected"}
+	}
+	var start, end *time.Time
+	for _, f := range filter.Filters {
+		if f.Name != "VEVENT" {
+			// only events are stored
+			return nil, nil
+		}
+		if f.TimeRange == nil {
+			continue
+		}
+		var err error
+		if start, err = parseTimeRange(f.TimeRange.Start); err != nil {
+			return nil, err
+		}
+		if end, err = parseTimeRange(f.TimeRange.End); err != nil {
+			return nil, err
+		}
+	}
+	objects, err := s.EventService.ListEventObjects(r.Context(), owner, start, end)
+	if err != nil {
+		return nil, err
+	}
+	resources := make([]*davResource, 0, len(objects))
+	for _, o := range objects {
+		res, err := objectResource(owner, o)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+func parseTimeRange(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(timeRangeLayout, value)
+	if err != nil {
+		return nil, &requestError{msg: fmt.Sprintf("can't parse time-range `%s`", value)}
+	}
+	return &t, nil
+}
+
+func (s *Server) calendarMultiget(r *http.Request, owner string, hrefs []string) ([]*davResource, error) {
+	resources := make([]*davResource, 0, len(hrefs))
+	for _, href := range hrefs {
+		u, err := url.Parse(href)
+		if err != nil {
+			resources = append(resources, &davResource{href: href, status: http.StatusNotFound})
+			continue
+		}
+		p, ok := parseDavPath(u.Path)
+		if !ok || p.kind != davObject || p.owner != owner {
+			resources = append(resources, &davResource{href: href, status: http.StatusNotFound})
+			continue
+		}
+		o, err := s.EventService.GetEventObject(r.Context(), p.uid, owner)
+		if err == errors.ErrNotFound {
+			resources = append(resources, &davResource{href: href, status: http.StatusNotFound})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		res, err := objectResource(owner, o)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+func (s *Server) davGet(w http.ResponseWriter, r *http.Request, owner, uid string) int {
+	o, err := s.EventService.GetEventObject(r.Context(), uid, owner)
+	if err != nil {
+		return davError(w, err)
+	}
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, o.Events()); err != nil {
+		return davError(w, err)
+	}
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("ETag", etag(o))
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(buf.Bytes())
+	}
+	return http.StatusOK
+}
+
+// checkPreconditions compares If-Match and If-None-Match headers with current ETag of the resource
+func (s *Server) checkPreconditions(r *http.Request, owner, uid string) (bool, error) {
+	ifMatch, ifNoneMatch := r.Header.Get("If-Match"), r.Header.Get("If-None-Match")
+	if ifMatch == "" && ifNoneMatch == "" {
+		return true, nil
+	}
+	current := ""
+	o, err := s.EventService.GetEventObject(r.Context(), uid, owner)
+	switch err {
+	case nil:
+		current = etag(o)
+	case errors.ErrNotFound:
+	default:
+		return false, err
+	}
+	if ifMatch != "" && (current == "" || ifMatch != "*" && !matchETag(ifMatch, current)) {
+		return false, nil
+	}
+	if ifNoneMatch != "" && current != "" && (ifNoneMatch == "*" || matchETag(ifNoneMatch, current)) {
+		return false, nil
+	}
+	return true, nil
+}
+
+func matchETag(header, current string) bool {
+	for _, t := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(t), "W/") == current {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) davPut(w http.ResponseWriter, r *http.Request, owner, uid string) int {
+	if ok, err := s.checkPreconditions(r, owner, uid); err != nil {
+		return davError(w, err)
+	} else if !ok {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return http.StatusPreconditionFailed
+	}
+	events, rejected, err := ical.Decode(io.LimitReader(r.Body, maxBodySize))
+	if err != nil {
+		return badRequest(w, err)
+	}
+	if len(rejected) > 0 {
+		return badRequest(w, rejected[0])
+	}
+	object, err := newEventObject(uid, events)
+	if err != nil {
+		return badRequest(w, err)
+	}
+	created, err := s.EventService.SaveEventObject(r.Context(), owner, object)
+	if err != nil {
+		return davError(w, err)
+	}
+	// ETag isn't returned, stored event can differ from the request
+	if created {
+		w.WriteHeader(http.StatusCreated)
+		return http.StatusCreated
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return http.StatusNoContent
+}
+
+// newEventObject checks that all events of resource have its UID and only one of them isn't an override
+func newEventObject(uid string, events []*models.Event) (*models.EventObject, error) {
+	o := &models.EventObject{}
+	for _, e := range events {
+		if e.Uid != uid {
+			return nil, &requestError{msg: fmt.Sprintf("UID `%s` doesn't match resource name", e.Uid)}
+		}
+		if e.OriginalStartTime != nil {
+			o.Overrides = append(o.Overrides, e)
+			continue
+		}
+		if o.Event != nil {
+			return nil, &requestError{msg: "resource should contain one event"}
+		}
+		o.Event = e
+	}
+	if o.Event == nil {
+		return nil, &requestError{msg: "resource doesn't contain event"}
+	}
+	return o, nil
+}
+
+func (s *Server) davDelete(w http.ResponseWriter, r *http.Request, owner, uid string) int {
+	if ok, err := s.checkPreconditions(r, owner, uid); err != nil {
+		return davError(w, err)
+	} else if !ok {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return http.StatusPreconditionFailed
+	}
+	if err := s.EventService.DeleteEventObject(r.Context(), uid, owner); err != nil {
+		return davError(w, err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return http.StatusNoContent
+}
@@ -0,0 +1,96 @@
+package web
+
+import (
+	"github.com/Brialius/calendar/internal/domain/services"
+	"github.com/Brialius/calendar/internal/memdb"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const davEvent = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:test\r\n" +
+	"BEGIN:VEVENT\r\nUID:standup\r\nDTSTART:20191104T100000Z\r\nDTEND:20191104T103000Z\r\n" +
+	"SUMMARY:standup\r\nRRULE:FREQ=DAILY;COUNT=5\r\nEND:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\nUID:standup\r\nRECURRENCE-ID:20191105T100000Z\r\nDTSTART:20191105T110000Z\r\n" +
+	"DTEND:20191105T113000Z\r\nSUMMARY:moved standup\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+
+func TestServer_serveDav(t *testing.T) {
+	storage, _ := memdb.NewMemEventStorage()
+	srv := httptest.NewServer((&Server{EventService: &services.EventService{EventStorage: storage}}).Handler())
+	defer srv.Close()
+	object := srv.URL + objectHref("user", "standup")
+
+	do := func(method, url, body string, headers ...string) (*http.Response, string) {
+		t.Helper()
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		req.SetBasicAuth("user", "")
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %s", method, url, err)
+		}
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return resp, string(b)
+	}
+
+	if resp, _ := do(http.MethodPut, object, davEvent, "If-None-Match", "*"); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("PUT: expected %d, got %s", http.StatusCreated, resp.Status)
+	}
+	resp, body := do(http.MethodGet, object, "")
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		t.Fatalf("GET: unexpected response %s, ETag `%s`", resp.Status, etag)
+	}
+	if !strings.Contains(body, "RECURRENCE-ID:20191105T100000Z") || !strings.Contains(body, "SUMMARY:moved standup") {
+		t.Errorf("GET: override is not found:\n%s", body)
+	}
+
+	resp, body = do("PROPFIND", srv.URL+calendarHref("user"),
+		`<D:propfind xmlns:D="DAV:"><D:prop><D:resourcetype/><D:getetag/><D:unknown/></D:prop></D:propfind>`, "Depth", "1")
+	if resp.StatusCode != http.StatusMultiStatus {
+		t.Fatalf("PROPFIND: expected %d, got %s", http.StatusMultiStatus, resp.Status)
+	}
+	for _, s := range []string{"<C:calendar/>", objectHref("user", "standup"), strings.Trim(etag, `"`), "<D:unknown></D:unknown>", "404 Not Found"} {
+		if !strings.Contains(body, s) {
+			t.Errorf("PROPFIND: `%s` is not found:\n%s", s, body)
+		}
+	}
+
+	query := func(start, end string) string {
+		_, body := do("REPORT", srv.URL+calendarHref("user"), `<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">`+
+			`<D:prop><D:getetag/><C:calendar-data/></D:prop><C:filter><C:comp-filter name="VCALENDAR"><C:comp-filter name="VEVENT">`+
+			`<C:time-range start="`+start+`" end="`+end+`"/></C:comp-filter></C:comp-filter></C:filter></C:calendar-query>`, "Depth", "1")
+		return body
+	}
+	if body := query("20191108T000000Z", "20191109T000000Z"); !strings.Contains(body, "BEGIN:VCALENDAR") {
+		t.Errorf("REPORT: event is not found in range:\n%s", body)
+	}
+	if body := query("20191110T000000Z", "20191111T000000Z"); strings.Contains(body, "<D:response>") {
+		t.Errorf("REPORT: finished series shouldn't be found:\n%s", body)
+	}
+
+	changed := strings.Replace(davEvent, "SUMMARY:standup", "SUMMARY:daily", 1)
+	if resp, _ := do(http.MethodPut, object, changed, "If-Match", `"stale"`); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("PUT with stale ETag: expected %d, got %s", http.StatusPreconditionFailed, resp.Status)
+	}
+	if resp, _ := do(http.MethodPut, object, changed, "If-Match", etag); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("PUT: expected %d, got %s", http.StatusNoContent, resp.Status)
+	}
+	if resp, _ := do(http.MethodDelete, object, "", "If-Match", etag); resp.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("DELETE with changed ETag: expected %d, got %s", http.StatusPreconditionFailed, resp.Status)
+	}
+	if resp, _ := do(http.MethodDelete, object, ""); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("DELETE: expected %d, got %s", http.StatusNoContent, resp.Status)
+	}
+	if resp, _ := do(http.MethodGet, object, ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET of deleted event: expected %d, got %s", http.StatusNotFound, resp.Status)
+	}
+	if resp, _ := do(http.MethodGet, srv.URL+objectHref("another", "standup"), ""); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("GET of another owner's event: expected %d, got %s", http.StatusForbidden, resp.Status)
+	}
+}
@@ -0,0 +1,60 @@
+package web
+
+import (
+	"bytes"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/ical"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const (
+	feedsPrefix = "/feeds/"
+	feedSuffix  = ".ics"
+)
+
+// FeedPath returns URL path of the feed with given token
+func FeedPath(token string) string {
+	return feedsPrefix + token + feedSuffix
+}
+
+// serveFeed renders events of token's owner as iCalendar, unknown tokens are not distinguished from absent pages
+func (s *Server) serveFeed(w http.ResponseWriter, r *http.Request) {
+	httpFeedCounter.Inc()
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		httpFeedErrorCounter.Inc()
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, feedsPrefix)
+	token := strings.TrimSuffix(name, feedSuffix)
+	if token == "" || token == name || strings.Contains(token, "/") {
+		httpFeedErrorCounter.Inc()
+		http.NotFound(w, r)
+		return
+	}
+	events, err := s.EventService.GetFeed(r.Context(), token)
+	if err != nil {
+		httpFeedErrorCounter.Inc()
+		if err == errors.ErrNotFound {
+			http.NotFound(w, r)
+			return
+		}
+		log.Printf("Error during feed preparing: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, events); err != nil {
+		httpFeedErrorCounter.Inc()
+		log.Printf("Error during feed encoding: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ical.ContentType)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(buf.Bytes())
+	}
+}
@@ -0,0 +1,66 @@
+package web
+
+import (
+	"context"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/Brialius/calendar/internal/domain/services"
+	"github.com/Brialius/calendar/internal/ical"
+	"github.com/Brialius/calendar/internal/memdb"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServer_serveFeed(t *testing.T) {
+	ctx := context.Background()
+	storage, _ := memdb.NewMemEventStorage()
+	es := &services.EventService{EventStorage: storage}
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	end := start.Add(time.Hour)
+	if _, err := es.CreateEvent(ctx, &models.Event{Owner: "user", Title: "meeting", StartTime: &start, EndTime: &end}); err != nil {
+		t.Fatalf("can't create event: %s", err)
+	}
+	token, err := es.CreateFeedToken(ctx, "user")
+	if err != nil {
+		t.Fatalf("can't create feed token: %s", err)
+	}
+	srv := httptest.NewServer((&Server{EventService: es}).Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + FeedPath(token.Token))
+	if err != nil {
+		t.Fatalf("can't get feed: %s", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != ical.ContentType {
+		t.Fatalf("unexpected response: %s %s", resp.Status, resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(string(body), "SUMMARY:meeting") {
+		t.Errorf("event is not found in feed:\n%s", body)
+	}
+
+	resp, err = http.Post(srv.URL+FeedPath(token.Token), "text/plain", nil)
+	if err != nil {
+		t.Fatalf("can't post feed: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected %d, got %d", http.StatusMethodNotAllowed, resp.StatusCode)
+	}
+
+	if err := es.RevokeFeedToken(ctx, token.Token, "user"); err != nil {
+		t.Fatalf("can't revoke feed token: %s", err)
+	}
+	resp, err = http.Get(srv.URL + FeedPath(token.Token))
+	if err != nil {
+		t.Fatalf("can't get feed: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("revoked feed: expected %d, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+}
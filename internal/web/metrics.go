@@ -0,0 +1,52 @@
+package web
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	httpFeedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "http_feed_count",
+		Help:        "HTTP calendar feed",
+		ConstLabels: prometheus.Labels{"http": "feed"},
+	})
+
+	httpFeedErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "http_feed_error_count",
+		Help:        "HTTP calendar feed error",
+		ConstLabels: prometheus.Labels{"http": "feed"},
+	})
+
+	httpDavCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "http_dav_count",
+		Help:        "HTTP CalDAV request",
+		ConstLabels: prometheus.Labels{"http": "dav"},
+	})
+
+	httpDavErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "http_dav_error_count",
+		Help:        "HTTP CalDAV request error",
+		ConstLabels: prometheus.Labels{"http": "dav"},
+	})
+
+	httpEventsCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "http_events_count",
+		Help:        "HTTP events API request",
+		ConstLabels: prometheus.Labels{"http": "events"},
+	})
+
+	httpEventsErrorCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "http_events_error_count",
+		Help:        "HTTP events API request error",
+		ConstLabels: prometheus.Labels{"http": "events"},
+	})
+)
+
+func init() {
+	prometheus.MustRegister(httpFeedCounter)
+	prometheus.MustRegister(httpFeedErrorCounter)
+	prometheus.MustRegister(httpDavCounter)
+	prometheus.MustRegister(httpDavErrorCounter)
+	prometheus.MustRegister(httpEventsCounter)
+	prometheus.MustRegister(httpEventsErrorCounter)
+}
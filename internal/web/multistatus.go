@@ -0,0 +1,195 @@
+package web
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	davNs    = "DAV:"
+	caldavNs = "urn:ietf:params:xml:ns:caldav"
+)
+
+// prefixes of namespaces used in responses
+var nsPrefixes = map[string]string{davNs: "D", caldavNs: "C"}
+
+// davProperty is WebDAV property with value rendered as inner XML
+type davProperty struct {
+	name  xml.Name
+	value string
+}
+
+func davProp(local, value string) davProperty {
+	return davProperty{name: xml.Name{Space: davNs, Local: local}, value: value}
+}
+
+func caldavProp(local, value string) davProperty {
+	return davProperty{name: xml.Name{Space: caldavNs, Local: local}, value: value}
+}
+
+// davResource is resource of multistatus response, either with properties or with status only
+type davResource struct {
+	href   string
+	props  []davProperty
+	status int
+}
+
+// propRequest is parsed prop element, names of requested properties are kept only
+type propRequest struct {
+	Names []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+type propfindRequest struct {
+	XMLName  xml.Name     `xml:"DAV: propfind"`
+	Prop     *propRequest `xml:"DAV: prop"`
+	AllProp  *struct{}    `xml:"DAV: allprop"`
+	PropName *struct{}    `xml:"DAV: propname"`
+}
+
+// propSelector selects properties of resource which are returned in response
+type propSelector struct {
+	names []xml.Name
+	// names only are returned, without values
+	namesOnly bool
+}
+
+// all properties are returned if names aren't set, except ones which should be requested explicitly
+var explicitProps = map[xml.Name]bool{{Space: caldavNs, Local: "calendar-data"}: true}
+
+func newPropSelector(p *propRequest) *propSelector {
+	s := &propSelector{}
+	if p != nil {
+		for _, n := range p.Names {
+			s.names = append(s.names, n.XMLName)
+		}
+	}
+	return s
+}
+
+// parsePropfind reads PROPFIND body, empty body is treated as allprop
+func parsePropfind(r io.Reader) (*propfindRequest, error) {
+	body, err := readBody(r)
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		return &propfindRequest{}, err
+	}
+	req := &propfindRequest{}
+	if err := xml.Unmarshal(body, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (pr *propfindRequest) selector() *propSelector {
+	if pr.Prop == nil || pr.AllProp != nil {
+		return &propSelector{namesOnly: pr.PropName != nil}
+	}
+	return newPropSelector(pr.Prop)
+}
+
+// split returns found and not found properties of resource
+func (s *propSelector) split(props []davProperty) (found []davProperty, missing []davProperty) {
+	if len(s.names) == 0 {
+		for _, p := range props {
+			if explicitProps[p.name] {
+				continue
+			}
+			if s.namesOnly {
+				p.value = ""
+			}
+			found = append(found, p)
+		}
+		return found, nil
+	}
+	for _, n := range s.names {
+		p, ok := findProp(props, n)
+		if !ok {
+			missing = append(missing, davProperty{name: n})
+			continue
+		}
+		found = append(found, p)
+	}
+	return found, missing
+}
+
+func findProp(props []davProperty, name xml.Name) (davProperty, bool) {
+	for _, p := range props {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return davProperty{}, false
+}
+
+// writeMultistatus writes 207 response with properties of resources selected by s
+func writeMultistatus(w http.ResponseWriter, s *propSelector, resources []*davResource) {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<D:multistatus xmlns:D="%s" xmlns:C="%s">`, davNs, caldavNs)
+	for _, r := range resources {
+		b.WriteString("<D:response><D:href>")
+		writeText(&b, r.href)
+		b.WriteString("</D:href>")
+		if r.status != 0 {
+			writeStatus(&b, r.status)
+		} else {
+			found, missing := s.split(r.props)
+			writePropstat(&b, found, http.StatusOK)
+			writePropstat(&b, missing, http.StatusNotFound)
+		}
+		b.WriteString("</D:response>")
+	}
+	b.WriteString("</D:multistatus>")
+	w.Header().Set("Content-Type", `application/xml; charset=utf-8`)
+	w.WriteHeader(http.StatusMultiStatus)
+	_, _ = io.WriteString(w, b.String())
+}
+
+func writePropstat(b *strings.Builder, props []davProperty, status int) {
+	if len(props) == 0 {
+		return
+	}
+	b.WriteString("<D:propstat><D:prop>")
+	for _, p := range props {
+		open, end := tags(p.name)
+		b.WriteString(open)
+		b.WriteString(p.value)
+		b.WriteString(end)
+	}
+	b.WriteString("</D:prop>")
+	writeStatus(b, status)
+	b.WriteString("</D:propstat>")
+}
+
+func writeStatus(b *strings.Builder, status int) {
+	fmt.Fprintf(b, "<D:status>HTTP/1.1 %d %s</D:status>", status, http.StatusText(status))
+}
+
+// tags returns opening and closing tags of element, unknown namespaces are declared in place
+func tags(name xml.Name) (string, string) {
+	if prefix, ok := nsPrefixes[name.Space]; ok {
+		return "<" + prefix + ":" + name.Local + ">", "</" + prefix + ":" + name.Local + ">"
+	}
+	var ns strings.Builder
+	writeText(&ns, name.Space)
+	return `<X:` + name.Local + ` xmlns:X="` + ns.String() + `">`, "</X:" + name.Local + ">"
+}
+
+func writeText(b *strings.Builder, s string) {
+	_ = xml.EscapeText(b, []byte(s))
+}
+
+func escapeText(s string) string {
+	var b strings.Builder
+	writeText(&b, s)
+	return b.String()
+}
+
+func hrefValue(href string) string {
+	return "<D:href>" + escapeText(href) + "</D:href>"
+}
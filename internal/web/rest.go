@@ -0,0 +1,284 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Brialius/calendar/internal/domain/errors"
+	"github.com/Brialius/calendar/internal/domain/models"
+	"github.com/satori/go.uuid"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const eventsPath = "/v1/events"
+
+// ownerHeader identifies owner of events like owner metadata of gRPC API, if authentication is disabled
+const ownerHeader = "Owner"
+
+// eventJson is event representation of JSON API, times are RFC 3339 strings
+type eventJson struct {
+	Id                string     `json:"id,omitempty"`
+	Title             string     `json:"title"`
+	Text              string     `json:"text"`
+	StartTime         *time.Time `json:"start_time"`
+	EndTime           *time.Time `json:"end_time"`
+	Recurrence        string     `json:"recurrence,omitempty"`
+	SeriesId          string     `json:"series_id,omitempty"`
+	OriginalStartTime *time.Time `json:"original_start_time,omitempty"`
+	// Transparency is busy, free or tentative, busy if it's not set
+	Transparency string `json:"transparency,omitempty"`
+	// AllDay event lasts dates from start_time till end_time exclusive
+	AllDay bool `json:"all_day,omitempty"`
+	// TimeZone is IANA time zone of the event, UTC if it's not set
+	TimeZone string `json:"time_zone,omitempty"`
+	// Reminders are Go durations before start_time, e.g. `24h` or `15m`, one day if they aren't set
+	Reminders []string `json:"reminders,omitempty"`
+}
+
+// updateEventJson is body of update request, scope and occurrence_start_time select changed occurrences
+// of recurring event like in gRPC API
+type updateEventJson struct {
+	eventJson
+	Scope               string     `json:"scope"`
+	OccurrenceStartTime *time.Time `json:"occurrence_start_time"`
+}
+
+type errorJson struct {
+	Error string `json:"error"`
+}
+
+func eventToJson(e *models.Event) *eventJson {
+	res := &eventJson{
+		Id:                e.Id.String(),
+		Title:             e.Title,
+		Text:              e.Text,
+		StartTime:         e.StartTime,
+		EndTime:           e.EndTime,
+		Recurrence:        e.Recurrence,
+		OriginalStartTime: e.OriginalStartTime,
+		Transparency:      string(e.Transparency),
+		AllDay:            e.AllDay,
+		TimeZone:          e.TimeZone,
+	}
+	for _, r := range e.EventReminders() {
+		res.Reminders = append(res.Reminders, r.String())
+	}
+	if e.SeriesId != nil {
+		res.SeriesId = e.SeriesId.String()
+	}
+	return res
+}
+
+func (ej *eventJson) toEvent(owner string) (*models.Event, error) {
+	if ej.StartTime == nil || ej.EndTime == nil {
+		return nil, fmt.Errorf("start_time and end_time are required")
+	}
+	var reminders models.Reminders
+	for _, r := range ej.Reminders {
+		d, err := time.ParseDuration(r)
+		if err != nil {
+			return nil, fmt.Errorf("reminder is incorrect: %s", err)
+		}
+		reminders = append(reminders, d)
+	}
+	return &models.Event{
+		Owner:        owner,
+		Title:        ej.Title,
+		Text:         ej.Text,
+		StartTime:    ej.StartTime,
+		EndTime:      ej.EndTime,
+		Recurrence:   ej.Recurrence,
+		Transparency: models.Transparency(ej.Transparency),
+		AllDay:       ej.AllDay,
+		TimeZone:     ej.TimeZone,
+		Reminders:    reminders,
+	}, nil
+}
+
+func parseScope(s string) (models.Scope, error) {
+	switch s {
+	case "", "all":
+		return models.ScopeAll, nil
+	case "this":
+		return models.ScopeThis, nil
+	case "this_and_following":
+		return models.ScopeThisAndFollowing, nil
+	}
+	return models.ScopeAll, fmt.Errorf("unknown scope `%s`", s)
+}
+
+// parseQueryTime parses optional RFC 3339 query parameter
+func parseQueryTime(r *http.Request, name string) (*time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("%s is incorrect: %s", name, err)
+	}
+	return &t, nil
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) int {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("can't write response: %s", err)
+	}
+	return status
+}
+
+func jsonError(w http.ResponseWriter, status int, msg string) int {
+	return writeJson(w, status, &errorJson{Error: msg})
+}
+
+// eventError maps domain errors to HTTP status codes
+func eventError(w http.ResponseWriter, err error) int {
+	switch err {
+	case errors.ErrNotFound:
+		return jsonError(w, http.StatusNotFound, err.Error())
+	case errors.ErrOverlaping:
+		return jsonError(w, http.StatusConflict, err.Error())
+	}
+	if berr, ok := err.(errors.EventError); ok {
+		return jsonError(w, http.StatusBadRequest, string(berr))
+	}
+	log.Printf("Error during events API request: %s", err)
+	return jsonError(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+// serveEvents serves JSON API of events:
+//
+//	POST   /v1/events                         create event
+//	GET    /v1/events?start=...[&end=...]     list events, recurring ones are expanded
+//	GET    /v1/events/{id}                    get event
+//	PUT    /v1/events/{id}                    update event
+//	DELETE /v1/events/{id}[?scope=...]        delete event
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	httpEventsCounter.Inc()
+	var status int
+	owner := s.requestOwner(r, r.Header.Get(ownerHeader))
+	id := strings.Trim(strings.TrimPrefix(r.URL.Path, eventsPath), "/")
+	switch {
+	case owner == "":
+		status = jsonError(w, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	case strings.Contains(id, "/"):
+		status = jsonError(w, http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	case id == "" && r.Method == http.MethodPost:
+		status = s.createEvent(w, r, owner)
+	case id == "" && r.Method == http.MethodGet:
+		status = s.listEvents(w, r, owner)
+	case id == "":
+		w.Header().Set("Allow", "GET, POST")
+		status = jsonError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	case !isUuid(id):
+		status = jsonError(w, http.StatusNotFound, errors.ErrNotFound.Error())
+	case r.Method == http.MethodGet:
+		status = s.getEvent(w, r, owner, id)
+	case r.Method == http.MethodPut:
+		status = s.updateEvent(w, r, owner, id)
+	case r.Method == http.MethodDelete:
+		status = s.deleteEvent(w, r, owner, id)
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		status = jsonError(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+	}
+	if status >= http.StatusBadRequest {
+		httpEventsErrorCounter.Inc()
+	}
+}
+
+func isUuid(id string) bool {
+	_, err := uuid.FromString(id)
+	return err == nil
+}
+
+func (s *Server) createEvent(w http.ResponseWriter, r *http.Request, owner string) int {
+	req := &eventJson{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(req); err != nil {
+		return jsonError(w, http.StatusBadRequest, err.Error())
+	}
+	event, err := req.toEvent(owner)
+	if err != nil {
+		return jsonError(w, http.StatusBadRequest, err.Error())
+	}
+	log.Printf("Creating new event: `%s`...", event.Title)
+	event, err = s.EventService.CreateEvent(r.Context(), event)
+	if err != nil {
+		return eventError(w, err)
+	}
+	w.Header().Set("Location", eventsPath+"/"+event.Id.String())
+	return writeJson(w, http.StatusCreated, eventToJson(event))
+}
+
+func (s *Server) listEvents(w http.ResponseWriter, r *http.Request, owner string) int {
+	start, err := parseQueryTime(r, "start")
+	if err != nil {
+		return jsonError(w, http.StatusBadRequest, err.Error())
+	}
+	if start == nil {
+		return jsonError(w, http.StatusBadRequest, "start is required")
+	}
+	end, err := parseQueryTime(r, "end")
+	if err != nil {
+		return jsonError(w, http.StatusBadRequest, err.Error())
+	}
+	events, err := s.EventService.ListEvents(r.Context(), owner, start, end)
+	if err != nil {
+		return eventError(w, err)
+	}
+	res := make([]*eventJson, 0, len(events))
+	for _, e := range events {
+		res = append(res, eventToJson(e))
+	}
+	return writeJson(w, http.StatusOK, res)
+}
+
+func (s *Server) getEvent(w http.ResponseWriter, r *http.Request, owner, id string) int {
+	event, err := s.EventService.GetEvent(r.Context(), id, owner)
+	if err != nil {
+		return eventError(w, err)
+	}
+	return writeJson(w, http.StatusOK, eventToJson(event))
+}
+
+func (s *Server) updateEvent(w http.ResponseWriter, r *http.Request, owner, id string) int {
+	req := &updateEventJson{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(req); err != nil {
+		return jsonError(w, http.StatusBadRequest, err.Error())
+	}
+	event, err := req.toEvent(owner)
+	if err != nil {
+		return jsonError(w, http.StatusBadRequest, err.Error())
+	}
+	scope, err := parseScope(req.Scope)
+	if err != nil {
+		return jsonError(w, http.StatusBadRequest, err.Error())
+	}
+	log.Printf("Updating event: `%s`...", id)
+	event, err = s.EventService.UpdateEvent(r.Context(), id, scope, req.OccurrenceStartTime, event)
+	if err != nil {
+		return eventError(w, err)
+	}
+	return writeJson(w, http.StatusOK, eventToJson(event))
+}
+
+func (s *Server) deleteEvent(w http.ResponseWriter, r *http.Request, owner, id string) int {
+	scope, err := parseScope(r.URL.Query().Get("scope"))
+	if err != nil {
+		return jsonError(w, http.StatusBadRequest, err.Error())
+	}
+	occurrenceStart, err := parseQueryTime(r, "occurrence_start_time")
+	if err != nil {
+		return jsonError(w, http.StatusBadRequest, err.Error())
+	}
+	log.Printf("Deleting event: `%s`...", id)
+	if err := s.EventService.DeleteEvent(r.Context(), id, owner, scope, occurrenceStart); err != nil {
+		return eventError(w, err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return http.StatusNoContent
+}
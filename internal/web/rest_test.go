@@ -0,0 +1,73 @@
+package web
+
+import (
+	"encoding/json"
+	"github.com/Brialius/calendar/internal/domain/services"
+	"github.com/Brialius/calendar/internal/memdb"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer_serveEvents(t *testing.T) {
+	storage, _ := memdb.NewMemEventStorage()
+	srv := httptest.NewServer((&Server{EventService: &services.EventService{EventStorage: storage}}).Handler())
+	defer srv.Close()
+
+	do := func(method, path, body string, res interface{}) int {
+		t.Helper()
+		req, _ := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		req.Header.Set(ownerHeader, "user")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %s", method, path, err)
+		}
+		defer resp.Body.Close()
+		if res != nil {
+			if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+				t.Fatalf("%s %s: can't decode response: %s", method, path, err)
+			}
+		}
+		return resp.StatusCode
+	}
+
+	created := &eventJson{}
+	body := `{"title": "meeting", "start_time": "2019-11-04T10:00:00Z", "end_time": "2019-11-04T11:00:00Z"}`
+	if status := do(http.MethodPost, eventsPath, body, created); status != http.StatusCreated || created.Id == "" {
+		t.Fatalf("POST: expected %d, got %d", http.StatusCreated, status)
+	}
+	overlapping := `{"title": "another", "start_time": "2019-11-04T10:30:00Z", "end_time": "2019-11-04T11:30:00Z"}`
+	if status := do(http.MethodPost, eventsPath, overlapping, nil); status != http.StatusConflict {
+		t.Errorf("POST of overlapping event: expected %d, got %d", http.StatusConflict, status)
+	}
+	incorrect := `{"title": "another", "start_time": "2019-11-05T10:00:00Z", "end_time": "2019-11-05T09:00:00Z"}`
+	if status := do(http.MethodPost, eventsPath, incorrect, nil); status != http.StatusBadRequest {
+		t.Errorf("POST with incorrect end: expected %d, got %d", http.StatusBadRequest, status)
+	}
+
+	var events []*eventJson
+	if status := do(http.MethodGet, eventsPath+"?start=2019-11-04T00:00:00Z", "", &events); status != http.StatusOK || len(events) != 1 {
+		t.Errorf("GET list: expected 1 event, got %d (%d)", len(events), status)
+	}
+	updated := &eventJson{}
+	body = `{"title": "moved", "start_time": "2019-11-04T12:00:00Z", "end_time": "2019-11-04T13:00:00Z"}`
+	if status := do(http.MethodPut, eventsPath+"/"+created.Id, body, updated); status != http.StatusOK || updated.Title != "moved" {
+		t.Errorf("PUT: unexpected response %d %+v", status, updated)
+	}
+	if status := do(http.MethodDelete, eventsPath+"/"+created.Id, "", nil); status != http.StatusNoContent {
+		t.Errorf("DELETE: expected %d, got %d", http.StatusNoContent, status)
+	}
+	if status := do(http.MethodGet, eventsPath+"/"+created.Id, "", nil); status != http.StatusNotFound {
+		t.Errorf("GET of deleted event: expected %d, got %d", http.StatusNotFound, status)
+	}
+
+	resp, err := http.Get(srv.URL + eventsPath + "?start=2019-11-04T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GET without owner: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET without owner: expected %d, got %d", http.StatusUnauthorized, resp.StatusCode)
+	}
+}
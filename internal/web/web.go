@@ -0,0 +1,50 @@
+package web
+
+import (
+	"crypto/tls"
+	"github.com/Brialius/calendar/internal/auth"
+	"github.com/Brialius/calendar/internal/domain/services"
+	"log"
+	"net"
+	"net/http"
+)
+
+// Server serves calendar feeds, CalDAV and JSON API of events over HTTP
+type Server struct {
+	EventService *services.EventService
+	// Authenticator verifies credentials of requests, owners sent by clients are trusted if it's nil
+	Authenticator *auth.Authenticator
+	Port          string
+	// Tls enables HTTPS, credentials of requests are sent in plain text if it's nil
+	Tls *tls.Config
+}
+
+func (s *Server) Serve() {
+	go func() {
+		l, err := net.Listen("tcp", ":"+s.Port)
+		if err == nil {
+			err = s.serve(l)
+		}
+		if err != nil {
+			log.Fatalf("Can't start http server: %s", err)
+		}
+	}()
+}
+
+// serve accepts connections of the listener, they are wrapped in TLS if it's configured
+func (s *Server) serve(l net.Listener) error {
+	if s.Tls != nil {
+		l = tls.NewListener(l, s.Tls)
+	}
+	return http.Serve(l, s.Handler())
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(feedsPrefix, s.serveFeed)
+	mux.HandleFunc(davPrefix, s.serveDav)
+	mux.HandleFunc("/.well-known/caldav", s.serveWellKnown)
+	mux.HandleFunc(eventsPath, s.serveEvents)
+	mux.HandleFunc(eventsPath+"/", s.serveEvents)
+	return mux
+}
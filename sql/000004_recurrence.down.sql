@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS event_occurrence_notifications;
+alter table events
+    drop column recurrence;
\ No newline at end of file
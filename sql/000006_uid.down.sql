@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS owner_uid_idx;
+alter table events
+    drop column uid;
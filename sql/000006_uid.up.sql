@@ -0,0 +1,7 @@
+alter table events
+    add uid text;
+update events
+set uid = id::text;
+alter table events
+    alter column uid set not null;
+CREATE UNIQUE INDEX owner_uid_idx ON events (owner, uid);
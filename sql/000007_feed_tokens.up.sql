@@ -0,0 +1,6 @@
+create table feed_tokens (
+                        token text primary key,
+                        owner text not null,
+                        created_at timestamp not null
+);
+CREATE INDEX feed_tokens_owner_idx ON feed_tokens (owner);
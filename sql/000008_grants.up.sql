@@ -0,0 +1,8 @@
+create table grants (
+                        owner text not null,
+                        grantee text not null,
+                        role text not null,
+                        created_at timestamp not null,
+                        primary key (owner, grantee)
+);
+CREATE INDEX grants_grantee_idx ON grants (grantee);
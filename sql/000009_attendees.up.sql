@@ -0,0 +1,7 @@
+create table attendees (
+                        event_id UUID not null references events (id) on delete cascade,
+                        name text not null,
+                        status text not null,
+                        primary key (event_id, name)
+);
+CREATE INDEX attendees_name_idx ON attendees (name);
@@ -0,0 +1,5 @@
+alter table events
+    drop constraint if exists events_owner_time_excl;
+drop function if exists check_events_overlap(text);
+alter table events
+    alter column end_time drop not null;
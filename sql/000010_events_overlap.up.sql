@@ -0,0 +1,31 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+-- events without end are unbounded ranges, they are stored as events without duration
+update events
+set end_time = start_time
+where end_time is null;
+alter table events
+    alter column end_time set not null;
+-- check_events_overlap fails with the list of existing overlapping events, which match the filter,
+-- so the constraint isn't added to the database, where they must be moved or cancelled first
+create function check_events_overlap(filter text) returns void
+    language plpgsql as
+$$
+declare
+    conflicts text;
+begin
+    execute format('with e as (select id, owner, start_time, end_time from events where %s) ' ||
+                   'select string_agg(a.id || '' and '' || b.id, '', '') from e a join e b ' ||
+                   'on a.owner = b.owner and a.id < b.id ' ||
+                   'and tstzrange(a.start_time, a.end_time, ''[)'') && tstzrange(b.start_time, b.end_time, ''[)'')',
+                   filter) into conflicts;
+    if conflicts is not null then
+        raise exception 'overlapping events must be moved or cancelled before migration: %', conflicts;
+    end if;
+end
+$$;
+-- single events and overrides of the same owner can't overlap, end_time is excluded from the range,
+-- so back-to-back events are allowed. Occurrences of recurring events are checked by the service
+select check_events_overlap($f$recurrence = '' and not cancelled$f$);
+alter table events
+    add constraint events_owner_time_excl exclude using gist (owner with =, tsrange(start_time, end_time, '[)') with &&)
+        where (recurrence = '' and not cancelled);
@@ -0,0 +1,8 @@
+alter table events
+    drop constraint events_owner_time_excl;
+alter table events
+    drop column transparency;
+select check_events_overlap($f$recurrence = '' and not cancelled$f$);
+alter table events
+    add constraint events_owner_time_excl exclude using gist (owner with =, tsrange(start_time, end_time, '[)') with &&)
+        where (recurrence = '' and not cancelled);
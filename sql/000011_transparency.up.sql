@@ -0,0 +1,9 @@
+alter table events
+    add transparency text not null default 'busy';
+-- free and tentative events don't block other bookings
+alter table events
+    drop constraint events_owner_time_excl;
+select check_events_overlap($f$recurrence = '' and not cancelled and transparency not in ('free', 'tentative')$f$);
+alter table events
+    add constraint events_owner_time_excl exclude using gist (owner with =, tsrange(start_time, end_time, '[)') with &&)
+        where (recurrence = '' and not cancelled and transparency not in ('free', 'tentative'));
@@ -0,0 +1,9 @@
+alter table events
+    add all_day boolean not null default false;
+-- all-day events are dates, they don't block bookings of any time of the day
+alter table events
+    drop constraint events_owner_time_excl;
+select check_events_overlap($f$recurrence = '' and not cancelled and not all_day and transparency not in ('free', 'tentative')$f$);
+alter table events
+    add constraint events_owner_time_excl exclude using gist (owner with =, tsrange(start_time, end_time, '[)') with &&)
+        where (recurrence = '' and not cancelled and not all_day and transparency not in ('free', 'tentative'));
@@ -0,0 +1,17 @@
+alter table grants
+    alter column created_at type timestamp using created_at at time zone 'UTC';
+alter table feed_tokens
+    alter column created_at type timestamp using created_at at time zone 'UTC';
+alter table event_occurrence_notifications
+    alter column start_time type timestamp using start_time at time zone 'UTC';
+alter table events
+    drop constraint events_owner_time_excl;
+alter table events
+    drop column time_zone,
+    alter column start_time type timestamp using start_time at time zone 'UTC',
+    alter column end_time type timestamp using end_time at time zone 'UTC',
+    alter column original_start_time type timestamp using original_start_time at time zone 'UTC';
+select check_events_overlap($f$recurrence = '' and not cancelled and not all_day and transparency not in ('free', 'tentative')$f$);
+alter table events
+    add constraint events_owner_time_excl exclude using gist (owner with =, tsrange(start_time, end_time, '[)') with &&)
+        where (recurrence = '' and not cancelled and not all_day and transparency not in ('free', 'tentative'));
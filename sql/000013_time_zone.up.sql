@@ -0,0 +1,18 @@
+-- times are instants, existing values were stored in UTC
+alter table events
+    drop constraint events_owner_time_excl;
+alter table events
+    alter column start_time type timestamptz using start_time at time zone 'UTC',
+    alter column end_time type timestamptz using end_time at time zone 'UTC',
+    alter column original_start_time type timestamptz using original_start_time at time zone 'UTC',
+    add time_zone text not null default '';
+select check_events_overlap($f$recurrence = '' and not cancelled and not all_day and transparency not in ('free', 'tentative')$f$);
+alter table events
+    add constraint events_owner_time_excl exclude using gist (owner with =, tstzrange(start_time, end_time, '[)') with &&)
+        where (recurrence = '' and not cancelled and not all_day and transparency not in ('free', 'tentative'));
+alter table event_occurrence_notifications
+    alter column start_time type timestamptz using start_time at time zone 'UTC';
+alter table feed_tokens
+    alter column created_at type timestamptz using created_at at time zone 'UTC';
+alter table grants
+    alter column created_at type timestamptz using created_at at time zone 'UTC';
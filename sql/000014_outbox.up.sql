@@ -0,0 +1,8 @@
+-- notifications are saved in the same transaction as changes, which caused them, and published by relay
+create table outbox (
+                        id UUID primary key,
+                        payload text not null,
+                        created_at timestamptz not null,
+                        sent_at timestamptz
+);
+CREATE INDEX outbox_pending_idx ON outbox (created_at) WHERE sent_at IS NULL;
@@ -0,0 +1,6 @@
+-- lease is held by one of service replicas, e.g. notificator leader, till it expires
+create table leases (
+                        name text primary key,
+                        holder text not null,
+                        expires_at timestamptz not null
+);
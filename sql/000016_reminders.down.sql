@@ -0,0 +1,15 @@
+alter table events
+    add notified bool default false;
+create table event_occurrence_notifications (
+                        event_id UUID not null references events (id) on delete cascade,
+                        start_time timestamptz not null,
+                        primary key (event_id, start_time)
+);
+insert into event_occurrence_notifications(event_id, start_time)
+    select distinct n.event_id, n.start_time from reminder_notifications n join events e on e.id = n.event_id
+    where e.recurrence <> '';
+update events set notified = true
+    where recurrence = '' and id in (select event_id from reminder_notifications);
+drop table reminder_notifications;
+alter table events
+    drop column reminders;
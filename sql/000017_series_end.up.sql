@@ -0,0 +1,4 @@
+-- end of the last occurrence of recurring event limited by COUNT or UNTIL, null for endless series.
+-- Existing series are treated as endless until they're updated
+alter table events
+    add series_end timestamptz;
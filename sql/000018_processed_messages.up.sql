@@ -0,0 +1,6 @@
+-- ids of notifications sent by sender, redelivered duplicates of them are skipped till they're deleted by retention
+create table processed_messages (
+                        message_id UUID primary key,
+                        processed_at timestamptz not null
+);
+CREATE INDEX processed_messages_processed_at_idx ON processed_messages (processed_at);
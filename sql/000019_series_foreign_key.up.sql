@@ -0,0 +1,3 @@
+-- series_id references events since 000005, the version rebuilds events table in SQLite,
+-- which can't add foreign key to existing column
+select 1;
@@ -0,0 +1,2 @@
+-- the version aligns columns of events in SQLite, they already match in PostgreSQL
+select 1;
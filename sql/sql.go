@@ -0,0 +1,14 @@
+// Package sql embeds schema migrations, so binaries don't depend on the sql/ directory at runtime
+package sql
+
+import "embed"
+
+// Postgres contains migrations for PostgreSQL storage, they are also applied by the migrate/migrate container
+//
+//go:embed *.sql
+var Postgres embed.FS
+
+// Sqlite contains the same migrations adapted to SQLite dialect
+//
+//go:embed sqlite/*.sql
+var Sqlite embed.FS
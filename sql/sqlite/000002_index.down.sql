@@ -0,0 +1 @@
+DROP INDEX IF EXISTS owner_start_time_idx;
\ No newline at end of file
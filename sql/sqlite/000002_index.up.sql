@@ -0,0 +1 @@
+CREATE INDEX owner_start_time_idx ON events (owner, start_time);
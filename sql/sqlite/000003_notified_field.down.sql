@@ -0,0 +1,2 @@
+alter table events
+    drop column notified;
\ No newline at end of file
@@ -0,0 +1,2 @@
+alter table events
+    add notified bool default false;
\ No newline at end of file
@@ -0,0 +1,7 @@
+alter table events
+    add recurrence text not null default '';
+create table event_occurrence_notifications (
+                        event_id UUID not null references events (id) on delete cascade,
+                        start_time timestamp not null,
+                        primary key (event_id, start_time)
+);
\ No newline at end of file
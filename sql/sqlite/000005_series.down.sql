@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS series_original_start_time_idx;
+alter table events
+    drop column cancelled;
+alter table events
+    drop column original_start_time;
+alter table events
+    drop column series_id;
@@ -0,0 +1,7 @@
+alter table events
+    add series_id UUID;
+alter table events
+    add original_start_time timestamp;
+alter table events
+    add cancelled bool not null default false;
+CREATE UNIQUE INDEX series_original_start_time_idx ON events (series_id, original_start_time);
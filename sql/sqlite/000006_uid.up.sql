@@ -0,0 +1,5 @@
+alter table events
+    add uid text not null default '';
+update events
+set uid = id;
+CREATE UNIQUE INDEX owner_uid_idx ON events (owner, uid);
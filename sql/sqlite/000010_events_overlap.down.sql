@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS events_overlap_insert;
+DROP TRIGGER IF EXISTS events_overlap_update;
@@ -0,0 +1,31 @@
+DROP TRIGGER events_overlap_insert;
+DROP TRIGGER events_overlap_update;
+alter table events
+    drop column transparency;
+-- existing overlapping events abort migration like in 000010
+CREATE TEMP TABLE events_overlap_check(id UUID);
+CREATE TEMP TRIGGER events_overlap_check BEFORE INSERT ON events_overlap_check
+BEGIN
+    SELECT RAISE(ABORT, 'overlapping events must be moved or cancelled before migration');
+END;
+INSERT INTO events_overlap_check
+SELECT a.id FROM events a JOIN events b ON a.owner = b.owner AND a.id < b.id
+    AND a.start_time < b.end_time AND a.end_time > b.start_time
+WHERE a.recurrence = '' AND NOT a.cancelled AND b.recurrence = '' AND NOT b.cancelled;
+DROP TABLE events_overlap_check;
+CREATE TRIGGER events_overlap_insert BEFORE INSERT ON events
+    WHEN NEW.recurrence = '' AND NOT NEW.cancelled
+BEGIN
+    SELECT RAISE(ABORT, 'events overlap')
+    WHERE EXISTS(SELECT 1 FROM events
+                 WHERE owner = NEW.owner AND id <> NEW.id AND recurrence = '' AND NOT cancelled
+                   AND start_time < NEW.end_time AND end_time > NEW.start_time);
+END;
+CREATE TRIGGER events_overlap_update BEFORE UPDATE OF start_time, end_time, recurrence, cancelled ON events
+    WHEN NEW.recurrence = '' AND NOT NEW.cancelled
+BEGIN
+    SELECT RAISE(ABORT, 'events overlap')
+    WHERE EXISTS(SELECT 1 FROM events
+                 WHERE owner = NEW.owner AND id <> NEW.id AND recurrence = '' AND NOT cancelled
+                   AND start_time < NEW.end_time AND end_time > NEW.start_time);
+END;
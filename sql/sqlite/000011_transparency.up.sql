@@ -0,0 +1,23 @@
+alter table events
+    add transparency text not null default 'busy';
+-- free and tentative events don't block other bookings
+DROP TRIGGER events_overlap_insert;
+DROP TRIGGER events_overlap_update;
+CREATE TRIGGER events_overlap_insert BEFORE INSERT ON events
+    WHEN NEW.recurrence = '' AND NOT NEW.cancelled AND NEW.transparency NOT IN ('free', 'tentative')
+BEGIN
+    SELECT RAISE(ABORT, 'events overlap')
+    WHERE EXISTS(SELECT 1 FROM events
+                 WHERE owner = NEW.owner AND id <> NEW.id AND recurrence = '' AND NOT cancelled
+                   AND transparency NOT IN ('free', 'tentative')
+                   AND start_time < NEW.end_time AND end_time > NEW.start_time);
+END;
+CREATE TRIGGER events_overlap_update BEFORE UPDATE OF start_time, end_time, recurrence, cancelled, transparency ON events
+    WHEN NEW.recurrence = '' AND NOT NEW.cancelled AND NEW.transparency NOT IN ('free', 'tentative')
+BEGIN
+    SELECT RAISE(ABORT, 'events overlap')
+    WHERE EXISTS(SELECT 1 FROM events
+                 WHERE owner = NEW.owner AND id <> NEW.id AND recurrence = '' AND NOT cancelled
+                   AND transparency NOT IN ('free', 'tentative')
+                   AND start_time < NEW.end_time AND end_time > NEW.start_time);
+END;
@@ -0,0 +1,23 @@
+alter table events
+    add all_day boolean not null default false;
+-- all-day events are dates, they don't block bookings of any time of the day
+DROP TRIGGER events_overlap_insert;
+DROP TRIGGER events_overlap_update;
+CREATE TRIGGER events_overlap_insert BEFORE INSERT ON events
+    WHEN NEW.recurrence = '' AND NOT NEW.cancelled AND NOT NEW.all_day AND NEW.transparency NOT IN ('free', 'tentative')
+BEGIN
+    SELECT RAISE(ABORT, 'events overlap')
+    WHERE EXISTS(SELECT 1 FROM events
+                 WHERE owner = NEW.owner AND id <> NEW.id AND recurrence = '' AND NOT cancelled AND NOT all_day
+                   AND transparency NOT IN ('free', 'tentative')
+                   AND start_time < NEW.end_time AND end_time > NEW.start_time);
+END;
+CREATE TRIGGER events_overlap_update BEFORE UPDATE OF start_time, end_time, recurrence, cancelled, transparency, all_day ON events
+    WHEN NEW.recurrence = '' AND NOT NEW.cancelled AND NOT NEW.all_day AND NEW.transparency NOT IN ('free', 'tentative')
+BEGIN
+    SELECT RAISE(ABORT, 'events overlap')
+    WHERE EXISTS(SELECT 1 FROM events
+                 WHERE owner = NEW.owner AND id <> NEW.id AND recurrence = '' AND NOT cancelled AND NOT all_day
+                   AND transparency NOT IN ('free', 'tentative')
+                   AND start_time < NEW.end_time AND end_time > NEW.start_time);
+END;
@@ -0,0 +1,2 @@
+alter table events
+    drop column time_zone;
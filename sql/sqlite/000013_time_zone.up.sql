@@ -0,0 +1,2 @@
+alter table events
+    add time_zone text not null default '';
@@ -0,0 +1,18 @@
+-- reminders are offsets in seconds before start, e.g. 86400,900,0
+alter table events
+    add reminders text not null default '';
+-- delivery state of every reminder of event or occurrence, reminder is offset in seconds
+create table reminder_notifications (
+                        event_id UUID not null references events (id) on delete cascade,
+                        start_time timestamp not null,
+                        reminder bigint not null,
+                        primary key (event_id, start_time, reminder)
+);
+-- notifications were sent a day before start
+insert into reminder_notifications(event_id, start_time, reminder)
+    select id, start_time, 86400 from events where notified and recurrence = '';
+insert into reminder_notifications(event_id, start_time, reminder)
+    select event_id, start_time, 86400 from event_occurrence_notifications;
+drop table event_occurrence_notifications;
+alter table events
+    drop column notified;
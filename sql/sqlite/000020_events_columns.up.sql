@@ -0,0 +1,50 @@
+-- columns of events are aligned with PostgreSQL, end_time is required since 000010 and uid has no default.
+-- SQLite can't change constraints of existing columns, so events table is rebuilt like in 000019
+UPDATE events SET end_time = start_time WHERE end_time IS NULL;
+UPDATE events SET uid = id WHERE uid = '';
+CREATE TABLE events_rebuilt (
+                        id UUID primary key,
+                        owner text not null,
+                        title text not null,
+                        text text,
+                        start_time timestamp not null,
+                        end_time timestamp not null,
+                        recurrence text not null default '',
+                        series_id UUID references events (id) on delete cascade,
+                        original_start_time timestamp,
+                        cancelled bool not null default false,
+                        uid text not null,
+                        transparency text not null default 'busy',
+                        all_day boolean not null default false,
+                        time_zone text not null default '',
+                        reminders text not null default '',
+                        series_end timestamp
+);
+INSERT INTO events_rebuilt(id, owner, title, text, start_time, end_time, recurrence, series_id, original_start_time, cancelled,
+                           uid, transparency, all_day, time_zone, reminders, series_end)
+SELECT id, owner, title, text, start_time, end_time, recurrence, series_id, original_start_time, cancelled,
+       uid, transparency, all_day, time_zone, reminders, series_end
+FROM events;
+DROP TABLE events;
+ALTER TABLE events_rebuilt RENAME TO events;
+CREATE INDEX owner_start_time_idx ON events (owner, start_time);
+CREATE UNIQUE INDEX series_original_start_time_idx ON events (series_id, original_start_time);
+CREATE UNIQUE INDEX owner_uid_idx ON events (owner, uid);
+CREATE TRIGGER events_overlap_insert BEFORE INSERT ON events
+    WHEN NEW.recurrence = '' AND NOT NEW.cancelled AND NOT NEW.all_day AND NEW.transparency NOT IN ('free', 'tentative')
+BEGIN
+    SELECT RAISE(ABORT, 'events overlap')
+    WHERE EXISTS(SELECT 1 FROM events
+                 WHERE owner = NEW.owner AND id <> NEW.id AND recurrence = '' AND NOT cancelled AND NOT all_day
+                   AND transparency NOT IN ('free', 'tentative')
+                   AND start_time < NEW.end_time AND end_time > NEW.start_time);
+END;
+CREATE TRIGGER events_overlap_update BEFORE UPDATE OF start_time, end_time, recurrence, cancelled, transparency, all_day ON events
+    WHEN NEW.recurrence = '' AND NOT NEW.cancelled AND NOT NEW.all_day AND NEW.transparency NOT IN ('free', 'tentative')
+BEGIN
+    SELECT RAISE(ABORT, 'events overlap')
+    WHERE EXISTS(SELECT 1 FROM events
+                 WHERE owner = NEW.owner AND id <> NEW.id AND recurrence = '' AND NOT cancelled AND NOT all_day
+                   AND transparency NOT IN ('free', 'tentative')
+                   AND start_time < NEW.end_time AND end_time > NEW.start_time);
+END;